@@ -4,12 +4,17 @@ package client
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
 	"net/http"
+	"path"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/branow/mcp-bitbucket/internal/util"
+	"github.com/branow/mcp-bitbucket/internal/util/bbql"
 	"github.com/branow/mcp-bitbucket/internal/util/web"
 )
 
@@ -95,24 +100,33 @@ func (c *Client) DeleteRepository(ctx context.Context, workspaceSlug string, rep
 // Parameters:
 //   - ctx: Context for the request
 //   - workspaceSlug: The workspace slug identifier
-//   - pagelen: Number of items per page
+//   - pagelen: Number of items per page (maximum 100)
 //   - page: Page number to retrieve (1-indexed)
+//   - role: Only repositories the authenticated user has this role in (member, contributor, admin, or owner). Empty applies no filter.
+//   - query: Optional BBQL filter and sort order (e.g., `project.key = "PAY"` sorted by "-updated_on"). Nil applies no filter.
 //
 // Returns the API response containing the list of repositories and pagination metadata.
-func (c *Client) ListRepositories(ctx context.Context, workspaceSlug string, pagelen int, page int) (*ApiResponse[Repository], error) {
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-repositories/#api-repositories-workspace-get
+func (c *Client) ListRepositories(ctx context.Context, workspaceSlug string, pagelen int, page int, role string, query *bbql.Query) (*ApiResponse[Repository], error) {
 	resp := &BitbucketResponse[ApiResponse[Repository]]{
 		Body: &ApiResponse[Repository]{},
 		Mime: web.MimeApplicationJson,
 	}
 
+	params := query.Params()
+	params["pagelen"] = strconv.Itoa(pagelen)
+	params["page"] = strconv.Itoa(page)
+
+	if role != "" {
+		params["role"] = role
+	}
+
 	req := prepare(c, ctx, &BitbucketRequest[any]{
 		Method: "GET",
 		Path:   []string{"repositories", workspaceSlug},
-		Query: map[string]string{
-			"pagelen": strconv.Itoa(pagelen),
-			"page":    strconv.Itoa(page),
-		},
-		Mime: web.MimeOmit,
+		Query:  params,
+		Mime:   web.MimeOmit,
 	})
 
 	if err := Perform(req, resp); err != nil {
@@ -174,6 +188,7 @@ func (c *Client) GetRepositorySource(ctx context.Context, workspaceSlug string,
 }
 
 // ListPullRequests retrieves a paginated list of pull requests for the specified repository.
+// The reviewers and participants of each pull request are included.
 //
 // Parameters:
 //   - ctx: Context for the request
@@ -182,27 +197,30 @@ func (c *Client) GetRepositorySource(ctx context.Context, workspaceSlug string,
 //   - pagelen: Number of items per page (maximum 50)
 //   - page: Page number to retrieve (1-indexed)
 //   - states: Filter by pull request states (e.g., "OPEN", "MERGED", "DECLINED"). Empty slice returns all states.
+//   - query: Optional BBQL filter and sort order (e.g., `author.nickname = "john"` sorted by "-updated_on"). Nil applies no filter.
 //
 // Returns the API response containing the list of pull requests and pagination metadata.
-func (c *Client) ListPullRequests(ctx context.Context, workspaceSlug string, repoSlug string, pagelen int, page int, states []string) (*ApiResponse[PullRequest], error) {
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-get
+func (c *Client) ListPullRequests(ctx context.Context, workspaceSlug string, repoSlug string, pagelen int, page int, states []string, query *bbql.Query) (*ApiResponse[PullRequest], error) {
 	resp := &BitbucketResponse[ApiResponse[PullRequest]]{
 		Body: &ApiResponse[PullRequest]{},
 		Mime: web.MimeApplicationJson,
 	}
 
-	query := map[string]string{
-		"pagelen": strconv.Itoa(pagelen),
-		"page":    strconv.Itoa(page),
-	}
+	params := query.Params()
+	params["pagelen"] = strconv.Itoa(pagelen)
+	params["page"] = strconv.Itoa(page)
+	params["fields"] = "+values.reviewers,+values.participants"
 
 	if len(states) > 0 {
-		query["state"] = strings.Join(states, ",")
+		params["state"] = strings.Join(states, ",")
 	}
 
 	req := prepare(c, ctx, &BitbucketRequest[any]{
 		Method: "GET",
 		Path:   []string{"repositories", workspaceSlug, repoSlug, "pullrequests"},
-		Query:  query,
+		Query:  params,
 		Mime:   web.MimeOmit,
 	})
 
@@ -326,6 +344,162 @@ func (c *Client) GetPullRequestDiff(ctx context.Context, workspaceSlug string, r
 	return resp.Body, nil
 }
 
+// ListCommits retrieves a paginated list of commits of a repository, newest first.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - pagelen: Number of items per page (maximum 100)
+//   - page: Page number to retrieve (1-indexed)
+//   - include: Branch, tag, or commit hash whose history to list. Empty lists all branches.
+//   - exclude: Branch, tag, or commit hash whose history to leave out. Empty excludes nothing.
+//   - path: File or directory path whose changes to list. Empty lists all commits.
+//
+// Returns the API response containing the list of commits with their hash, message, author, and parents.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-commits/#api-repositories-workspace-repo-slug-commits-get
+func (c *Client) ListCommits(ctx context.Context, workspaceSlug string, repoSlug string, pagelen int, page int, include string, exclude string, path string) (*ApiResponse[Commit], error) {
+	resp := &BitbucketResponse[ApiResponse[Commit]]{
+		Body: &ApiResponse[Commit]{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	params := map[string]string{
+		"pagelen": strconv.Itoa(pagelen),
+		"page":    strconv.Itoa(page),
+	}
+	if include != "" {
+		params["include"] = include
+	}
+	if exclude != "" {
+		params["exclude"] = exclude
+	}
+	if path != "" {
+		params["path"] = path
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "commits"},
+		Query:  params,
+		Mime:   web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// GetCommit retrieves a single commit of a repository.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - commit: The commit hash, full or abbreviated
+//
+// Returns the commit with its hash, message, author, date, and parents.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-commits/#api-repositories-workspace-repo-slug-commit-commit-get
+func (c *Client) GetCommit(ctx context.Context, workspaceSlug string, repoSlug string, commit string) (*Commit, error) {
+	resp := &BitbucketResponse[Commit]{
+		Body: &Commit{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "commit", commit},
+		Mime:   web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// GetDiff retrieves the unified diff of a commit or between two revisions.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - spec: A commit hash to diff against its first parent, or two revisions in the form "a..b"
+//     to diff the changes of a that are not in b
+//   - path: File path to limit the diff to. Empty includes all files.
+//
+// Returns the diff content as a plain text string in unified diff format.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-commits/#api-repositories-workspace-repo-slug-diff-spec-get
+func (c *Client) GetDiff(ctx context.Context, workspaceSlug string, repoSlug string, spec string, path string) (*string, error) {
+	resp := &BitbucketResponse[string]{
+		Body: new(string),
+		Mime: web.MimeTextPlain,
+	}
+
+	params := map[string]string{}
+	if path != "" {
+		params["path"] = path
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "diff", spec},
+		Query:  params,
+		Mime:   web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// GetDiffStat retrieves a paginated list of the files changed in a commit or between two revisions
+// with the number of added and removed lines.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - spec: A commit hash to compare with its first parent, or two revisions in the form "a..b"
+//   - path: File path to limit the changes to. Empty includes all files.
+//   - pagelen: Number of items per page (maximum 500)
+//   - page: Page number to retrieve (1-indexed)
+//
+// Returns the API response containing the changed files and their status.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-commits/#api-repositories-workspace-repo-slug-diffstat-spec-get
+func (c *Client) GetDiffStat(ctx context.Context, workspaceSlug string, repoSlug string, spec string, path string, pagelen int, page int) (*ApiResponse[DiffStat], error) {
+	resp := &BitbucketResponse[ApiResponse[DiffStat]]{
+		Body: &ApiResponse[DiffStat]{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	params := map[string]string{
+		"pagelen": strconv.Itoa(pagelen),
+		"page":    strconv.Itoa(page),
+	}
+	if path != "" {
+		params["path"] = path
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "diffstat", spec},
+		Query:  params,
+		Mime:   web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
 // GetFileSource retrieves the raw content of a file at a specific commit.
 //
 // Parameters:
@@ -519,32 +693,61 @@ func (c *Client) GetBranch(ctx context.Context, workspaceSlug string, repoSlug s
 	return resp.Body, nil
 }
 
-// CreatePullRequest creates a new pull request in the specified repository.
+// DeleteBranch deletes a branch from the specified repository.
 //
 // Parameters:
 //   - ctx: Context for the request
 //   - workspaceSlug: The workspace slug identifier
 //   - repoSlug: The repository slug identifier
-//   - body: Request configuration including title, source branch, and optional fields
+//   - branchName: The name of the branch to delete
 //
-// The minimum required fields are title and source branch name.
-// If destination is not specified, it defaults to the repository's main branch.
-// Optional fields include description, close_source_branch, draft, and reviewers.
+// The main branch cannot be deleted.
 //
-// Returns the created pull request object with full details.
+// Returns an error if the deletion fails.
 //
-// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-post
-func (c *Client) CreatePullRequest(ctx context.Context, workspaceSlug string, repoSlug string, body *CreatePullRequestRequest) (*PullRequest, error) {
-	resp := &BitbucketResponse[PullRequest]{
-		Body: &PullRequest{},
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-refs/#api-repositories-workspace-repo-slug-refs-branches-name-delete
+func (c *Client) DeleteBranch(ctx context.Context, workspaceSlug string, repoSlug string, branchName string) error {
+	resp := &BitbucketResponse[any]{
+		Mime: web.MimeOmit,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "DELETE",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "refs", "branches", branchName},
+		Mime:   web.MimeOmit,
+	})
+
+	return Perform(req, resp)
+}
+
+// ListBranches retrieves a paginated list of branches of the specified repository.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - pagelen: Number of items per page
+//   - page: Page number to retrieve (1-indexed)
+//   - query: Optional BBQL filter and sort order. Nil applies no filter.
+//
+// Returns the API response containing the list of branches with their target commits.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-refs/#api-repositories-workspace-repo-slug-refs-branches-get
+func (c *Client) ListBranches(ctx context.Context, workspaceSlug string, repoSlug string, pagelen int, page int, query *bbql.Query) (*ApiResponse[Branch], error) {
+	resp := &BitbucketResponse[ApiResponse[Branch]]{
+		Body: &ApiResponse[Branch]{},
 		Mime: web.MimeApplicationJson,
 	}
 
-	req := prepare(c, ctx, &BitbucketRequest[CreatePullRequestRequest]{
-		Method: "POST",
-		Path:   []string{"repositories", workspaceSlug, repoSlug, "pullrequests"},
-		Body:   body,
-		Mime:   web.MimeApplicationJson,
+	params := query.Params()
+	params["pagelen"] = strconv.Itoa(pagelen)
+	params["page"] = strconv.Itoa(page)
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "refs", "branches"},
+		Query:  params,
+		Mime:   web.MimeOmit,
 	})
 
 	if err := Perform(req, resp); err != nil {
@@ -553,30 +756,64 @@ func (c *Client) CreatePullRequest(ctx context.Context, workspaceSlug string, re
 	return resp.Body, nil
 }
 
-// CreatePullRequestComment creates a new comment on a specific pull request.
+// ListTags retrieves a paginated list of tags of the specified repository.
 //
 // Parameters:
 //   - ctx: Context for the request
 //   - workspaceSlug: The workspace slug identifier
 //   - repoSlug: The repository slug identifier
-//   - pullRequestId: The pull request ID number
-//   - body: Request configuration including comment content and optional inline/parent references
+//   - pagelen: Number of items per page
+//   - page: Page number to retrieve (1-indexed)
+//   - query: Optional BBQL filter and sort order. Nil applies no filter.
 //
-// The minimum required field is content.raw with the comment text.
-// Optional fields include inline (for code line comments) and parent (for reply comments).
+// Returns the API response containing the list of tags with their target commits.
 //
-// Returns the created comment object with full details.
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-refs/#api-repositories-workspace-repo-slug-refs-tags-get
+func (c *Client) ListTags(ctx context.Context, workspaceSlug string, repoSlug string, pagelen int, page int, query *bbql.Query) (*ApiResponse[Tag], error) {
+	resp := &BitbucketResponse[ApiResponse[Tag]]{
+		Body: &ApiResponse[Tag]{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	params := query.Params()
+	params["pagelen"] = strconv.Itoa(pagelen)
+	params["page"] = strconv.Itoa(page)
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "refs", "tags"},
+		Query:  params,
+		Mime:   web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// CreateTag creates a new tag in the specified repository.
 //
-// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-comments-post
-func (c *Client) CreatePullRequestComment(ctx context.Context, workspaceSlug string, repoSlug string, pullRequestId int, body *CreatePullRequestCommentRequest) (*PullRequestComment, error) {
-	resp := &BitbucketResponse[PullRequestComment]{
-		Body: &PullRequestComment{},
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - body: Request configuration including tag name, target commit hash, and optional message
+//
+// A tag with a message is created as an annotated tag, otherwise as a lightweight one.
+//
+// Returns the created tag object.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-refs/#api-repositories-workspace-repo-slug-refs-tags-post
+func (c *Client) CreateTag(ctx context.Context, workspaceSlug string, repoSlug string, body *CreateTagRequest) (*Tag, error) {
+	resp := &BitbucketResponse[Tag]{
+		Body: &Tag{},
 		Mime: web.MimeApplicationJson,
 	}
 
-	req := prepare(c, ctx, &BitbucketRequest[CreatePullRequestCommentRequest]{
+	req := prepare(c, ctx, &BitbucketRequest[CreateTagRequest]{
 		Method: "POST",
-		Path:   []string{"repositories", workspaceSlug, repoSlug, "pullrequests", strconv.Itoa(pullRequestId), "comments"},
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "refs", "tags"},
 		Body:   body,
 		Mime:   web.MimeApplicationJson,
 	})
@@ -587,30 +824,60 @@ func (c *Client) CreatePullRequestComment(ctx context.Context, workspaceSlug str
 	return resp.Body, nil
 }
 
-// MergePullRequest merges a pull request.
+// DeleteTag deletes a tag from the specified repository.
 //
 // Parameters:
 //   - ctx: Context for the request
 //   - workspaceSlug: The workspace slug identifier
 //   - repoSlug: The repository slug identifier
-//   - pullRequestId: The pull request ID number
-//   - body: Request configuration including type, message, close_source_branch, and merge_strategy
+//   - tagName: The name of the tag to delete
 //
-// The type field is required. Other fields are optional.
-// Returns the updated pull request object with state changed to "MERGED".
+// Returns an error if the deletion fails.
 //
-// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-merge-post
-func (c *Client) MergePullRequest(ctx context.Context, workspaceSlug string, repoSlug string, pullRequestId int, body *MergePullRequestRequest) (*PullRequest, error) {
-	resp := &BitbucketResponse[PullRequest]{
-		Body: &PullRequest{},
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-refs/#api-repositories-workspace-repo-slug-refs-tags-name-delete
+func (c *Client) DeleteTag(ctx context.Context, workspaceSlug string, repoSlug string, tagName string) error {
+	resp := &BitbucketResponse[any]{
+		Mime: web.MimeOmit,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "DELETE",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "refs", "tags", tagName},
+		Mime:   web.MimeOmit,
+	})
+
+	return Perform(req, resp)
+}
+
+// ListRefs retrieves a paginated list of both branches and tags of the specified repository.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - pagelen: Number of items per page
+//   - page: Page number to retrieve (1-indexed)
+//   - query: Optional BBQL filter and sort order. Nil applies no filter.
+//
+// Returns the API response containing the list of refs, distinguished by their type ("branch" or "tag").
+// Branches are decoded as tags without a message and tagger.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-refs/#api-repositories-workspace-repo-slug-refs-get
+func (c *Client) ListRefs(ctx context.Context, workspaceSlug string, repoSlug string, pagelen int, page int, query *bbql.Query) (*ApiResponse[Tag], error) {
+	resp := &BitbucketResponse[ApiResponse[Tag]]{
+		Body: &ApiResponse[Tag]{},
 		Mime: web.MimeApplicationJson,
 	}
 
-	req := prepare(c, ctx, &BitbucketRequest[MergePullRequestRequest]{
-		Method: "POST",
-		Path:   []string{"repositories", workspaceSlug, repoSlug, "pullrequests", strconv.Itoa(pullRequestId), "merge"},
-		Body:   body,
-		Mime:   web.MimeApplicationJson,
+	params := query.Params()
+	params["pagelen"] = strconv.Itoa(pagelen)
+	params["page"] = strconv.Itoa(page)
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "refs"},
+		Query:  params,
+		Mime:   web.MimeOmit,
 	})
 
 	if err := Perform(req, resp); err != nil {
@@ -619,27 +886,32 @@ func (c *Client) MergePullRequest(ctx context.Context, workspaceSlug string, rep
 	return resp.Body, nil
 }
 
-// DeclinePullRequest declines a pull request.
+// CreatePullRequest creates a new pull request in the specified repository.
 //
 // Parameters:
 //   - ctx: Context for the request
 //   - workspaceSlug: The workspace slug identifier
 //   - repoSlug: The repository slug identifier
-//   - pullRequestId: The pull request ID number
+//   - body: Request configuration including title, source branch, and optional fields
 //
-// Returns the updated pull request object with state changed to "DECLINED".
+// The minimum required fields are title and source branch name.
+// If destination is not specified, it defaults to the repository's main branch.
+// Optional fields include description, close_source_branch, draft, and reviewers.
 //
-// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-decline-post
-func (c *Client) DeclinePullRequest(ctx context.Context, workspaceSlug string, repoSlug string, pullRequestId int) (*PullRequest, error) {
+// Returns the created pull request object with full details.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-post
+func (c *Client) CreatePullRequest(ctx context.Context, workspaceSlug string, repoSlug string, body *CreatePullRequestRequest) (*PullRequest, error) {
 	resp := &BitbucketResponse[PullRequest]{
 		Body: &PullRequest{},
 		Mime: web.MimeApplicationJson,
 	}
 
-	req := prepare(c, ctx, &BitbucketRequest[any]{
+	req := prepare(c, ctx, &BitbucketRequest[CreatePullRequestRequest]{
 		Method: "POST",
-		Path:   []string{"repositories", workspaceSlug, repoSlug, "pullrequests", strconv.Itoa(pullRequestId), "decline"},
-		Mime:   web.MimeOmit,
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "pullrequests"},
+		Body:   body,
+		Mime:   web.MimeApplicationJson,
 	})
 
 	if err := Perform(req, resp); err != nil {
@@ -648,6 +920,1599 @@ func (c *Client) DeclinePullRequest(ctx context.Context, workspaceSlug string, r
 	return resp.Body, nil
 }
 
+// UpdatePullRequest updates the title, description, destination branch, reviewers,
+// and flags of an existing pull request.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - pullRequestId: The pull request ID number
+//   - body: Request configuration including title, reviewers, and optional fields
+//
+// The title is required. The reviewers list replaces the current reviewers,
+// so it must contain every reviewer that should remain on the pull request.
+//
+// Returns the updated pull request object with full details.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-put
+func (c *Client) UpdatePullRequest(ctx context.Context, workspaceSlug string, repoSlug string, pullRequestId int, body *UpdatePullRequestRequest) (*PullRequest, error) {
+	resp := &BitbucketResponse[PullRequest]{
+		Body: &PullRequest{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[UpdatePullRequestRequest]{
+		Method: "PUT",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "pullrequests", strconv.Itoa(pullRequestId)},
+		Body:   body,
+		Mime:   web.MimeApplicationJson,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// CreatePullRequestComment creates a new comment on a specific pull request.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - pullRequestId: The pull request ID number
+//   - body: Request configuration including comment content and optional inline/parent references
+//
+// The minimum required field is content.raw with the comment text.
+// Optional fields include inline (for code line comments) and parent (for reply comments).
+//
+// Returns the created comment object with full details.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-comments-post
+func (c *Client) CreatePullRequestComment(ctx context.Context, workspaceSlug string, repoSlug string, pullRequestId int, body *CreatePullRequestCommentRequest) (*PullRequestComment, error) {
+	resp := &BitbucketResponse[PullRequestComment]{
+		Body: &PullRequestComment{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[CreatePullRequestCommentRequest]{
+		Method: "POST",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "pullrequests", strconv.Itoa(pullRequestId), "comments"},
+		Body:   body,
+		Mime:   web.MimeApplicationJson,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// MergePullRequest merges a pull request.
+// Bitbucket merges synchronously unless the merge takes too long, in which case it
+// accepts the merge with 202 and continues it in a task whose status is polled with
+// GetMergeTaskStatus.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - pullRequestId: The pull request ID number
+//   - body: Request configuration including type, message, close_source_branch, and merge_strategy
+//
+// The type field is required. Other fields are optional.
+// Returns the merged pull request with state changed to "MERGED",
+// or the ID of the merge task if the merge continues asynchronously.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-merge-post
+func (c *Client) MergePullRequest(ctx context.Context, workspaceSlug string, repoSlug string, pullRequestId int, body *MergePullRequestRequest) (*PullRequestMerge, error) {
+	resp := &BitbucketResponse[PullRequest]{
+		Body:     &PullRequest{},
+		Mime:     web.MimeApplicationJson,
+		Accepted: true,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[MergePullRequestRequest]{
+		Method: "POST",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "pullrequests", strconv.Itoa(pullRequestId), "merge"},
+		Body:   body,
+		Mime:   web.MimeApplicationJson,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	if resp.Status == http.StatusAccepted {
+		location := resp.Header.Get("Location")
+		if location == "" {
+			slog.Error("Merge task status location is missing", "pullRequestId", pullRequestId)
+			return nil, util.NewInternalError()
+		}
+		return &PullRequestMerge{TaskID: path.Base(location)}, nil
+	}
+	return &PullRequestMerge{PullRequest: resp.Body}, nil
+}
+
+// GetMergeTaskStatus retrieves the status of an asynchronous pull request merge.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - pullRequestId: The pull request ID number
+//   - taskId: The merge task ID returned by MergePullRequest
+//
+// Returns the task status, "PENDING" while the merge is in progress and "SUCCESS" once it completes,
+// with the merged pull request as the merge result.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-merge-task-status-task-id-get
+func (c *Client) GetMergeTaskStatus(ctx context.Context, workspaceSlug string, repoSlug string, pullRequestId int, taskId string) (*MergeTaskStatus, error) {
+	resp := &BitbucketResponse[MergeTaskStatus]{
+		Body: &MergeTaskStatus{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "pullrequests", strconv.Itoa(pullRequestId), "merge", "task-status", taskId},
+		Mime:   web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// DeclinePullRequest declines a pull request.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - pullRequestId: The pull request ID number
+//
+// Returns the updated pull request object with state changed to "DECLINED".
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-decline-post
+func (c *Client) DeclinePullRequest(ctx context.Context, workspaceSlug string, repoSlug string, pullRequestId int) (*PullRequest, error) {
+	resp := &BitbucketResponse[PullRequest]{
+		Body: &PullRequest{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "POST",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "pullrequests", strconv.Itoa(pullRequestId), "decline"},
+		Mime:   web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// GetPullRequestDiffStat retrieves a paginated list of the files changed by a pull request
+// with the number of added and removed lines. Files with conflicts between the source and
+// destination branches have the "merge conflict" status.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - pullRequestId: The pull request ID number
+//   - pagelen: Number of items per page (maximum 500)
+//   - page: Page number to retrieve (1-indexed)
+//
+// Returns the API response containing the changed files and their status.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-diffstat-get
+func (c *Client) GetPullRequestDiffStat(ctx context.Context, workspaceSlug string, repoSlug string, pullRequestId int, pagelen int, page int) (*ApiResponse[DiffStat], error) {
+	resp := &BitbucketResponse[ApiResponse[DiffStat]]{
+		Body: &ApiResponse[DiffStat]{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "pullrequests", strconv.Itoa(pullRequestId), "diffstat"},
+		Query: map[string]string{
+			"pagelen": strconv.Itoa(pagelen),
+			"page":    strconv.Itoa(page),
+		},
+		Mime: web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// ListPullRequestStatuses retrieves a paginated list of the build statuses reported
+// for the commits of a pull request.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - pullRequestId: The pull request ID number
+//   - pagelen: Number of items per page (maximum 100)
+//   - page: Page number to retrieve (1-indexed)
+//
+// Returns the API response containing the build statuses with their state.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-statuses-get
+func (c *Client) ListPullRequestStatuses(ctx context.Context, workspaceSlug string, repoSlug string, pullRequestId int, pagelen int, page int) (*ApiResponse[CommitStatus], error) {
+	resp := &BitbucketResponse[ApiResponse[CommitStatus]]{
+		Body: &ApiResponse[CommitStatus]{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "pullrequests", strconv.Itoa(pullRequestId), "statuses"},
+		Query: map[string]string{
+			"pagelen": strconv.Itoa(pagelen),
+			"page":    strconv.Itoa(page),
+		},
+		Mime: web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// ListVisiblePullRequestStatuses retrieves a page of the build statuses of a pull request like
+// ListPullRequestStatuses, but returns an empty page instead of an error if the statuses
+// cannot be read (403 or 404), e.g. when the source repository of a fork is not accessible.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - pullRequestId: The pull request ID number
+//   - pagelen: Number of items per page (maximum 100)
+//   - page: Page number to retrieve (1-indexed)
+//
+// Returns the API response containing the build statuses with their state.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-statuses-get
+func (c *Client) ListVisiblePullRequestStatuses(ctx context.Context, workspaceSlug string, repoSlug string, pullRequestId int, pagelen int, page int) (*ApiResponse[CommitStatus], error) {
+	resp := &BitbucketResponse[ApiResponse[CommitStatus]]{
+		Body: &ApiResponse[CommitStatus]{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "pullrequests", strconv.Itoa(pullRequestId), "statuses"},
+		Query: map[string]string{
+			"pagelen": strconv.Itoa(pagelen),
+			"page":    strconv.Itoa(page),
+		},
+		Mime: web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		if resp.Status == http.StatusForbidden || resp.Status == http.StatusNotFound {
+			slog.Warn("Pull request build statuses are not accessible",
+				util.NewLogArgsExtractor().AddError(err).AddPullRequest(workspaceSlug, repoSlug, pullRequestId).Extract()...)
+			return &ApiResponse[CommitStatus]{}, nil
+		}
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// ListCommitStatuses retrieves a paginated list of the build statuses reported for a commit.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - commit: The commit hash
+//   - pagelen: Number of items per page (maximum 100)
+//   - page: Page number to retrieve (1-indexed)
+//
+// Returns the API response containing the build statuses with their state.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-commit-statuses/#api-repositories-workspace-repo-slug-commit-commit-statuses-get
+func (c *Client) ListCommitStatuses(ctx context.Context, workspaceSlug string, repoSlug string, commit string, pagelen int, page int) (*ApiResponse[CommitStatus], error) {
+	resp := &BitbucketResponse[ApiResponse[CommitStatus]]{
+		Body: &ApiResponse[CommitStatus]{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "commit", commit, "statuses"},
+		Query: map[string]string{
+			"pagelen": strconv.Itoa(pagelen),
+			"page":    strconv.Itoa(page),
+		},
+		Mime: web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// CreateBuildStatus reports a build status for a commit.
+// If a build status with the same key already exists for the commit, it is overwritten.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - commit: The commit hash
+//   - body: Request configuration including the key, state, name, and URL of the build
+//
+// Returns the created or updated build status.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-commit-statuses/#api-repositories-workspace-repo-slug-commit-commit-statuses-build-post
+func (c *Client) CreateBuildStatus(ctx context.Context, workspaceSlug string, repoSlug string, commit string, body *CreateBuildStatusRequest) (*CommitStatus, error) {
+	resp := &BitbucketResponse[CommitStatus]{
+		Body: &CommitStatus{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[CreateBuildStatusRequest]{
+		Method: "POST",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "commit", commit, "statuses", "build"},
+		Body:   body,
+		Mime:   web.MimeApplicationJson,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// CreateOrUpdateReport creates a Code Insights report for a commit,
+// or replaces the report with the same ID.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - commit: The commit hash
+//   - reportID: The external ID of the report, unique per commit
+//   - body: Request configuration including the title, type, result, and data of the report
+//
+// Returns the created or updated report.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-reports/#api-repositories-workspace-repo-slug-commit-commit-reports-reportid-put
+func (c *Client) CreateOrUpdateReport(ctx context.Context, workspaceSlug string, repoSlug string, commit string, reportID string, body *CreateReportRequest) (*Report, error) {
+	resp := &BitbucketResponse[Report]{
+		Body: &Report{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[CreateReportRequest]{
+		Method: "PUT",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "commit", commit, "reports", reportID},
+		Body:   body,
+		Mime:   web.MimeApplicationJson,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// ListReports retrieves a paginated list of the Code Insights reports of a commit.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - commit: The commit hash
+//   - pagelen: Number of items per page (maximum 100)
+//   - page: Page number to retrieve (1-indexed)
+//
+// Returns the API response containing the reports.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-reports/#api-repositories-workspace-repo-slug-commit-commit-reports-get
+func (c *Client) ListReports(ctx context.Context, workspaceSlug string, repoSlug string, commit string, pagelen int, page int) (*ApiResponse[Report], error) {
+	resp := &BitbucketResponse[ApiResponse[Report]]{
+		Body: &ApiResponse[Report]{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "commit", commit, "reports"},
+		Query: map[string]string{
+			"pagelen": strconv.Itoa(pagelen),
+			"page":    strconv.Itoa(page),
+		},
+		Mime: web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// ListAnnotations retrieves a paginated list of the annotations of a Code Insights report.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - commit: The commit hash
+//   - reportID: The external ID or UUID of the report
+//   - pagelen: Number of items per page (maximum 100)
+//   - page: Page number to retrieve (1-indexed)
+//
+// Returns the API response containing the annotations.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-reports/#api-repositories-workspace-repo-slug-commit-commit-reports-reportid-annotations-get
+func (c *Client) ListAnnotations(ctx context.Context, workspaceSlug string, repoSlug string, commit string, reportID string, pagelen int, page int) (*ApiResponse[Annotation], error) {
+	resp := &BitbucketResponse[ApiResponse[Annotation]]{
+		Body: &ApiResponse[Annotation]{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "commit", commit, "reports", reportID, "annotations"},
+		Query: map[string]string{
+			"pagelen": strconv.Itoa(pagelen),
+			"page":    strconv.Itoa(page),
+		},
+		Mime: web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// CreateAnnotations creates or updates annotations of a Code Insights report in bulk.
+// Annotations are matched by their external ID; at most 100 annotations can be sent at once.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - commit: The commit hash
+//   - reportID: The external ID or UUID of the report
+//   - body: The annotations with their path, line, severity, and summary
+//
+// Returns the created annotations.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-reports/#api-repositories-workspace-repo-slug-commit-commit-reports-reportid-annotations-post
+func (c *Client) CreateAnnotations(ctx context.Context, workspaceSlug string, repoSlug string, commit string, reportID string, body []CreateAnnotationRequest) ([]Annotation, error) {
+	resp := &BitbucketResponse[[]Annotation]{
+		Body: &[]Annotation{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[[]CreateAnnotationRequest]{
+		Method: "POST",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "commit", commit, "reports", reportID, "annotations"},
+		Body:   &body,
+		Mime:   web.MimeApplicationJson,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return *resp.Body, nil
+}
+
+// ListPullRequestActivity retrieves a page of the activity log of a specific pull request:
+// updates, approvals, change requests, and comments, newest first.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - pullRequestId: The pull request ID number
+//   - pagelen: Number of items per page (maximum 50)
+//   - cursor: The "ctx" parameter of the next page link, or empty for the first page
+//
+// The activity log is paginated with an opaque cursor instead of page numbers,
+// so the next page is requested with the "ctx" parameter taken from the next link.
+//
+// Returns the API response containing the activity entries and the link to the next page.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-activity-get
+func (c *Client) ListPullRequestActivity(ctx context.Context, workspaceSlug string, repoSlug string, pullRequestId int, pagelen int, cursor string) (*ApiResponse[PullRequestActivity], error) {
+	resp := &BitbucketResponse[ApiResponse[PullRequestActivity]]{
+		Body: &ApiResponse[PullRequestActivity]{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	params := map[string]string{
+		"pagelen": strconv.Itoa(pagelen),
+	}
+	if cursor != "" {
+		params["ctx"] = cursor
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "pullrequests", strconv.Itoa(pullRequestId), "activity"},
+		Query:  params,
+		Mime:   web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// ListPullRequestTasks retrieves a paginated list of tasks on a specific pull request.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - pullRequestId: The pull request ID number
+//   - pagelen: Number of items per page (maximum 100)
+//   - page: Page number to retrieve (1-indexed)
+//
+// Returns the API response containing the list of tasks with their content, state, and creator.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-tasks-get
+func (c *Client) ListPullRequestTasks(ctx context.Context, workspaceSlug string, repoSlug string, pullRequestId int, pagelen int, page int) (*ApiResponse[PullRequestTask], error) {
+	resp := &BitbucketResponse[ApiResponse[PullRequestTask]]{
+		Body: &ApiResponse[PullRequestTask]{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "pullrequests", strconv.Itoa(pullRequestId), "tasks"},
+		Query: map[string]string{
+			"pagelen": strconv.Itoa(pagelen),
+			"page":    strconv.Itoa(page),
+		},
+		Mime: web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// CreatePullRequestTask creates a new task on a pull request, optionally attached to a comment.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - pullRequestId: The pull request ID number
+//   - body: Task content and optional comment reference
+//
+// Returns the created task with its ID and state.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-tasks-post
+func (c *Client) CreatePullRequestTask(ctx context.Context, workspaceSlug string, repoSlug string, pullRequestId int, body *CreatePullRequestTaskRequest) (*PullRequestTask, error) {
+	resp := &BitbucketResponse[PullRequestTask]{
+		Body: &PullRequestTask{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[CreatePullRequestTaskRequest]{
+		Method: "POST",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "pullrequests", strconv.Itoa(pullRequestId), "tasks"},
+		Body:   body,
+		Mime:   web.MimeApplicationJson,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// UpdatePullRequestTask updates the content or state of a pull request task.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - pullRequestId: The pull request ID number
+//   - taskId: The task ID number
+//   - body: New content and/or state ("RESOLVED" or "UNRESOLVED")
+//
+// Returns the updated task.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-tasks-task-id-put
+func (c *Client) UpdatePullRequestTask(ctx context.Context, workspaceSlug string, repoSlug string, pullRequestId int, taskId int, body *UpdatePullRequestTaskRequest) (*PullRequestTask, error) {
+	resp := &BitbucketResponse[PullRequestTask]{
+		Body: &PullRequestTask{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[UpdatePullRequestTaskRequest]{
+		Method: "PUT",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "pullrequests", strconv.Itoa(pullRequestId), "tasks", strconv.Itoa(taskId)},
+		Body:   body,
+		Mime:   web.MimeApplicationJson,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// DeletePullRequestTask deletes a task from a pull request.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - pullRequestId: The pull request ID number
+//   - taskId: The task ID number
+//
+// Returns an error if the deletion fails.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-tasks-task-id-delete
+func (c *Client) DeletePullRequestTask(ctx context.Context, workspaceSlug string, repoSlug string, pullRequestId int, taskId int) error {
+	resp := &BitbucketResponse[any]{
+		Mime: web.MimeOmit,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "DELETE",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "pullrequests", strconv.Itoa(pullRequestId), "tasks", strconv.Itoa(taskId)},
+		Mime:   web.MimeOmit,
+	})
+
+	return Perform(req, resp)
+}
+
+// ApprovePullRequest approves a pull request as the authenticated user.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - pullRequestId: The pull request ID number
+//
+// Returns the authenticated user's participant entry with approved set to true.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-approve-post
+func (c *Client) ApprovePullRequest(ctx context.Context, workspaceSlug string, repoSlug string, pullRequestId int) (*PullRequestParticipant, error) {
+	resp := &BitbucketResponse[PullRequestParticipant]{
+		Body: &PullRequestParticipant{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "POST",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "pullrequests", strconv.Itoa(pullRequestId), "approve"},
+		Mime:   web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// UnapprovePullRequest withdraws the authenticated user's approval of a pull request.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - pullRequestId: The pull request ID number
+//
+// Returns an error if the request fails.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-approve-delete
+func (c *Client) UnapprovePullRequest(ctx context.Context, workspaceSlug string, repoSlug string, pullRequestId int) error {
+	resp := &BitbucketResponse[any]{
+		Mime: web.MimeOmit,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "DELETE",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "pullrequests", strconv.Itoa(pullRequestId), "approve"},
+		Mime:   web.MimeOmit,
+	})
+
+	return Perform(req, resp)
+}
+
+// RequestPullRequestChanges requests changes on a pull request as the authenticated user.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - pullRequestId: The pull request ID number
+//
+// Returns the authenticated user's participant entry with state set to "changes_requested".
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-request-changes-post
+func (c *Client) RequestPullRequestChanges(ctx context.Context, workspaceSlug string, repoSlug string, pullRequestId int) (*PullRequestParticipant, error) {
+	resp := &BitbucketResponse[PullRequestParticipant]{
+		Body: &PullRequestParticipant{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "POST",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "pullrequests", strconv.Itoa(pullRequestId), "request-changes"},
+		Mime:   web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// RemovePullRequestChangeRequest withdraws the authenticated user's change request on a pull request.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - pullRequestId: The pull request ID number
+//
+// Returns an error if the request fails.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-request-changes-delete
+func (c *Client) RemovePullRequestChangeRequest(ctx context.Context, workspaceSlug string, repoSlug string, pullRequestId int) error {
+	resp := &BitbucketResponse[any]{
+		Mime: web.MimeOmit,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "DELETE",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "pullrequests", strconv.Itoa(pullRequestId), "request-changes"},
+		Mime:   web.MimeOmit,
+	})
+
+	return Perform(req, resp)
+}
+
+// GetCurrentUser retrieves the user the client is authenticated as.
+//
+// Parameters:
+//   - ctx: Context for the request
+//
+// Returns the authenticated user's profile.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-users/#api-user-get
+func (c *Client) GetCurrentUser(ctx context.Context) (*User, error) {
+	resp := &BitbucketResponse[User]{
+		Body: &User{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"user"},
+		Mime:   web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// ListUserEmails retrieves a paginated list of the email addresses of the authenticated user.
+// OAuth tokens require the email scope; if the credentials are not allowed to read
+// the email addresses (401 or 403), an empty page is returned instead of an error.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - pagelen: Number of items per page
+//   - page: Page number to retrieve (1-indexed)
+//
+// Returns the API response containing the user's email addresses.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-users/#api-user-emails-get
+func (c *Client) ListUserEmails(ctx context.Context, pagelen int, page int) (*ApiResponse[UserEmail], error) {
+	resp := &BitbucketResponse[ApiResponse[UserEmail]]{
+		Body: &ApiResponse[UserEmail]{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"user", "emails"},
+		Query: map[string]string{
+			"pagelen": strconv.Itoa(pagelen),
+			"page":    strconv.Itoa(page),
+		},
+		Mime: web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		if resp.Status == http.StatusUnauthorized || resp.Status == http.StatusForbidden {
+			return &ApiResponse[UserEmail]{}, nil
+		}
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// ListUserPullRequests retrieves a paginated list of pull requests of the specified user
+// across all repositories. The reviewers and participants of each pull request are included.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - selectedUser: The user's account ID or UUID
+//   - pagelen: Number of items per page (maximum 50)
+//   - page: Page number to retrieve (1-indexed)
+//   - states: Filter by pull request states (e.g., "OPEN", "MERGED", "DECLINED"). Empty slice returns open ones.
+//   - query: Optional BBQL filter and sort order. Nil applies no filter.
+//
+// Returns the API response containing the list of pull requests and pagination metadata.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-pullrequests-selected-user-get
+func (c *Client) ListUserPullRequests(ctx context.Context, selectedUser string, pagelen int, page int, states []string, query *bbql.Query) (*ApiResponse[PullRequest], error) {
+	resp := &BitbucketResponse[ApiResponse[PullRequest]]{
+		Body: &ApiResponse[PullRequest]{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	params := query.Params()
+	params["pagelen"] = strconv.Itoa(pagelen)
+	params["page"] = strconv.Itoa(page)
+	params["fields"] = "+values.reviewers,+values.participants"
+
+	if len(states) > 0 {
+		params["state"] = strings.Join(states, ",")
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"pullrequests", selectedUser},
+		Query:  params,
+		Mime:   web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// ListBranchRestrictions retrieves a paginated list of the branch restrictions of a repository,
+// including the merge checks such as required approvals and passing builds.
+// Reading branch restrictions requires repository admin permission.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - pagelen: Number of items per page (maximum 100)
+//   - page: Page number to retrieve (1-indexed)
+//
+// Returns the API response containing the branch restrictions with the branches they apply to.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-branch-restrictions/#api-repositories-workspace-repo-slug-branch-restrictions-get
+func (c *Client) ListBranchRestrictions(ctx context.Context, workspaceSlug string, repoSlug string, pagelen int, page int) (*ApiResponse[BranchRestriction], error) {
+	resp := &BitbucketResponse[ApiResponse[BranchRestriction]]{
+		Body: &ApiResponse[BranchRestriction]{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "branch-restrictions"},
+		Query: map[string]string{
+			"pagelen": strconv.Itoa(pagelen),
+			"page":    strconv.Itoa(page),
+		},
+		Mime: web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// GetEffectiveBranchingModel retrieves the branching model of a repository,
+// taking the settings inherited from its project into account.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//
+// Returns the development and production branches and the branch type prefixes.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-branching-model/#api-repositories-workspace-repo-slug-effective-branching-model-get
+func (c *Client) GetEffectiveBranchingModel(ctx context.Context, workspaceSlug string, repoSlug string) (*BranchingModel, error) {
+	resp := &BitbucketResponse[BranchingModel]{
+		Body: &BranchingModel{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "effective-branching-model"},
+		Mime:   web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// ListDefaultReviewers retrieves a paginated list of the default reviewers configured
+// directly on a repository. Reviewers inherited from the project are not included.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - pagelen: Number of items per page (maximum 100)
+//   - page: Page number to retrieve (1-indexed)
+//
+// Returns the API response containing the default reviewer users.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-default-reviewers-get
+func (c *Client) ListDefaultReviewers(ctx context.Context, workspaceSlug string, repoSlug string, pagelen int, page int) (*ApiResponse[User], error) {
+	resp := &BitbucketResponse[ApiResponse[User]]{
+		Body: &ApiResponse[User]{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "default-reviewers"},
+		Query: map[string]string{
+			"pagelen": strconv.Itoa(pagelen),
+			"page":    strconv.Itoa(page),
+		},
+		Mime: web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// ListEffectiveDefaultReviewers retrieves a paginated list of the effective default reviewers
+// of a repository: the ones configured on the repository and the ones inherited from its project.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - pagelen: Number of items per page (maximum 100)
+//   - page: Page number to retrieve (1-indexed)
+//
+// Returns the API response containing the default reviewers with the level they are configured at.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-effective-default-reviewers-get
+func (c *Client) ListEffectiveDefaultReviewers(ctx context.Context, workspaceSlug string, repoSlug string, pagelen int, page int) (*ApiResponse[DefaultReviewer], error) {
+	resp := &BitbucketResponse[ApiResponse[DefaultReviewer]]{
+		Body: &ApiResponse[DefaultReviewer]{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "effective-default-reviewers"},
+		Query: map[string]string{
+			"pagelen": strconv.Itoa(pagelen),
+			"page":    strconv.Itoa(page),
+		},
+		Mime: web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// ListWorkspaceMembers retrieves a paginated list of the members of a workspace.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - pagelen: Number of items per page (maximum 100)
+//   - page: Page number to retrieve (1-indexed)
+//
+// Returns the API response containing the workspace memberships with their users.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-workspaces/#api-workspaces-workspace-members-get
+func (c *Client) ListWorkspaceMembers(ctx context.Context, workspaceSlug string, pagelen int, page int) (*ApiResponse[WorkspaceMembership], error) {
+	resp := &BitbucketResponse[ApiResponse[WorkspaceMembership]]{
+		Body: &ApiResponse[WorkspaceMembership]{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"workspaces", workspaceSlug, "members"},
+		Query: map[string]string{
+			"pagelen": strconv.Itoa(pagelen),
+			"page":    strconv.Itoa(page),
+		},
+		Mime: web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// ListWorkspaces retrieves a paginated list of the workspaces the authenticated user is a member of,
+// together with the user's permission in each of them.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - pagelen: Number of items per page (maximum 100)
+//   - page: Page number to retrieve (1-indexed)
+//
+// Returns the API response containing the workspace memberships of the user.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-workspaces/#api-user-permissions-workspaces-get
+func (c *Client) ListWorkspaces(ctx context.Context, pagelen int, page int) (*ApiResponse[WorkspaceMembership], error) {
+	resp := &BitbucketResponse[ApiResponse[WorkspaceMembership]]{
+		Body: &ApiResponse[WorkspaceMembership]{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"user", "permissions", "workspaces"},
+		Query: map[string]string{
+			"pagelen": strconv.Itoa(pagelen),
+			"page":    strconv.Itoa(page),
+		},
+		Mime: web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// ListProjects retrieves a paginated list of the projects of a workspace.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - pagelen: Number of items per page (maximum 100)
+//   - page: Page number to retrieve (1-indexed)
+//
+// Returns the API response containing the list of projects and pagination metadata.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-workspaces/#api-workspaces-workspace-projects-get
+func (c *Client) ListProjects(ctx context.Context, workspaceSlug string, pagelen int, page int) (*ApiResponse[Project], error) {
+	resp := &BitbucketResponse[ApiResponse[Project]]{
+		Body: &ApiResponse[Project]{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"workspaces", workspaceSlug, "projects"},
+		Query: map[string]string{
+			"pagelen": strconv.Itoa(pagelen),
+			"page":    strconv.Itoa(page),
+		},
+		Mime: web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// GetProject retrieves a project of a workspace.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - projectKey: The project key (e.g., "PROJ")
+//
+// Returns the project including its description and visibility.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-projects/#api-workspaces-workspace-projects-project-key-get
+func (c *Client) GetProject(ctx context.Context, workspaceSlug string, projectKey string) (*Project, error) {
+	resp := &BitbucketResponse[Project]{
+		Body: &Project{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"workspaces", workspaceSlug, "projects", projectKey},
+		Mime:   web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// ListPipelines retrieves a paginated list of pipelines of a repository, newest first.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - pagelen: Number of items per page
+//   - page: Page number to retrieve (1-indexed)
+//   - branch: Branch name to filter the pipelines by. Empty includes all branches.
+//   - status: Pipeline status to filter by (e.g. "FAILED", "SUCCESSFUL", "IN_PROGRESS"). Empty includes all.
+//
+// Returns the API response containing the list of pipelines and pagination metadata.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pipelines/#api-repositories-workspace-repo-slug-pipelines-get
+func (c *Client) ListPipelines(ctx context.Context, workspaceSlug string, repoSlug string, pagelen int, page int, branch string, status string) (*ApiResponse[Pipeline], error) {
+	resp := &BitbucketResponse[ApiResponse[Pipeline]]{
+		Body: &ApiResponse[Pipeline]{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	params := map[string]string{
+		"pagelen": strconv.Itoa(pagelen),
+		"page":    strconv.Itoa(page),
+		"sort":    "-created_on",
+	}
+	if branch != "" {
+		params["target.branch"] = branch
+	}
+	if status != "" {
+		params["status"] = status
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "pipelines"},
+		Query:  params,
+		Mime:   web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// GetPipeline retrieves a single pipeline of a repository.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - pipeline: The pipeline UUID enclosed in braces, or its build number
+//
+// Returns the pipeline with its state, target, and trigger.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pipelines/#api-repositories-workspace-repo-slug-pipelines-pipeline-uuid-get
+func (c *Client) GetPipeline(ctx context.Context, workspaceSlug string, repoSlug string, pipeline string) (*Pipeline, error) {
+	resp := &BitbucketResponse[Pipeline]{
+		Body: &Pipeline{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "pipelines", pipeline},
+		Mime:   web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// ListPipelineSteps retrieves a paginated list of the steps of a pipeline.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - pipelineUUID: The pipeline UUID enclosed in braces
+//   - pagelen: Number of items per page
+//   - page: Page number to retrieve (1-indexed)
+//
+// Returns the API response containing the list of steps in execution order.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pipelines/#api-repositories-workspace-repo-slug-pipelines-pipeline-uuid-steps-get
+func (c *Client) ListPipelineSteps(ctx context.Context, workspaceSlug string, repoSlug string, pipelineUUID string, pagelen int, page int) (*ApiResponse[PipelineStep], error) {
+	resp := &BitbucketResponse[ApiResponse[PipelineStep]]{
+		Body: &ApiResponse[PipelineStep]{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "pipelines", pipelineUUID, "steps"},
+		Query: map[string]string{
+			"pagelen": strconv.Itoa(pagelen),
+			"page":    strconv.Itoa(page),
+		},
+		Mime: web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// GetPipelineStepLog retrieves the log of a pipeline step, or a byte range of it.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - pipelineUUID: The pipeline UUID enclosed in braces
+//   - stepUUID: The step UUID enclosed in braces
+//   - byteRange: An HTTP Range header value, e.g. "bytes=0-1023" for the first KiB
+//     or "bytes=-1024" for the last one. Empty retrieves the whole log.
+//
+// Returns the log content with the Content-Range of a partial response,
+// which is empty when the whole log was returned.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pipelines/#api-repositories-workspace-repo-slug-pipelines-pipeline-uuid-steps-step-uuid-log-get
+func (c *Client) GetPipelineStepLog(ctx context.Context, workspaceSlug string, repoSlug string, pipelineUUID string, stepUUID string, byteRange string) (*PipelineStepLog, error) {
+	resp := &BitbucketResponse[string]{
+		Body: new(string),
+		Mime: web.MimeTextPlain,
+	}
+
+	header := map[string]string{}
+	if byteRange != "" {
+		header["Range"] = byteRange
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "pipelines", pipelineUUID, "steps", stepUUID, "log"},
+		Header: header,
+		Mime:   web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+
+	log := &PipelineStepLog{Content: *resp.Body}
+	if resp.Status == http.StatusPartialContent {
+		log.ContentRange = resp.Header.Get("Content-Range")
+	}
+	return log, nil
+}
+
+// TriggerPipeline starts a new pipeline run on a branch or commit.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - body: Request configuration including the target ref or commit, the pipeline selector, and variables
+//
+// A target without a selector runs the pipeline configured for the branch;
+// a "custom" selector runs the custom pipeline with the given name.
+//
+// Returns the created pipeline in its pending state.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pipelines/#api-repositories-workspace-repo-slug-pipelines-post
+func (c *Client) TriggerPipeline(ctx context.Context, workspaceSlug string, repoSlug string, body *TriggerPipelineRequest) (*Pipeline, error) {
+	resp := &BitbucketResponse[Pipeline]{
+		Body: &Pipeline{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[TriggerPipelineRequest]{
+		Method: "POST",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "pipelines"},
+		Body:   body,
+		Mime:   web.MimeApplicationJson,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// StopPipeline signals a running pipeline to stop.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - pipelineUUID: The pipeline UUID enclosed in braces
+//
+// Returns an error if the pipeline cannot be stopped, e.g. because it has already completed.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pipelines/#api-repositories-workspace-repo-slug-pipelines-pipeline-uuid-stoppipeline-post
+func (c *Client) StopPipeline(ctx context.Context, workspaceSlug string, repoSlug string, pipelineUUID string) error {
+	resp := &BitbucketResponse[any]{
+		Mime: web.MimeOmit,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "POST",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "pipelines", pipelineUUID, "stopPipeline"},
+		Mime:   web.MimeOmit,
+	})
+
+	return Perform(req, resp)
+}
+
+// ListIssues retrieves a paginated list of the issues of a repository's issue tracker.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - pagelen: Number of items per page (maximum 50)
+//   - page: Page number to retrieve (1-indexed)
+//   - query: Optional BBQL filter and sort order (e.g., `state = "open"` sorted by "-updated_on"). Nil applies no filter.
+//
+// Returns the API response containing the issues.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-issue-tracker/#api-repositories-workspace-repo-slug-issues-get
+func (c *Client) ListIssues(ctx context.Context, workspaceSlug string, repoSlug string, pagelen int, page int, query *bbql.Query) (*ApiResponse[Issue], error) {
+	resp := &BitbucketResponse[ApiResponse[Issue]]{
+		Body: &ApiResponse[Issue]{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	params := query.Params()
+	params["pagelen"] = strconv.Itoa(pagelen)
+	params["page"] = strconv.Itoa(page)
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "issues"},
+		Query:  params,
+		Mime:   web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// GetIssue retrieves a specific issue of a repository's issue tracker.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - issueId: The issue ID number
+//
+// Returns the issue including its content, state, kind, priority, reporter, and assignee.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-issue-tracker/#api-repositories-workspace-repo-slug-issues-issue-id-get
+func (c *Client) GetIssue(ctx context.Context, workspaceSlug string, repoSlug string, issueId int) (*Issue, error) {
+	resp := &BitbucketResponse[Issue]{
+		Body: &Issue{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "issues", strconv.Itoa(issueId)},
+		Mime:   web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// CreateIssue creates a new issue in a repository's issue tracker.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - body: Request configuration including the title, content, kind, priority, and assignee
+//
+// Returns the created issue.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-issue-tracker/#api-repositories-workspace-repo-slug-issues-post
+func (c *Client) CreateIssue(ctx context.Context, workspaceSlug string, repoSlug string, body *CreateIssueRequest) (*Issue, error) {
+	resp := &BitbucketResponse[Issue]{
+		Body: &Issue{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[CreateIssueRequest]{
+		Method: "POST",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "issues"},
+		Body:   body,
+		Mime:   web.MimeApplicationJson,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// UpdateIssue modifies an existing issue. Only the fields present in the body are changed.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - issueId: The issue ID number
+//   - body: Request configuration with the fields to change
+//
+// Returns the updated issue.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-issue-tracker/#api-repositories-workspace-repo-slug-issues-issue-id-put
+func (c *Client) UpdateIssue(ctx context.Context, workspaceSlug string, repoSlug string, issueId int, body *UpdateIssueRequest) (*Issue, error) {
+	resp := &BitbucketResponse[Issue]{
+		Body: &Issue{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[UpdateIssueRequest]{
+		Method: "PUT",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "issues", strconv.Itoa(issueId)},
+		Body:   body,
+		Mime:   web.MimeApplicationJson,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// ListIssueComments retrieves a paginated list of the comments of an issue, oldest first.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - issueId: The issue ID number
+//   - pagelen: Number of items per page (maximum 100)
+//   - page: Page number to retrieve (1-indexed)
+//
+// Returns the API response containing the comments.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-issue-tracker/#api-repositories-workspace-repo-slug-issues-issue-id-comments-get
+func (c *Client) ListIssueComments(ctx context.Context, workspaceSlug string, repoSlug string, issueId int, pagelen int, page int) (*ApiResponse[IssueComment], error) {
+	resp := &BitbucketResponse[ApiResponse[IssueComment]]{
+		Body: &ApiResponse[IssueComment]{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "issues", strconv.Itoa(issueId), "comments"},
+		Query: map[string]string{
+			"pagelen": strconv.Itoa(pagelen),
+			"page":    strconv.Itoa(page),
+		},
+		Mime: web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// CreateIssueComment adds a comment to an issue.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - repoSlug: The repository slug identifier
+//   - issueId: The issue ID number
+//   - body: Request configuration including the comment content
+//
+// Returns the created comment.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-issue-tracker/#api-repositories-workspace-repo-slug-issues-issue-id-comments-post
+func (c *Client) CreateIssueComment(ctx context.Context, workspaceSlug string, repoSlug string, issueId int, body *CreateIssueCommentRequest) (*IssueComment, error) {
+	resp := &BitbucketResponse[IssueComment]{
+		Body: &IssueComment{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[CreateIssueCommentRequest]{
+		Method: "POST",
+		Path:   []string{"repositories", workspaceSlug, repoSlug, "issues", strconv.Itoa(issueId), "comments"},
+		Body:   body,
+		Mime:   web.MimeApplicationJson,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// SearchCode searches the code of all repositories in a workspace.
+// The repository of every matching file is included in the response.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - workspaceSlug: The workspace slug identifier
+//   - searchQuery: The search query, optionally with modifiers (e.g., `NewClient repo:api lang:go`)
+//   - pagelen: Number of items per page (maximum 100)
+//   - page: Page number to retrieve (1-indexed)
+//
+// Returns the API response containing the matching files with their highlighted content and path matches.
+//
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-search/#api-workspaces-workspace-search-code-get
+func (c *Client) SearchCode(ctx context.Context, workspaceSlug string, searchQuery string, pagelen int, page int) (*CodeSearchResponse, error) {
+	resp := &BitbucketResponse[CodeSearchResponse]{
+		Body: &CodeSearchResponse{},
+		Mime: web.MimeApplicationJson,
+	}
+
+	req := prepare(c, ctx, &BitbucketRequest[any]{
+		Method: "GET",
+		Path:   []string{"workspaces", workspaceSlug, "search", "code"},
+		Query: map[string]string{
+			"search_query": searchQuery,
+			"pagelen":      strconv.Itoa(pagelen),
+			"page":         strconv.Itoa(page),
+			"fields":       "+values.file.commit.repository",
+		},
+		Mime: web.MimeOmit,
+	})
+
+	if err := Perform(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// CredentialsKey identifies the credentials the client authenticates with in the given context,
+// such as the OAuth token of the current MCP session, without revealing them.
+// The key is a SHA-256 hash of the Authorization header the client would send.
+//
+// Returns an InternalError if the request cannot be authorized.
+func (c *Client) CredentialsKey(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.cfg.Url, nil)
+	if err != nil {
+		return "", util.NewInternalError()
+	}
+	if err := c.authorizer.Authorize(ctx, req); err != nil {
+		slog.Error("Authorization failed", "error", err)
+		return "", util.NewInternalError()
+	}
+	sum := sha256.Sum256([]byte(req.Header.Get("Authorization")))
+	return hex.EncodeToString(sum[:]), nil
+}
+
 // prepare populates a BitbucketRequest with client configuration and authentication.
 // It sets the base URL, HTTP client, and determines which authentication method to use.
 // BearerAuth takes precedence over BasicAuth if both are configured.
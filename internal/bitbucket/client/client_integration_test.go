@@ -314,7 +314,7 @@ func (s *IntegrationTestSuite_BasicAuth) TestErrorNonExistentWorkspace() {
 	nonExistentWorkspace := "non-existent-workspace-12345"
 
 	t.Run("list repositories with non-existent workspace", func(t *testing.T) {
-		_, err := s.bb.ListRepositories(context.Background(), nonExistentWorkspace, 10, 1)
+		_, err := s.bb.ListRepositories(context.Background(), nonExistentWorkspace, 10, 1, "", nil)
 		s.Error(err, "Should return error for non-existent workspace")
 		util.AssertJsonRpcError(t, err, util.CodeResourceNotFoundErr, "Should be a ResourceNotFound error (404)")
 	})
@@ -346,7 +346,7 @@ func (s *IntegrationTestSuite_BasicAuth) TestErrorNonExistentRepository() {
 	})
 
 	t.Run("list pull requests with non-existent repo", func(t *testing.T) {
-		_, err := s.bb.ListPullRequests(context.Background(), s.workspace, nonExistentRepo, 10, 1, []string{"OPEN"})
+		_, err := s.bb.ListPullRequests(context.Background(), s.workspace, nonExistentRepo, 10, 1, []string{"OPEN"}, nil)
 		s.Error(err, "Should return error for non-existent repository")
 		util.AssertJsonRpcError(t, err, util.CodeResourceNotFoundErr, "Should be a ResourceNotFound error (404)")
 	})
@@ -649,7 +649,7 @@ func stepVerifyListRepositories(t *testing.T, bb *client.Client, workspace, repo
 	t.Helper()
 
 	t.Run("verify list repositories includes created repo", func(t *testing.T) {
-		repoList, err := bb.ListRepositories(context.Background(), workspace, 50, 1)
+		repoList, err := bb.ListRepositories(context.Background(), workspace, 50, 1, "", nil)
 		require.NoError(t, err, "Failed to list repositories")
 		require.NotNil(t, repoList, "Repository list should not be nil")
 
@@ -747,7 +747,7 @@ func stepVerifyListPullRequests(t *testing.T, bb *client.Client, workspace, repo
 	t.Helper()
 
 	t.Run("verify list pull requests", func(t *testing.T) {
-		prList, err := bb.ListPullRequests(context.Background(), workspace, repoSlug, 50, 1, []string{"OPEN"})
+		prList, err := bb.ListPullRequests(context.Background(), workspace, repoSlug, 50, 1, []string{"OPEN"}, nil)
 		require.NoError(t, err, "Failed to list pull requests")
 		require.NotNil(t, prList, "Pull request list should not be nil")
 
@@ -855,8 +855,16 @@ func stepMergePullRequest(t *testing.T, bb *client.Client, workspace, repoSlug s
 			Message: fmt.Sprintf("Merge pull request #%d", prID),
 		}
 
-		pr, err := bb.MergePullRequest(context.Background(), workspace, repoSlug, prID, mergeReq)
+		merge, err := bb.MergePullRequest(context.Background(), workspace, repoSlug, prID, mergeReq)
 		require.NoError(t, err, "Failed to merge pull request")
+
+		pr := merge.PullRequest
+		for pr == nil {
+			time.Sleep(time.Second)
+			status, err := bb.GetMergeTaskStatus(context.Background(), workspace, repoSlug, prID, merge.TaskID)
+			require.NoError(t, err, "Failed to get merge task status")
+			pr = status.MergeResult
+		}
 		require.NotNil(t, pr, "Merged pull request should not be nil")
 		require.Equal(t, "MERGED", pr.State, "Pull request state should be MERGED")
 	})
@@ -878,7 +886,7 @@ func stepVerifyListPullRequestsByState(t *testing.T, bb *client.Client, workspac
 
 	stateStr := states[0]
 	t.Run(fmt.Sprintf("verify list pull requests with state filter [%s]", stateStr), func(t *testing.T) {
-		prList, err := bb.ListPullRequests(context.Background(), workspace, repoSlug, 50, 1, states)
+		prList, err := bb.ListPullRequests(context.Background(), workspace, repoSlug, 50, 1, states, nil)
 		require.NoError(t, err, "Failed to list pull requests with state filter %v", states)
 		require.NotNil(t, prList, "Pull request list should not be nil")
 
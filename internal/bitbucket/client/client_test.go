@@ -11,6 +11,7 @@ import (
 
 	"github.com/branow/mcp-bitbucket/internal/bitbucket/client"
 	"github.com/branow/mcp-bitbucket/internal/util"
+	"github.com/branow/mcp-bitbucket/internal/util/bbql"
 	"github.com/modelcontextprotocol/go-sdk/jsonrpc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -53,9 +54,11 @@ func TestClient_ListRepositories(t *testing.T) {
 				MockDataFile: tt.File,
 				ErrorCode:    tt.ErrorCode,
 				Path:         fmt.Sprintf("/%s/%s", "repositories", workspace),
+				Query:        map[string]string{"pagelen": "10", "page": "1", "role": "member", "q": `is_private = true`, "sort": "-updated_on"},
 				Decode:       DecodeJson[client.ApiResponse[client.Repository]],
 				CallClient: func(bb *client.Client) (*client.ApiResponse[client.Repository], error) {
-					return bb.ListRepositories(context.Background(), workspace, pagelen, page)
+					query := bbql.New().Where(bbql.Eq("is_private", true)).SortDesc("updated_on")
+					return bb.ListRepositories(context.Background(), workspace, pagelen, page, "member", query)
 				},
 			})
 		})
@@ -143,9 +146,11 @@ func TestClient_ListPullRequests(t *testing.T) {
 				MockDataFile: tt.File,
 				ErrorCode:    tt.ErrorCode,
 				Path:         fmt.Sprintf("/%s/%s/%s/%s", "repositories", workspace, repoSlug, "pullrequests"),
+				Query:        map[string]string{"pagelen": "10", "page": "1", "state": "OPEN,MERGED", "q": `author.nickname = "testuser"`, "sort": "created_on", "fields": "+values.reviewers,+values.participants"},
 				Decode:       DecodeJson[client.ApiResponse[client.PullRequest]],
 				CallClient: func(bb *client.Client) (*client.ApiResponse[client.PullRequest], error) {
-					return bb.ListPullRequests(context.Background(), workspace, repoSlug, pagelen, page, nil)
+					query := bbql.New().Where(bbql.Eq("author.nickname", "testuser")).SortAsc("created_on")
+					return bb.ListPullRequests(context.Background(), workspace, repoSlug, pagelen, page, []string{"OPEN", "MERGED"}, query)
 				},
 			})
 		})
@@ -332,83 +337,1853 @@ func TestClient_GetDirectorySource(t *testing.T) {
 	}
 }
 
+func TestClient_UpdatePullRequest(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, pullRequestId := "test_workspace", "test-repo", 1
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/pull_request_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/pull_request_mock_404.txt",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.PullRequest]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s/%d", "repositories", workspace, repoSlug, "pullrequests", pullRequestId),
+				Decode:       DecodeJson[client.PullRequest],
+				CallClient: func(bb *client.Client) (*client.PullRequest, error) {
+					return bb.UpdatePullRequest(context.Background(), workspace, repoSlug, pullRequestId, &client.UpdatePullRequestRequest{
+						Title:     "Add new feature",
+						Reviewers: []client.CreatePullRequestReviewer{{UUID: "{reviewer-uuid}"}},
+					})
+				},
+			})
+		})
+	}
+}
+
+func TestClient_ListDefaultReviewers(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, pagelen, page := "test_workspace", "test-repo", 100, 1
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/default_reviewers_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/repository_mock_404.json",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.ApiResponse[client.User]]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s", "repositories", workspace, repoSlug, "default-reviewers"),
+				Query:        map[string]string{"pagelen": "100", "page": "1"},
+				Decode:       DecodeJson[client.ApiResponse[client.User]],
+				CallClient: func(bb *client.Client) (*client.ApiResponse[client.User], error) {
+					return bb.ListDefaultReviewers(context.Background(), workspace, repoSlug, pagelen, page)
+				},
+			})
+		})
+	}
+}
+
+func TestClient_ListEffectiveDefaultReviewers(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, pagelen, page := "test_workspace", "test-repo", 100, 1
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/effective_default_reviewers_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/repository_mock_404.json",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.ApiResponse[client.DefaultReviewer]]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s", "repositories", workspace, repoSlug, "effective-default-reviewers"),
+				Query:        map[string]string{"pagelen": "100", "page": "1"},
+				Decode:       DecodeJson[client.ApiResponse[client.DefaultReviewer]],
+				CallClient: func(bb *client.Client) (*client.ApiResponse[client.DefaultReviewer], error) {
+					return bb.ListEffectiveDefaultReviewers(context.Background(), workspace, repoSlug, pagelen, page)
+				},
+			})
+		})
+	}
+}
+
+func TestClient_ListWorkspaceMembers(t *testing.T) {
+	t.Parallel()
+	workspace, pagelen, page := "test-workspace", 100, 1
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/workspace_members_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/repository_list_mock_404.json",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.ApiResponse[client.WorkspaceMembership]]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s", "workspaces", workspace, "members"),
+				Query:        map[string]string{"pagelen": "100", "page": "1"},
+				Decode:       DecodeJson[client.ApiResponse[client.WorkspaceMembership]],
+				CallClient: func(bb *client.Client) (*client.ApiResponse[client.WorkspaceMembership], error) {
+					return bb.ListWorkspaceMembers(context.Background(), workspace, pagelen, page)
+				},
+			})
+		})
+	}
+}
+
+func TestClient_ListWorkspaces(t *testing.T) {
+	t.Parallel()
+	pagelen, page := 50, 1
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/workspaces_mock.json",
+		},
+		{
+			Name:      "Unauthorized",
+			Status:    401,
+			File:      "testdata/repository_list_mock_401.json",
+			ErrorCode: util.CodeInvalidParamsErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.ApiResponse[client.WorkspaceMembership]]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         "/user/permissions/workspaces",
+				Query:        map[string]string{"pagelen": "50", "page": "1"},
+				Decode:       DecodeJson[client.ApiResponse[client.WorkspaceMembership]],
+				CallClient: func(bb *client.Client) (*client.ApiResponse[client.WorkspaceMembership], error) {
+					return bb.ListWorkspaces(context.Background(), pagelen, page)
+				},
+			})
+		})
+	}
+}
+
+func TestClient_ListProjects(t *testing.T) {
+	t.Parallel()
+	workspace, pagelen, page := "test-workspace", 50, 1
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/projects_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/repository_list_mock_404.json",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.ApiResponse[client.Project]]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s", "workspaces", workspace, "projects"),
+				Query:        map[string]string{"pagelen": "50", "page": "1"},
+				Decode:       DecodeJson[client.ApiResponse[client.Project]],
+				CallClient: func(bb *client.Client) (*client.ApiResponse[client.Project], error) {
+					return bb.ListProjects(context.Background(), workspace, pagelen, page)
+				},
+			})
+		})
+	}
+}
+
+func TestClient_GetProject(t *testing.T) {
+	t.Parallel()
+	workspace, projectKey := "test-workspace", "TEST"
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/project_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/project_mock_404.json",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.Project]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s", "workspaces", workspace, "projects", projectKey),
+				Decode:       DecodeJson[client.Project],
+				CallClient: func(bb *client.Client) (*client.Project, error) {
+					return bb.GetProject(context.Background(), workspace, projectKey)
+				},
+			})
+		})
+	}
+}
+
+func TestClient_ListPullRequestActivity(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, pullRequestId, pagelen := "test_workspace", "test-repo", 1, 50
+
+	tests := []struct {
+		ClientEndpointTestCase
+		Cursor string
+		Query  map[string]string
+	}{
+		{
+			ClientEndpointTestCase: ClientEndpointTestCase{Name: "First page", Status: 200, File: "testdata/pull_request_activity_mock.json"},
+			Query:                  map[string]string{"pagelen": "50"},
+		},
+		{
+			ClientEndpointTestCase: ClientEndpointTestCase{Name: "Next page", Status: 200, File: "testdata/pull_request_activity_mock.json"},
+			Cursor:                 "page-2-cursor",
+			Query:                  map[string]string{"pagelen": "50", "ctx": "page-2-cursor"},
+		},
+		{
+			ClientEndpointTestCase: ClientEndpointTestCase{Name: "Not Found", Status: 404, File: "testdata/pull_request_mock_404.txt", ErrorCode: util.CodeResourceNotFoundErr},
+			Query:                  map[string]string{"pagelen": "50"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.ApiResponse[client.PullRequestActivity]]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s/%d/%s", "repositories", workspace, repoSlug, "pullrequests", pullRequestId, "activity"),
+				Query:        tt.Query,
+				Decode:       DecodeJson[client.ApiResponse[client.PullRequestActivity]],
+				CallClient: func(bb *client.Client) (*client.ApiResponse[client.PullRequestActivity], error) {
+					return bb.ListPullRequestActivity(context.Background(), workspace, repoSlug, pullRequestId, pagelen, tt.Cursor)
+				},
+			})
+		})
+	}
+}
+
+func TestClient_ListPullRequestTasks(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, pullRequestId, pagelen, page := "test_workspace", "test-repo", 1, 100, 1
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/pull_request_tasks_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/pull_request_mock_404.txt",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.ApiResponse[client.PullRequestTask]]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s/%d/%s", "repositories", workspace, repoSlug, "pullrequests", pullRequestId, "tasks"),
+				Query:        map[string]string{"pagelen": "100", "page": "1"},
+				Decode:       DecodeJson[client.ApiResponse[client.PullRequestTask]],
+				CallClient: func(bb *client.Client) (*client.ApiResponse[client.PullRequestTask], error) {
+					return bb.ListPullRequestTasks(context.Background(), workspace, repoSlug, pullRequestId, pagelen, page)
+				},
+			})
+		})
+	}
+}
+
+func TestClient_CreatePullRequestTask(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, pullRequestId := "test_workspace", "test-repo", 1
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 201,
+			File:   "testdata/pull_request_task_mock.json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.PullRequestTask]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s/%d/%s", "repositories", workspace, repoSlug, "pullrequests", pullRequestId, "tasks"),
+				Decode:       DecodeJson[client.PullRequestTask],
+				CallClient: func(bb *client.Client) (*client.PullRequestTask, error) {
+					return bb.CreatePullRequestTask(context.Background(), workspace, repoSlug, pullRequestId, &client.CreatePullRequestTaskRequest{
+						Content: client.CreatePullRequestCommentContent{Raw: "Rename the helper method"},
+						Comment: &client.PullRequestTaskCommentRef{ID: 987654321},
+					})
+				},
+			})
+		})
+	}
+}
+
+func TestClient_ApprovePullRequest(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, pullRequestId := "test_workspace", "test-repo", 1
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/pull_request_participant_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/pull_request_mock_404.txt",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.PullRequestParticipant]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s/%d/%s", "repositories", workspace, repoSlug, "pullrequests", pullRequestId, "approve"),
+				Decode:       DecodeJson[client.PullRequestParticipant],
+				CallClient: func(bb *client.Client) (*client.PullRequestParticipant, error) {
+					return bb.ApprovePullRequest(context.Background(), workspace, repoSlug, pullRequestId)
+				},
+			})
+		})
+	}
+}
+
+func TestClient_RequestPullRequestChanges(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, pullRequestId := "test_workspace", "test-repo", 1
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/pull_request_participant_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/pull_request_mock_404.txt",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.PullRequestParticipant]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s/%d/%s", "repositories", workspace, repoSlug, "pullrequests", pullRequestId, "request-changes"),
+				Decode:       DecodeJson[client.PullRequestParticipant],
+				CallClient: func(bb *client.Client) (*client.PullRequestParticipant, error) {
+					return bb.RequestPullRequestChanges(context.Background(), workspace, repoSlug, pullRequestId)
+				},
+			})
+		})
+	}
+}
+
+func TestClient_GetCurrentUser(t *testing.T) {
+	t.Parallel()
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/user_mock.json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.User]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         "/user",
+				Decode:       DecodeJson[client.User],
+				CallClient: func(bb *client.Client) (*client.User, error) {
+					return bb.GetCurrentUser(context.Background())
+				},
+			})
+		})
+	}
+}
+
+func TestClient_ListUserEmails(t *testing.T) {
+	t.Parallel()
+	pagelen, page := 100, 1
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/user_emails_mock.json",
+		},
+		{
+			Name:   "Unauthorized",
+			Status: 401,
+			File:   "testdata/user_emails_mock_403.json",
+		},
+		{
+			Name:   "Forbidden",
+			Status: 403,
+			File:   "testdata/user_emails_mock_403.json",
+		},
+		{
+			Name:      "Bad Request",
+			Status:    400,
+			File:      "testdata/repository_list_mock_400.json",
+			ErrorCode: util.CodeInvalidParamsErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.ApiResponse[client.UserEmail]]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         "/user/emails",
+				Query:        map[string]string{"pagelen": "100", "page": "1"},
+				Decode:       DecodeJson[client.ApiResponse[client.UserEmail]],
+				CallClient: func(bb *client.Client) (*client.ApiResponse[client.UserEmail], error) {
+					return bb.ListUserEmails(context.Background(), pagelen, page)
+				},
+			})
+		})
+	}
+}
+
+func TestClient_CredentialsKey(t *testing.T) {
+	t.Parallel()
+	config := client.BitbucketConfig{Url: "https://api.bitbucket.org/2.0", Timeout: 1}
+	key := func(authorizer util.Authorizer) string {
+		key, err := client.NewClient(config, authorizer).CredentialsKey(context.Background())
+		require.NoError(t, err)
+		return key
+	}
+
+	first := key(util.NewOAuthAuthorizer(util.NewStaticTokenExtractor("first-token")))
+	assert.Len(t, first, 64)
+	assert.NotContains(t, first, "first-token")
+	assert.Equal(t, first, key(util.NewOAuthAuthorizer(util.NewStaticTokenExtractor("first-token"))))
+	assert.NotEqual(t, first, key(util.NewOAuthAuthorizer(util.NewStaticTokenExtractor("second-token"))))
+	assert.NotEqual(t, first, key(util.NewBasicAuthorizer("test_user", "test_password")))
+
+	_, err := client.NewClient(config, util.NewOAuthAuthorizer(util.NewStaticTokenExtractor(""))).CredentialsKey(context.Background())
+	require.Error(t, err)
+}
+
+func TestClient_ListUserPullRequests(t *testing.T) {
+	t.Parallel()
+	selectedUser, pagelen, page := "test-account-id", 10, 1
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/pull_requests_mock.json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.ApiResponse[client.PullRequest]]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s", "pullrequests", selectedUser),
+				Query:        map[string]string{"state": "OPEN", "q": `reviewers.uuid = "{test-user-uuid}"`, "fields": "+values.reviewers,+values.participants"},
+				Decode:       DecodeJson[client.ApiResponse[client.PullRequest]],
+				CallClient: func(bb *client.Client) (*client.ApiResponse[client.PullRequest], error) {
+					query := bbql.New().Where(bbql.Eq("reviewers.uuid", "{test-user-uuid}"))
+					return bb.ListUserPullRequests(context.Background(), selectedUser, pagelen, page, []string{"OPEN"}, query)
+				},
+			})
+		})
+	}
+}
+
 func DecodeJson[T any](data []byte, res *T) error {
 	return json.Unmarshal(data, res)
 }
 
-func DecodeText(data []byte, res *string) error {
-	*res = string(data)
-	return nil
+func DecodeText(data []byte, res *string) error {
+	*res = string(data)
+	return nil
+}
+
+type ClientEndpointTestCase struct {
+	Name      string
+	Status    int
+	ErrorCode int64
+	File      string
+}
+
+type ClientTestCase[T any] struct {
+	MockDataFile string
+	Status       int
+	Path         string
+	Query        map[string]string // expected query parameters, if any
+	CallClient   func(*client.Client) (*T, error)
+	Decode       func(data []byte, res *T) error
+	ErrorCode    int64
+}
+
+func RunClientTest[T any](t *testing.T, tc ClientTestCase[T]) {
+	t.Helper()
+
+	mockData, err := os.ReadFile(tc.MockDataFile)
+	require.NoError(t, err, "failed to read mock data file")
+
+	var expectedResponse T
+
+	if tc.ErrorCode == 0 {
+		err = tc.Decode(mockData, &expectedResponse)
+		require.NoError(t, err, "failed to decode expected response")
+	}
+
+	testUsername := "test_user"
+	testPassword := "test_password"
+
+	serverURL := NewTestServer(t, tc.Path, func(resp http.ResponseWriter, req *http.Request) {
+		actualUsername, actualPassword, ok := req.BasicAuth()
+		require.True(t, ok, "expected basic auth")
+		require.Equal(t, testUsername, actualUsername)
+		require.Equal(t, testPassword, actualPassword)
+		for key, value := range tc.Query {
+			assert.Equal(t, value, req.URL.Query().Get(key), "unexpected query parameter %q", key)
+		}
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(tc.Status)
+		resp.Write(mockData)
+	})
+
+	config := client.BitbucketConfig{
+		Url:     serverURL,
+		Timeout: 1,
+	}
+
+	authorizer := util.NewBasicAuthorizer(testUsername, testPassword)
+	bb := client.NewClient(config, authorizer)
+	actualResponse, err := tc.CallClient(bb)
+
+	if tc.ErrorCode == 0 {
+		require.NoError(t, err)
+		assert.Equal(t, &expectedResponse, actualResponse)
+	} else {
+		require.Error(t, err)
+		var jsonrpcErr *jsonrpc.Error
+		require.ErrorAs(t, err, &jsonrpcErr, "Error should be a jsonrpc.Error")
+		assert.Equal(t, tc.ErrorCode, jsonrpcErr.Code, "Error code should match")
+		assert.Nil(t, actualResponse)
+	}
+}
+
+func NewTestServer(t *testing.T, pattern string, handle func(http.ResponseWriter, *http.Request)) string {
+	t.Helper()
+	handler := http.NewServeMux()
+	handler.HandleFunc(pattern, handle)
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestClient_GetPullRequestDiffStat(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, pullRequestId, pagelen, page := "test_workspace", "test-repo", 1, 500, 1
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/pull_request_diffstat_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/pull_request_mock_404.txt",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.ApiResponse[client.DiffStat]]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s/%d/%s", "repositories", workspace, repoSlug, "pullrequests", pullRequestId, "diffstat"),
+				Query:        map[string]string{"pagelen": "500", "page": "1"},
+				Decode:       DecodeJson[client.ApiResponse[client.DiffStat]],
+				CallClient: func(bb *client.Client) (*client.ApiResponse[client.DiffStat], error) {
+					return bb.GetPullRequestDiffStat(context.Background(), workspace, repoSlug, pullRequestId, pagelen, page)
+				},
+			})
+		})
+	}
+}
+
+func TestClient_ListPullRequestStatuses(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, pullRequestId, pagelen, page := "test_workspace", "test-repo", 1, 100, 1
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/pull_request_statuses_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/pull_request_mock_404.txt",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.ApiResponse[client.CommitStatus]]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s/%d/%s", "repositories", workspace, repoSlug, "pullrequests", pullRequestId, "statuses"),
+				Query:        map[string]string{"pagelen": "100", "page": "1"},
+				Decode:       DecodeJson[client.ApiResponse[client.CommitStatus]],
+				CallClient: func(bb *client.Client) (*client.ApiResponse[client.CommitStatus], error) {
+					return bb.ListPullRequestStatuses(context.Background(), workspace, repoSlug, pullRequestId, pagelen, page)
+				},
+			})
+		})
+	}
+}
+
+func TestClient_ListVisiblePullRequestStatuses(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, pullRequestId, pagelen, page := "test_workspace", "test-repo", 1, 100, 1
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/pull_request_statuses_mock.json",
+		},
+		{
+			Name:   "Forbidden",
+			Status: 403,
+			File:   "testdata/pull_request_statuses_mock_403.json",
+		},
+		{
+			Name:   "Not Found",
+			Status: 404,
+			File:   "testdata/repository_mock_404.json",
+		},
+		{
+			Name:      "Unavailable",
+			Status:    503,
+			File:      "testdata/pull_request_mock_404.txt",
+			ErrorCode: util.CodeResourceUnavailableErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.ApiResponse[client.CommitStatus]]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s/%d/%s", "repositories", workspace, repoSlug, "pullrequests", pullRequestId, "statuses"),
+				Query:        map[string]string{"pagelen": "100", "page": "1"},
+				Decode:       DecodeJson[client.ApiResponse[client.CommitStatus]],
+				CallClient: func(bb *client.Client) (*client.ApiResponse[client.CommitStatus], error) {
+					return bb.ListVisiblePullRequestStatuses(context.Background(), workspace, repoSlug, pullRequestId, pagelen, page)
+				},
+			})
+		})
+	}
+}
+
+func TestClient_ListCommitStatuses(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, commit, pagelen, page := "test_workspace", "test-repo", "def456ghi789", 100, 1
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/commit_statuses_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/commit_mock_404.json",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.ApiResponse[client.CommitStatus]]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s/%s/%s", "repositories", workspace, repoSlug, "commit", commit, "statuses"),
+				Query:        map[string]string{"pagelen": "100", "page": "1"},
+				Decode:       DecodeJson[client.ApiResponse[client.CommitStatus]],
+				CallClient: func(bb *client.Client) (*client.ApiResponse[client.CommitStatus], error) {
+					return bb.ListCommitStatuses(context.Background(), workspace, repoSlug, commit, pagelen, page)
+				},
+			})
+		})
+	}
+}
+
+func TestClient_CreateBuildStatus(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, commit := "test_workspace", "test-repo", "def456ghi789"
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 201,
+			File:   "testdata/build_status_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/commit_mock_404.json",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.CommitStatus]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s/%s/%s/%s", "repositories", workspace, repoSlug, "commit", commit, "statuses", "build"),
+				Decode:       DecodeJson[client.CommitStatus],
+				CallClient: func(bb *client.Client) (*client.CommitStatus, error) {
+					return bb.CreateBuildStatus(context.Background(), workspace, repoSlug, commit, &client.CreateBuildStatusRequest{
+						Key:   "agent-review",
+						State: "SUCCESSFUL",
+						Name:  "Agent review",
+						URL:   "https://agents.example.com/reviews/15",
+					})
+				},
+			})
+		})
+	}
+}
+
+func TestClient_CreateOrUpdateReport(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, commit, reportID := "test_workspace", "test-repo", "def456ghi789", "eslint"
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/report_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/commit_mock_404.json",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.Report]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s/%s/%s/%s", "repositories", workspace, repoSlug, "commit", commit, "reports", reportID),
+				Decode:       DecodeJson[client.Report],
+				CallClient: func(bb *client.Client) (*client.Report, error) {
+					return bb.CreateOrUpdateReport(context.Background(), workspace, repoSlug, commit, reportID, &client.CreateReportRequest{
+						Title:      "ESLint",
+						Details:    "3 findings: 1 errors, 2 warnings, 0 notes.",
+						ReportType: "BUG",
+						Result:     "FAILED",
+					})
+				},
+			})
+		})
+	}
+}
+
+func TestClient_ListReports(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, commit, pagelen, page := "test_workspace", "test-repo", "def456ghi789", 100, 1
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/reports_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/commit_mock_404.json",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.ApiResponse[client.Report]]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s/%s/%s", "repositories", workspace, repoSlug, "commit", commit, "reports"),
+				Query:        map[string]string{"pagelen": "100", "page": "1"},
+				Decode:       DecodeJson[client.ApiResponse[client.Report]],
+				CallClient: func(bb *client.Client) (*client.ApiResponse[client.Report], error) {
+					return bb.ListReports(context.Background(), workspace, repoSlug, commit, pagelen, page)
+				},
+			})
+		})
+	}
+}
+
+func TestClient_ListAnnotations(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, commit, reportID, pagelen, page := "test_workspace", "test-repo", "def456ghi789", "eslint", 100, 1
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/annotations_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/commit_mock_404.json",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.ApiResponse[client.Annotation]]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s/%s/%s/%s/%s", "repositories", workspace, repoSlug, "commit", commit, "reports", reportID, "annotations"),
+				Query:        map[string]string{"pagelen": "100", "page": "1"},
+				Decode:       DecodeJson[client.ApiResponse[client.Annotation]],
+				CallClient: func(bb *client.Client) (*client.ApiResponse[client.Annotation], error) {
+					return bb.ListAnnotations(context.Background(), workspace, repoSlug, commit, reportID, pagelen, page)
+				},
+			})
+		})
+	}
+}
+
+func TestClient_CreateAnnotations(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, commit, reportID := "test_workspace", "test-repo", "def456ghi789", "eslint"
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/annotations_created_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/commit_mock_404.json",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[[]client.Annotation]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s/%s/%s/%s/%s", "repositories", workspace, repoSlug, "commit", commit, "reports", reportID, "annotations"),
+				Decode:       DecodeJson[[]client.Annotation],
+				CallClient: func(bb *client.Client) (*[]client.Annotation, error) {
+					annotations, err := bb.CreateAnnotations(context.Background(), workspace, repoSlug, commit, reportID, []client.CreateAnnotationRequest{
+						{ExternalID: "no-unused-vars-1", AnnotationType: "BUG", Path: "src/main.js", Line: 12, Summary: "'result' is assigned a value but never used.", Severity: "HIGH"},
+						{ExternalID: "eqeqeq-2", AnnotationType: "CODE_SMELL", Path: "src/utils.js", Line: 4, Summary: "Expected '===' and instead saw '=='.", Severity: "MEDIUM"},
+					})
+					if err != nil {
+						return nil, err
+					}
+					return &annotations, nil
+				},
+			})
+		})
+	}
+}
+
+func TestClient_ListBranchRestrictions(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, pagelen, page := "test_workspace", "test-repo", 100, 1
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/branch_restrictions_mock.json",
+		},
+		{
+			Name:      "Forbidden",
+			Status:    403,
+			File:      "testdata/branch_restrictions_mock_403.json",
+			ErrorCode: util.CodeInvalidParamsErr,
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/repository_mock_404.json",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.ApiResponse[client.BranchRestriction]]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s", "repositories", workspace, repoSlug, "branch-restrictions"),
+				Query:        map[string]string{"pagelen": "100", "page": "1"},
+				Decode:       DecodeJson[client.ApiResponse[client.BranchRestriction]],
+				CallClient: func(bb *client.Client) (*client.ApiResponse[client.BranchRestriction], error) {
+					return bb.ListBranchRestrictions(context.Background(), workspace, repoSlug, pagelen, page)
+				},
+			})
+		})
+	}
+}
+
+func TestClient_GetEffectiveBranchingModel(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug := "test_workspace", "test-repo"
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/effective_branching_model_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/repository_mock_404.json",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.BranchingModel]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s", "repositories", workspace, repoSlug, "effective-branching-model"),
+				Decode:       DecodeJson[client.BranchingModel],
+				CallClient: func(bb *client.Client) (*client.BranchingModel, error) {
+					return bb.GetEffectiveBranchingModel(context.Background(), workspace, repoSlug)
+				},
+			})
+		})
+	}
+}
+
+func TestClient_MergePullRequest(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, pullRequestId := "test_workspace", "test-repo", 1
+	path := fmt.Sprintf("/%s/%s/%s/%s/%d/%s", "repositories", workspace, repoSlug, "pullrequests", pullRequestId, "merge")
+
+	mockData, err := os.ReadFile("testdata/pull_request_mock.json")
+	require.NoError(t, err, "failed to read mock data file")
+	var pr client.PullRequest
+	require.NoError(t, DecodeJson(mockData, &pr))
+
+	tests := []struct {
+		Name     string
+		Status   int
+		Location string
+		Expected *client.PullRequestMerge
+	}{
+		{
+			Name:     "Merged",
+			Status:   200,
+			Expected: &client.PullRequestMerge{PullRequest: &pr},
+		},
+		{
+			Name:     "Accepted",
+			Status:   202,
+			Location: "https://api.bitbucket.org/2.0" + path + "/task-status/merge-task-id",
+			Expected: &client.PullRequestMerge{TaskID: "merge-task-id"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			serverURL := NewTestServer(t, path, func(resp http.ResponseWriter, req *http.Request) {
+				require.Equal(t, http.MethodPost, req.Method)
+				resp.Header().Set("Content-Type", "application/json")
+				if tt.Location != "" {
+					resp.Header().Set("Location", tt.Location)
+					resp.WriteHeader(tt.Status)
+					return
+				}
+				resp.WriteHeader(tt.Status)
+				resp.Write(mockData)
+			})
+
+			bb := client.NewClient(client.BitbucketConfig{Url: serverURL, Timeout: 1}, util.NewBasicAuthorizer("test_user", "test_password"))
+			merge, err := bb.MergePullRequest(context.Background(), workspace, repoSlug, pullRequestId, &client.MergePullRequestRequest{
+				Type: "pullrequest_merge_parameters",
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.Expected, merge)
+		})
+	}
+}
+
+func TestClient_GetMergeTaskStatus(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, pullRequestId, taskId := "test_workspace", "test-repo", 1, "merge-task-id"
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/merge_task_status_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/pull_request_mock_404.txt",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.MergeTaskStatus]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s/%d/%s/%s/%s", "repositories", workspace, repoSlug, "pullrequests", pullRequestId, "merge", "task-status", taskId),
+				Decode:       DecodeJson[client.MergeTaskStatus],
+				CallClient: func(bb *client.Client) (*client.MergeTaskStatus, error) {
+					return bb.GetMergeTaskStatus(context.Background(), workspace, repoSlug, pullRequestId, taskId)
+				},
+			})
+		})
+	}
+}
+
+func TestClient_ListCommits(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, pagelen, page := "test_workspace", "test-repo", 50, 2
+	include, exclude, path := "feature", "main", "src/main.go"
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/commits_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/repository_mock_404.json",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.ApiResponse[client.Commit]]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s", "repositories", workspace, repoSlug, "commits"),
+				Query: map[string]string{
+					"pagelen": "50",
+					"page":    "2",
+					"include": include,
+					"exclude": exclude,
+					"path":    path,
+				},
+				Decode: DecodeJson[client.ApiResponse[client.Commit]],
+				CallClient: func(bb *client.Client) (*client.ApiResponse[client.Commit], error) {
+					return bb.ListCommits(context.Background(), workspace, repoSlug, pagelen, page, include, exclude, path)
+				},
+			})
+		})
+	}
+}
+
+func TestClient_GetCommit(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, commit := "test_workspace", "test-repo", "abc123def456"
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/commit_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/commit_mock_404.json",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.Commit]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s/%s", "repositories", workspace, repoSlug, "commit", commit),
+				Decode:       DecodeJson[client.Commit],
+				CallClient: func(bb *client.Client) (*client.Commit, error) {
+					return bb.GetCommit(context.Background(), workspace, repoSlug, commit)
+				},
+			})
+		})
+	}
+}
+
+func TestClient_GetDiff(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, spec, path := "test_workspace", "test-repo", "abc123def456", "src/main.go"
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/pull_request_diff_mock.txt",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/commit_mock_404.json",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[string]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s/%s", "repositories", workspace, repoSlug, "diff", spec),
+				Query:        map[string]string{"path": path},
+				Decode:       DecodeText,
+				CallClient: func(bb *client.Client) (*string, error) {
+					return bb.GetDiff(context.Background(), workspace, repoSlug, spec, path)
+				},
+			})
+		})
+	}
+}
+
+func TestClient_GetDiffStat(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, spec, pagelen, page := "test_workspace", "test-repo", "abc123def456", 500, 1
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/pull_request_diffstat_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/commit_mock_404.json",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.ApiResponse[client.DiffStat]]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s/%s", "repositories", workspace, repoSlug, "diffstat", spec),
+				Query:        map[string]string{"pagelen": "500", "page": "1"},
+				Decode:       DecodeJson[client.ApiResponse[client.DiffStat]],
+				CallClient: func(bb *client.Client) (*client.ApiResponse[client.DiffStat], error) {
+					return bb.GetDiffStat(context.Background(), workspace, repoSlug, spec, "", pagelen, page)
+				},
+			})
+		})
+	}
+}
+
+func TestClient_ListBranches(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, pagelen, page := "test_workspace", "test-repo", 10, 1
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/branches_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/repository_mock_404.json",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.ApiResponse[client.Branch]]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s/%s", "repositories", workspace, repoSlug, "refs", "branches"),
+				Query:        map[string]string{"pagelen": "10", "page": "1", "q": `name ~ "release"`, "sort": "-target.date"},
+				Decode:       DecodeJson[client.ApiResponse[client.Branch]],
+				CallClient: func(bb *client.Client) (*client.ApiResponse[client.Branch], error) {
+					query := bbql.New().Where(bbql.Contains("name", "release")).SortDesc("target.date")
+					return bb.ListBranches(context.Background(), workspace, repoSlug, pagelen, page, query)
+				},
+			})
+		})
+	}
+}
+
+func TestClient_ListTags(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, pagelen, page := "test_workspace", "test-repo", 10, 1
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/tags_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/repository_mock_404.json",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.ApiResponse[client.Tag]]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s/%s", "repositories", workspace, repoSlug, "refs", "tags"),
+				Query:        map[string]string{"pagelen": "10", "page": "1", "sort": "-target.date"},
+				Decode:       DecodeJson[client.ApiResponse[client.Tag]],
+				CallClient: func(bb *client.Client) (*client.ApiResponse[client.Tag], error) {
+					return bb.ListTags(context.Background(), workspace, repoSlug, pagelen, page, bbql.New().SortDesc("target.date"))
+				},
+			})
+		})
+	}
+}
+
+func TestClient_ListRefs(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, pagelen, page := "test_workspace", "test-repo", 10, 1
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/refs_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/repository_mock_404.json",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.ApiResponse[client.Tag]]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s", "repositories", workspace, repoSlug, "refs"),
+				Query:        map[string]string{"pagelen": "10", "page": "1"},
+				Decode:       DecodeJson[client.ApiResponse[client.Tag]],
+				CallClient: func(bb *client.Client) (*client.ApiResponse[client.Tag], error) {
+					return bb.ListRefs(context.Background(), workspace, repoSlug, pagelen, page, nil)
+				},
+			})
+		})
+	}
+}
+
+func TestClient_CreateTag(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug := "test_workspace", "test-repo"
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 201,
+			File:   "testdata/tag_mock.json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.Tag]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s/%s", "repositories", workspace, repoSlug, "refs", "tags"),
+				Decode:       DecodeJson[client.Tag],
+				CallClient: func(bb *client.Client) (*client.Tag, error) {
+					return bb.CreateTag(context.Background(), workspace, repoSlug, &client.CreateTagRequest{
+						Name:    "v1.2.0",
+						Target:  client.CreateBranchTarget{Hash: "def456abc123456789012345678901234567890ab"},
+						Message: "Release 1.2.0\n",
+					})
+				},
+			})
+		})
+	}
+}
+
+func TestClient_TriggerPipeline(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug := "test_workspace", "test-repo"
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 201,
+			File:   "testdata/pipeline_triggered_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/repository_mock_404.json",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.Pipeline]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s", "repositories", workspace, repoSlug, "pipelines"),
+				Decode:       DecodeJson[client.Pipeline],
+				CallClient: func(bb *client.Client) (*client.Pipeline, error) {
+					return bb.TriggerPipeline(context.Background(), workspace, repoSlug, &client.TriggerPipelineRequest{
+						Target: client.PipelineTarget{
+							Type:     "pipeline_ref_target",
+							RefType:  "branch",
+							RefName:  "main",
+							Selector: &client.PipelineSelector{Type: "custom", Pattern: "deploy-staging"},
+						},
+						Variables: []client.PipelineVariable{{Type: "pipeline_variable", Key: "ENV", Value: "staging"}},
+					})
+				},
+			})
+		})
+	}
+}
+
+func TestClient_ListPipelines(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, pagelen, page := "test_workspace", "test-repo", 10, 1
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/pipelines_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/repository_mock_404.json",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.ApiResponse[client.Pipeline]]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s", "repositories", workspace, repoSlug, "pipelines"),
+				Query:        map[string]string{"pagelen": "10", "page": "1", "sort": "-created_on", "target.branch": "main", "status": "FAILED"},
+				Decode:       DecodeJson[client.ApiResponse[client.Pipeline]],
+				CallClient: func(bb *client.Client) (*client.ApiResponse[client.Pipeline], error) {
+					return bb.ListPipelines(context.Background(), workspace, repoSlug, pagelen, page, "main", "FAILED")
+				},
+			})
+		})
+	}
+}
+
+func TestClient_GetPipeline(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, pipeline := "test_workspace", "test-repo", "42"
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/pipeline_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/repository_mock_404.json",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.Pipeline]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s/%s", "repositories", workspace, repoSlug, "pipelines", pipeline),
+				Decode:       DecodeJson[client.Pipeline],
+				CallClient: func(bb *client.Client) (*client.Pipeline, error) {
+					return bb.GetPipeline(context.Background(), workspace, repoSlug, pipeline)
+				},
+			})
+		})
+	}
+}
+
+func TestClient_ListPipelineSteps(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, pipelineUUID, pagelen, page := "test_workspace", "test-repo", "{9f1c2e3a-4b5d-4e6f-8a9b-0c1d2e3f4a5b}", 100, 1
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/pipeline_steps_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/repository_mock_404.json",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.ApiResponse[client.PipelineStep]]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s/%s/%s", "repositories", workspace, repoSlug, "pipelines", "{pipeline}", "steps"),
+				Query:        map[string]string{"pagelen": "100", "page": "1"},
+				Decode:       DecodeJson[client.ApiResponse[client.PipelineStep]],
+				CallClient: func(bb *client.Client) (*client.ApiResponse[client.PipelineStep], error) {
+					return bb.ListPipelineSteps(context.Background(), workspace, repoSlug, pipelineUUID, pagelen, page)
+				},
+			})
+		})
+	}
+}
+
+func TestClient_GetPipelineStepLog(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug := "test_workspace", "test-repo"
+	pipelineUUID, stepUUID := "{9f1c2e3a-4b5d-4e6f-8a9b-0c1d2e3f4a5b}", "{b2c3d4e5-f6a7-4b8c-9d0e-1f2a3b4c5d6e}"
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/pipeline_step_log_mock.txt",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/repository_mock_404.json",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.PipelineStepLog]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s/%s/%s/%s/%s", "repositories", workspace, repoSlug, "pipelines", "{pipeline}", "steps", "{step}", "log"),
+				Decode: func(data []byte, res *client.PipelineStepLog) error {
+					res.Content = string(data)
+					return nil
+				},
+				CallClient: func(bb *client.Client) (*client.PipelineStepLog, error) {
+					return bb.GetPipelineStepLog(context.Background(), workspace, repoSlug, pipelineUUID, stepUUID, "bytes=-1024")
+				},
+			})
+		})
+	}
 }
 
-type ClientEndpointTestCase struct {
-	Name      string
-	Status    int
-	ErrorCode int64
-	File      string
+func TestClient_ListIssues(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, pagelen, page := "test_workspace", "test-repo", 50, 1
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/issues_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/repository_mock_404.json",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.ApiResponse[client.Issue]]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s", "repositories", workspace, repoSlug, "issues"),
+				Query:        map[string]string{"pagelen": "50", "page": "1", "q": `state = "open" OR state = "new"`, "sort": "-updated_on"},
+				Decode:       DecodeJson[client.ApiResponse[client.Issue]],
+				CallClient: func(bb *client.Client) (*client.ApiResponse[client.Issue], error) {
+					query := bbql.New().Where(bbql.In("state", "open", "new")).SortDesc("updated_on")
+					return bb.ListIssues(context.Background(), workspace, repoSlug, pagelen, page, query)
+				},
+			})
+		})
+	}
 }
 
-type ClientTestCase[T any] struct {
-	MockDataFile string
-	Status       int
-	Path         string
-	CallClient   func(*client.Client) (*T, error)
-	Decode       func(data []byte, res *T) error
-	ErrorCode    int64
+func TestClient_GetIssue(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, issueId := "test_workspace", "test-repo", 1
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/issue_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/issue_mock_404.json",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.Issue]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s/%d", "repositories", workspace, repoSlug, "issues", issueId),
+				Decode:       DecodeJson[client.Issue],
+				CallClient: func(bb *client.Client) (*client.Issue, error) {
+					return bb.GetIssue(context.Background(), workspace, repoSlug, issueId)
+				},
+			})
+		})
+	}
 }
 
-func RunClientTest[T any](t *testing.T, tc ClientTestCase[T]) {
-	t.Helper()
+func TestClient_CreateIssue(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug := "test_workspace", "test-repo"
 
-	mockData, err := os.ReadFile(tc.MockDataFile)
-	require.NoError(t, err, "failed to read mock data file")
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 201,
+			File:   "testdata/issue_created_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/repository_mock_404.json",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
 
-	var expectedResponse T
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.Issue]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s", "repositories", workspace, repoSlug, "issues"),
+				Decode:       DecodeJson[client.Issue],
+				CallClient: func(bb *client.Client) (*client.Issue, error) {
+					return bb.CreateIssue(context.Background(), workspace, repoSlug, &client.CreateIssueRequest{
+						Title:    "Crash on export",
+						Content:  &client.CreatePullRequestCommentContent{Raw: "Exporting a report crashes the app."},
+						Kind:     "bug",
+						Assignee: &client.IssueUserRef{UUID: "{dev-user-uuid}"},
+					})
+				},
+			})
+		})
+	}
+}
 
-	if tc.ErrorCode == 0 {
-		err = tc.Decode(mockData, &expectedResponse)
-		require.NoError(t, err, "failed to decode expected response")
+func TestClient_UpdateIssue(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, issueId := "test_workspace", "test-repo", 1
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/issue_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/issue_mock_404.json",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
 	}
 
-	testUsername := "test_user"
-	testPassword := "test_password"
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.Issue]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s/%d", "repositories", workspace, repoSlug, "issues", issueId),
+				Decode:       DecodeJson[client.Issue],
+				CallClient: func(bb *client.Client) (*client.Issue, error) {
+					return bb.UpdateIssue(context.Background(), workspace, repoSlug, issueId, &client.UpdateIssueRequest{State: "open", Priority: "critical"})
+				},
+			})
+		})
+	}
+}
 
-	serverURL := NewTestServer(t, tc.Path, func(resp http.ResponseWriter, req *http.Request) {
-		actualUsername, actualPassword, ok := req.BasicAuth()
-		require.True(t, ok, "expected basic auth")
-		require.Equal(t, testUsername, actualUsername)
-		require.Equal(t, testPassword, actualPassword)
-		resp.Header().Set("Content-Type", "application/json")
-		resp.WriteHeader(tc.Status)
-		resp.Write(mockData)
-	})
+func TestClient_ListIssueComments(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, issueId, pagelen, page := "test_workspace", "test-repo", 1, 100, 1
 
-	config := client.BitbucketConfig{
-		Url:     serverURL,
-		Timeout: 1,
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/issue_comments_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/issue_mock_404.json",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
 	}
 
-	authorizer := util.NewBasicAuthorizer(testUsername, testPassword)
-	bb := client.NewClient(config, authorizer)
-	actualResponse, err := tc.CallClient(bb)
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.ApiResponse[client.IssueComment]]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s/%d/%s", "repositories", workspace, repoSlug, "issues", issueId, "comments"),
+				Query:        map[string]string{"pagelen": "100", "page": "1"},
+				Decode:       DecodeJson[client.ApiResponse[client.IssueComment]],
+				CallClient: func(bb *client.Client) (*client.ApiResponse[client.IssueComment], error) {
+					return bb.ListIssueComments(context.Background(), workspace, repoSlug, issueId, pagelen, page)
+				},
+			})
+		})
+	}
+}
 
-	if tc.ErrorCode == 0 {
-		require.NoError(t, err)
-		assert.Equal(t, &expectedResponse, actualResponse)
-	} else {
-		require.Error(t, err)
-		var jsonrpcErr *jsonrpc.Error
-		require.ErrorAs(t, err, &jsonrpcErr, "Error should be a jsonrpc.Error")
-		assert.Equal(t, tc.ErrorCode, jsonrpcErr.Code, "Error code should match")
-		assert.Nil(t, actualResponse)
+func TestClient_CreateIssueComment(t *testing.T) {
+	t.Parallel()
+	workspace, repoSlug, issueId := "test_workspace", "test-repo", 1
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 201,
+			File:   "testdata/issue_comment_mock.json",
+		},
+		{
+			Name:      "Not Found",
+			Status:    404,
+			File:      "testdata/issue_mock_404.json",
+			ErrorCode: util.CodeResourceNotFoundErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.IssueComment]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s/%d/%s", "repositories", workspace, repoSlug, "issues", issueId, "comments"),
+				Decode:       DecodeJson[client.IssueComment],
+				CallClient: func(bb *client.Client) (*client.IssueComment, error) {
+					return bb.CreateIssueComment(context.Background(), workspace, repoSlug, issueId, &client.CreateIssueCommentRequest{
+						Content: client.CreatePullRequestCommentContent{Raw: "Fixed in the next release."},
+					})
+				},
+			})
+		})
 	}
 }
 
-func NewTestServer(t *testing.T, pattern string, handle func(http.ResponseWriter, *http.Request)) string {
-	t.Helper()
-	handler := http.NewServeMux()
-	handler.HandleFunc(pattern, handle)
-	server := httptest.NewServer(handler)
-	t.Cleanup(server.Close)
-	return server.URL
+func TestClient_SearchCode(t *testing.T) {
+	t.Parallel()
+	workspace, query, pagelen, page := "test-workspace", "NewClient lang:go", 10, 1
+
+	tests := []ClientEndpointTestCase{
+		{
+			Name:   "Success",
+			Status: 200,
+			File:   "testdata/code_search_mock.json",
+		},
+		{
+			Name:      "Invalid Query",
+			Status:    400,
+			File:      "testdata/code_search_mock_400.json",
+			ErrorCode: util.CodeInvalidParamsErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			RunClientTest(t, ClientTestCase[client.CodeSearchResponse]{
+				Status:       tt.Status,
+				MockDataFile: tt.File,
+				ErrorCode:    tt.ErrorCode,
+				Path:         fmt.Sprintf("/%s/%s/%s/%s", "workspaces", workspace, "search", "code"),
+				Query: map[string]string{
+					"search_query": query,
+					"pagelen":      "10",
+					"page":         "1",
+					"fields":       "+values.file.commit.repository",
+				},
+				Decode: DecodeJson[client.CodeSearchResponse],
+				CallClient: func(bb *client.Client) (*client.CodeSearchResponse, error) {
+					return bb.SearchCode(context.Background(), workspace, query, pagelen, page)
+				},
+			})
+		})
+	}
 }
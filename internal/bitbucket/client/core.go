@@ -23,6 +23,8 @@ type BitbucketRequest[T any] struct {
 	Path []string
 	// Query contains URL query parameters
 	Query map[string]string
+	// Header contains additional HTTP request headers (e.g. Range)
+	Header map[string]string
 	// Body is the request body to be serialized (nil for GET requests)
 	Body *T
 	// Mime specifies the Content-Type for the request body
@@ -42,6 +44,13 @@ type BitbucketResponse[T any] struct {
 	Body *T
 	// Mime specifies the expected Content-Type of the response
 	Mime web.Mime
+	// Status is set to the HTTP status code once the request is performed
+	Status int
+	// Header is set to the HTTP response headers once the request is performed
+	Header http.Header
+	// Accepted marks an operation Bitbucket may accept with 202 and an empty body,
+	// which is then not deserialized so that callers can follow up using Status and Header
+	Accepted bool
 }
 
 // Perform executes a Bitbucket API request and deserializes the response.
@@ -90,6 +99,10 @@ func buildRequest[T any](bbReq *BitbucketRequest[T]) (*http.Request, error) {
 		return nil, util.NewInternalError()
 	}
 
+	for key, value := range bbReq.Header {
+		req.Header.Set(key, value)
+	}
+
 	if bbReq.Authorizer.Authorize(bbReq.Context, req) != nil {
 		slog.Error("Authorization failed", util.NewLogArgsExtractor().AddError(err).AddRequest(req).Extract()...)
 		return nil, util.NewInternalError()
@@ -99,6 +112,9 @@ func buildRequest[T any](bbReq *BitbucketRequest[T]) (*http.Request, error) {
 }
 
 func readResponse[T any](resp *http.Response, bbResp *BitbucketResponse[T]) error {
+	bbResp.Status = resp.StatusCode
+	bbResp.Header = resp.Header
+
 	switch {
 	case resp.StatusCode >= 500:
 		return util.NewResourceUnavailableError(fmt.Sprintf("Bitbucket service unavailable (status %d)", resp.StatusCode))
@@ -119,6 +135,9 @@ func readResponse[T any](resp *http.Response, bbResp *BitbucketResponse[T]) erro
 			message = errResp.Error.Message
 		}
 		return util.NewInvalidParamsError(message)
+	case resp.StatusCode == http.StatusAccepted && bbResp.Accepted:
+		resp.Body.Close()
+		return nil
 	}
 
 	if err := web.ReadResponseBody(resp, bbResp.Mime, bbResp.Body); err != nil {
@@ -92,6 +92,40 @@ func TestPerform_Success(t *testing.T) {
 		err := client.Perform(req, resp)
 		require.NoError(t, err)
 	})
+
+	t.Run("with request headers", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "bytes=-5", r.Header.Get("Range"))
+			w.Header().Set("Content-Range", "bytes 6-10/11")
+			w.WriteHeader(http.StatusPartialContent)
+			w.Write([]byte("world"))
+		}))
+		defer server.Close()
+
+		req := &client.BitbucketRequest[TestBody]{
+			Method:     "GET",
+			BaseUrl:    server.URL,
+			Path:       []string{"api", "log"},
+			Header:     map[string]string{"Range": "bytes=-5"},
+			Mime:       web.MimeOmit,
+			Authorizer: util.NewBasicAuthorizer("user", "pass"),
+			Context:    context.Background(),
+			Client:     server.Client(),
+		}
+
+		resp := &client.BitbucketResponse[string]{
+			Body: new(string),
+			Mime: web.MimeTextPlain,
+		}
+
+		err := client.Perform(req, resp)
+		require.NoError(t, err)
+		assert.Equal(t, "world", *resp.Body)
+		assert.Equal(t, http.StatusPartialContent, resp.Status)
+		assert.Equal(t, "bytes 6-10/11", resp.Header.Get("Content-Range"))
+	})
 }
 
 func TestPerform_ServerError(t *testing.T) {
@@ -104,11 +104,16 @@ type Workspace struct {
 }
 
 type Project struct {
-	Type  string      `json:"type"`
-	Key   string      `json:"key"`
-	UUID  string      `json:"uuid"`
-	Name  string      `json:"name"`
-	Links CommonLinks `json:"links"`
+	Type                    string      `json:"type"`
+	Key                     string      `json:"key"`
+	UUID                    string      `json:"uuid"`
+	Name                    string      `json:"name"`
+	Description             string      `json:"description"`
+	IsPrivate               bool        `json:"is_private"`
+	HasPubliclyVisibleRepos bool        `json:"has_publicly_visible_repos"`
+	CreatedOn               string      `json:"created_on"`
+	UpdatedOn               string      `json:"updated_on"`
+	Links                   CommonLinks `json:"links"`
 }
 
 type MainBranch struct {
@@ -365,7 +370,153 @@ type PullRequestCommentParentLinks struct {
 }
 
 type PullRequestCommentResolution struct {
-	Type *string `json:"type,omitempty"`
+	Type      *string `json:"type,omitempty"`
+	User      *User   `json:"user,omitempty"`
+	CreatedOn *string `json:"created_on,omitempty"`
+}
+
+type PullRequestActivity struct {
+	Update           *PullRequestActivityUpdate    `json:"update,omitempty"`
+	Approval         *PullRequestActivityApproval  `json:"approval,omitempty"`
+	ChangesRequested *PullRequestActivityApproval  `json:"changes_requested,omitempty"`
+	Comment          *PullRequestComment           `json:"comment,omitempty"`
+	PullRequest      PullRequestCommentPullRequest `json:"pull_request"`
+}
+
+type PullRequestActivityUpdate struct {
+	State       string            `json:"state"`
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+	Reason      string            `json:"reason"`
+	Draft       bool              `json:"draft"`
+	Date        string            `json:"date"`
+	Author      User              `json:"author"`
+	Source      PullRequestBranch `json:"source"`
+	Destination PullRequestBranch `json:"destination"`
+	Changes     map[string]any    `json:"changes,omitempty"`
+}
+
+type PullRequestActivityApproval struct {
+	Date        string                        `json:"date"`
+	User        User                          `json:"user"`
+	PullRequest PullRequestCommentPullRequest `json:"pullrequest"`
+}
+
+type PullRequestTask struct {
+	ID         int                     `json:"id"`
+	Type       string                  `json:"type"`
+	CreatedOn  string                  `json:"created_on"`
+	UpdatedOn  string                  `json:"updated_on"`
+	State      string                  `json:"state"`
+	Content    PullRequestTaskContent  `json:"content"`
+	Creator    User                    `json:"creator"`
+	Pending    bool                    `json:"pending"`
+	ResolvedOn *string                 `json:"resolved_on,omitempty"`
+	ResolvedBy *User                   `json:"resolved_by,omitempty"`
+	Comment    *PullRequestTaskComment `json:"comment,omitempty"`
+	Links      PRLinks                 `json:"links"`
+}
+
+type PullRequestTaskContent struct {
+	Type   string `json:"type"`
+	Raw    string `json:"raw"`
+	Markup string `json:"markup"`
+	HTML   string `json:"html"`
+}
+
+type PullRequestTaskComment struct {
+	ID    int     `json:"id"`
+	Type  string  `json:"type"`
+	Links PRLinks `json:"links"`
+}
+
+type DefaultReviewer struct {
+	Type         string `json:"type"`
+	ReviewerType string `json:"reviewer_type"`
+	User         User   `json:"user"`
+}
+
+type UserEmail struct {
+	Type        string      `json:"type"`
+	Email       string      `json:"email"`
+	IsPrimary   bool        `json:"is_primary"`
+	IsConfirmed bool        `json:"is_confirmed"`
+	Links       CommonLinks `json:"links"`
+}
+
+type WorkspaceMembership struct {
+	Type       string      `json:"type"`
+	Permission string      `json:"permission"`
+	User       User        `json:"user"`
+	Workspace  Workspace   `json:"workspace"`
+	Links      CommonLinks `json:"links"`
+}
+
+type DiffStat struct {
+	Type         string        `json:"type"`
+	Status       string        `json:"status"`
+	LinesAdded   int           `json:"lines_added"`
+	LinesRemoved int           `json:"lines_removed"`
+	Old          *DiffStatFile `json:"old"`
+	New          *DiffStatFile `json:"new"`
+}
+
+type DiffStatFile struct {
+	Type        string  `json:"type"`
+	Path        string  `json:"path"`
+	EscapedPath string  `json:"escaped_path,omitempty"`
+	Links       PRLinks `json:"links"`
+}
+
+type CommitStatus struct {
+	Type        string            `json:"type"`
+	UUID        string            `json:"uuid"`
+	Key         string            `json:"key"`
+	RefName     *string           `json:"refname"`
+	URL         string            `json:"url"`
+	State       string            `json:"state"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	CreatedOn   string            `json:"created_on"`
+	UpdatedOn   string            `json:"updated_on"`
+	Links       CommitStatusLinks `json:"links"`
+}
+
+type CommitStatusLinks struct {
+	Self   Link `json:"self"`
+	Commit Link `json:"commit"`
+}
+
+type BranchRestriction struct {
+	Type            string  `json:"type"`
+	ID              int     `json:"id"`
+	Kind            string  `json:"kind"`
+	BranchMatchKind string  `json:"branch_match_kind"`
+	BranchType      string  `json:"branch_type,omitempty"`
+	Pattern         string  `json:"pattern"`
+	Value           *int    `json:"value"`
+	Users           []User  `json:"users"`
+	Groups          []any   `json:"groups"`
+	Links           PRLinks `json:"links"`
+}
+
+type BranchingModel struct {
+	Type        string                `json:"type"`
+	Development *BranchingModelBranch `json:"development,omitempty"`
+	Production  *BranchingModelBranch `json:"production,omitempty"`
+	BranchTypes []BranchingModelType  `json:"branch_types"`
+	Links       PRLinks               `json:"links"`
+}
+
+type BranchingModelBranch struct {
+	Name          string  `json:"name"`
+	UseMainbranch bool    `json:"use_mainbranch"`
+	Branch        *Branch `json:"branch,omitempty"`
+}
+
+type BranchingModelType struct {
+	Kind   string `json:"kind"`
+	Prefix string `json:"prefix"`
 }
 
 type CreateRepositoryRequest struct {
@@ -428,6 +579,95 @@ type CreateBranchTarget struct {
 	Hash string `json:"hash"`
 }
 
+type CreateBuildStatusRequest struct {
+	Key         string `json:"key"`
+	State       string `json:"state"`
+	Name        string `json:"name,omitempty"`
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+	RefName     string `json:"refname,omitempty"`
+}
+
+type Report struct {
+	Type       string       `json:"type"`
+	UUID       string       `json:"uuid"`
+	ExternalID string       `json:"external_id"`
+	Title      string       `json:"title"`
+	Details    string       `json:"details"`
+	ReportType string       `json:"report_type"`
+	Reporter   string       `json:"reporter,omitempty"`
+	Link       string       `json:"link,omitempty"`
+	Result     string       `json:"result,omitempty"`
+	Data       []ReportData `json:"data,omitempty"`
+	CreatedOn  string       `json:"created_on"`
+	UpdatedOn  string       `json:"updated_on"`
+}
+
+type ReportData struct {
+	Title string `json:"title"`
+	Type  string `json:"type"`
+	Value any    `json:"value"`
+}
+
+type CreateReportRequest struct {
+	Title      string       `json:"title"`
+	Details    string       `json:"details"`
+	ReportType string       `json:"report_type"`
+	Reporter   string       `json:"reporter,omitempty"`
+	Link       string       `json:"link,omitempty"`
+	Result     string       `json:"result,omitempty"`
+	Data       []ReportData `json:"data,omitempty"`
+}
+
+type Annotation struct {
+	Type           string `json:"type"`
+	UUID           string `json:"uuid"`
+	ExternalID     string `json:"external_id"`
+	AnnotationType string `json:"annotation_type"`
+	Path           string `json:"path,omitempty"`
+	Line           int    `json:"line,omitempty"`
+	Summary        string `json:"summary"`
+	Details        string `json:"details,omitempty"`
+	Severity       string `json:"severity,omitempty"`
+	Result         string `json:"result,omitempty"`
+	Link           string `json:"link,omitempty"`
+	CreatedOn      string `json:"created_on"`
+	UpdatedOn      string `json:"updated_on"`
+}
+
+type CreateAnnotationRequest struct {
+	ExternalID     string `json:"external_id"`
+	AnnotationType string `json:"annotation_type"`
+	Path           string `json:"path,omitempty"`
+	Line           int    `json:"line,omitempty"`
+	Summary        string `json:"summary"`
+	Details        string `json:"details,omitempty"`
+	Severity       string `json:"severity,omitempty"`
+	Result         string `json:"result,omitempty"`
+	Link           string `json:"link,omitempty"`
+}
+
+type TriggerPipelineRequest struct {
+	Target    PipelineTarget     `json:"target"`
+	Variables []PipelineVariable `json:"variables,omitempty"`
+}
+
+type Tag struct {
+	Type    string        `json:"type"`
+	Name    string        `json:"name"`
+	Target  BranchTarget  `json:"target"`
+	Message string        `json:"message,omitempty"`
+	Date    string        `json:"date,omitempty"`
+	Tagger  *CommitAuthor `json:"tagger,omitempty"`
+	Links   BranchLinks   `json:"links"`
+}
+
+type CreateTagRequest struct {
+	Name    string             `json:"name"`
+	Target  CreateBranchTarget `json:"target"`
+	Message string             `json:"message,omitempty"`
+}
+
 type CreatePullRequestRequest struct {
 	Title             string                      `json:"title"`
 	Description       string                      `json:"description,omitempty"`
@@ -450,6 +690,15 @@ type CreatePullRequestReviewer struct {
 	UUID string `json:"uuid"`
 }
 
+type UpdatePullRequestRequest struct {
+	Title             string                      `json:"title"`
+	Description       *string                     `json:"description,omitempty"`
+	Destination       *CreatePullRequestBranch    `json:"destination,omitempty"`
+	CloseSourceBranch *bool                       `json:"close_source_branch,omitempty"`
+	Draft             *bool                       `json:"draft,omitempty"`
+	Reviewers         []CreatePullRequestReviewer `json:"reviewers"`
+}
+
 type CreatePullRequestCommentRequest struct {
 	Content CreatePullRequestCommentContent `json:"content"`
 	Inline  *PullRequestCommentInline       `json:"inline,omitempty"`
@@ -465,3 +714,237 @@ type MergePullRequestRequest struct {
 	CloseSourceBranch *bool  `json:"close_source_branch,omitempty"`
 	MergeStrategy     string `json:"merge_strategy,omitempty"`
 }
+
+type PullRequestMerge struct {
+	PullRequest *PullRequest
+	TaskID      string
+}
+
+type MergeTaskStatus struct {
+	TaskStatus  string       `json:"task_status"`
+	MergeResult *PullRequest `json:"merge_result,omitempty"`
+}
+
+type CreatePullRequestTaskRequest struct {
+	Content CreatePullRequestCommentContent `json:"content"`
+	Comment *PullRequestTaskCommentRef      `json:"comment,omitempty"`
+	Pending *bool                           `json:"pending,omitempty"`
+}
+
+type PullRequestTaskCommentRef struct {
+	ID int `json:"id"`
+}
+
+type UpdatePullRequestTaskRequest struct {
+	Content *CreatePullRequestCommentContent `json:"content,omitempty"`
+	State   string                           `json:"state,omitempty"`
+}
+
+type Pipeline struct {
+	Type             string             `json:"type"`
+	UUID             string             `json:"uuid"`
+	BuildNumber      int                `json:"build_number"`
+	Creator          *User              `json:"creator,omitempty"`
+	Target           PipelineTarget     `json:"target"`
+	Trigger          PipelineTrigger    `json:"trigger"`
+	State            PipelineState      `json:"state"`
+	CreatedOn        string             `json:"created_on"`
+	CompletedOn      string             `json:"completed_on,omitempty"`
+	BuildSecondsUsed int                `json:"build_seconds_used"`
+	RunNumber        int                `json:"run_number,omitempty"`
+	Variables        []PipelineVariable `json:"variables,omitempty"`
+}
+
+type PipelineTarget struct {
+	Type        string                  `json:"type"`
+	RefType     string                  `json:"ref_type,omitempty"`
+	RefName     string                  `json:"ref_name,omitempty"`
+	Source      string                  `json:"source,omitempty"`
+	Destination string                  `json:"destination,omitempty"`
+	Commit      *PipelineCommit         `json:"commit,omitempty"`
+	Selector    *PipelineSelector       `json:"selector,omitempty"`
+	PullRequest *PipelinePullRequestRef `json:"pullrequest,omitempty"`
+}
+
+type PipelineCommit struct {
+	Type string `json:"type"`
+	Hash string `json:"hash"`
+}
+
+type PipelineSelector struct {
+	Type    string `json:"type"`
+	Pattern string `json:"pattern,omitempty"`
+}
+
+type PipelinePullRequestRef struct {
+	Type string `json:"type"`
+	ID   int    `json:"id"`
+}
+
+type PipelineTrigger struct {
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+}
+
+type PipelineState struct {
+	Type   string              `json:"type"`
+	Name   string              `json:"name"`
+	Result *PipelineStateValue `json:"result,omitempty"`
+	Stage  *PipelineStateValue `json:"stage,omitempty"`
+}
+
+type PipelineStateValue struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type PipelineVariable struct {
+	Type    string `json:"type"`
+	Key     string `json:"key"`
+	Value   string `json:"value,omitempty"`
+	Secured bool   `json:"secured,omitempty"`
+}
+
+type PipelineStep struct {
+	Type              string            `json:"type"`
+	UUID              string            `json:"uuid"`
+	Name              string            `json:"name"`
+	State             PipelineState     `json:"state"`
+	StartedOn         string            `json:"started_on,omitempty"`
+	CompletedOn       string            `json:"completed_on,omitempty"`
+	DurationInSeconds int               `json:"duration_in_seconds,omitempty"`
+	Image             *PipelineImage    `json:"image,omitempty"`
+	SetupCommands     []PipelineCommand `json:"setup_commands,omitempty"`
+	ScriptCommands    []PipelineCommand `json:"script_commands,omitempty"`
+	RunNumber         int               `json:"run_number,omitempty"`
+}
+
+type PipelineImage struct {
+	Name string `json:"name"`
+}
+
+type PipelineCommand struct {
+	Name    string `json:"name"`
+	Command string `json:"command"`
+	Action  string `json:"action,omitempty"`
+}
+
+type PipelineStepLog struct {
+	Content      string
+	ContentRange string
+}
+
+type Issue struct {
+	Type      string         `json:"type"`
+	ID        int            `json:"id"`
+	Title     string         `json:"title"`
+	Content   *IssueContent  `json:"content,omitempty"`
+	Reporter  *User          `json:"reporter,omitempty"`
+	Assignee  *User          `json:"assignee,omitempty"`
+	State     string         `json:"state"`
+	Kind      string         `json:"kind"`
+	Priority  string         `json:"priority"`
+	Component *IssueCategory `json:"component,omitempty"`
+	Milestone *IssueCategory `json:"milestone,omitempty"`
+	Version   *IssueCategory `json:"version,omitempty"`
+	Votes     int            `json:"votes"`
+	CreatedOn string         `json:"created_on"`
+	UpdatedOn string         `json:"updated_on"`
+	EditedOn  *string        `json:"edited_on,omitempty"`
+	Links     IssueLinks     `json:"links"`
+}
+
+type IssueContent struct {
+	Type   string `json:"type"`
+	Raw    string `json:"raw"`
+	Markup string `json:"markup"`
+	HTML   string `json:"html"`
+}
+
+type IssueCategory struct {
+	Name string `json:"name"`
+}
+
+type IssueLinks struct {
+	Self     Link `json:"self"`
+	HTML     Link `json:"html"`
+	Comments Link `json:"comments"`
+}
+
+type IssueComment struct {
+	Type      string        `json:"type"`
+	ID        int           `json:"id"`
+	Content   *IssueContent `json:"content,omitempty"`
+	User      *User         `json:"user,omitempty"`
+	CreatedOn string        `json:"created_on"`
+	UpdatedOn *string       `json:"updated_on,omitempty"`
+}
+
+type CreateIssueRequest struct {
+	Title     string                           `json:"title"`
+	Content   *CreatePullRequestCommentContent `json:"content,omitempty"`
+	Kind      string                           `json:"kind,omitempty"`
+	Priority  string                           `json:"priority,omitempty"`
+	Assignee  *IssueUserRef                    `json:"assignee,omitempty"`
+	Component *IssueCategory                   `json:"component,omitempty"`
+	Milestone *IssueCategory                   `json:"milestone,omitempty"`
+	Version   *IssueCategory                   `json:"version,omitempty"`
+}
+
+type UpdateIssueRequest struct {
+	Title    string                           `json:"title,omitempty"`
+	Content  *CreatePullRequestCommentContent `json:"content,omitempty"`
+	State    string                           `json:"state,omitempty"`
+	Kind     string                           `json:"kind,omitempty"`
+	Priority string                           `json:"priority,omitempty"`
+	Assignee *IssueUserRef                    `json:"assignee,omitempty"`
+}
+
+type IssueUserRef struct {
+	UUID string `json:"uuid"`
+}
+
+type CreateIssueCommentRequest struct {
+	Content CreatePullRequestCommentContent `json:"content"`
+}
+
+type CodeSearchResponse struct {
+	ApiResponse[CodeSearchResult]
+	QuerySubstituted bool `json:"query_substituted"`
+}
+
+type CodeSearchResult struct {
+	Type              string                   `json:"type"`
+	ContentMatchCount int                      `json:"content_match_count"`
+	ContentMatches    []CodeSearchContentMatch `json:"content_matches"`
+	PathMatches       []CodeSearchSegment      `json:"path_matches"`
+	File              CodeSearchFile           `json:"file"`
+}
+
+type CodeSearchContentMatch struct {
+	Lines []CodeSearchLine `json:"lines"`
+}
+
+type CodeSearchLine struct {
+	Line     int                 `json:"line"`
+	Segments []CodeSearchSegment `json:"segments"`
+}
+
+type CodeSearchSegment struct {
+	Text  string `json:"text"`
+	Match bool   `json:"match,omitempty"`
+}
+
+type CodeSearchFile struct {
+	Type   string           `json:"type"`
+	Path   string           `json:"path"`
+	Commit CodeSearchCommit `json:"commit"`
+	Links  CommonLinks      `json:"links"`
+}
+
+type CodeSearchCommit struct {
+	Type       string           `json:"type"`
+	Hash       string           `json:"hash"`
+	Repository CommitRepository `json:"repository"`
+	Links      CommonLinks      `json:"links"`
+}
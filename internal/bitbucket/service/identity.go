@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/branow/mcp-bitbucket/internal/bitbucket/client"
+	"golang.org/x/sync/errgroup"
+)
+
+// identityTTL limits how long the identity behind a set of credentials is cached.
+const identityTTL = time.Hour
+
+// identityCache caches the authenticated user per credentials key,
+// so that the identity behind an OAuth token is only fetched once per session.
+type identityCache struct {
+	mu      sync.Mutex
+	entries map[string]identityEntry
+}
+
+type identityEntry struct {
+	user    *CurrentUser
+	emails  bool // whether the email addresses of the user were fetched
+	expires time.Time
+}
+
+func newIdentityCache() *identityCache {
+	return &identityCache{entries: map[string]identityEntry{}}
+}
+
+// get returns the cached user of the credentials key and whether their email addresses were fetched,
+// or nil if the user is missing or expired.
+func (c *identityCache) get(key string) (*CurrentUser, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expires) {
+		return nil, false
+	}
+	return entry.user, entry.emails
+}
+
+// put caches the user of the credentials key and drops the expired entries.
+// A user cached with their email addresses is kept until it expires.
+func (c *identityCache) put(key string, user *CurrentUser, emails bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for k, entry := range c.entries {
+		if now.After(entry.expires) {
+			delete(c.entries, k)
+		}
+	}
+	if entry, ok := c.entries[key]; ok && entry.emails && !emails {
+		return
+	}
+	c.entries[key] = identityEntry{user: user, emails: emails, expires: now.Add(identityTTL)}
+}
+
+// GetCurrentUser retrieves the user the service acts as, with their email addresses.
+// The user and the emails are fetched in parallel and cached per credentials, e.g. per OAuth token,
+// so repeated calls within a session need no requests; if the user was already cached by another
+// feature, only the emails are fetched. If the credentials are not allowed to read the email
+// addresses, the user is returned without them.
+//
+// Parameters:
+//   - ctx: Context for the request, carrying the credentials of the session
+//
+// Returns the CurrentUser, or an error if the request fails.
+func (s *Service) GetCurrentUser(ctx context.Context) (*CurrentUser, error) {
+	key, err := s.client.CredentialsKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cached, hasEmails := s.identities.get(key)
+	if cached != nil && hasEmails {
+		return cached, nil
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+
+	var user *client.User
+	var emails []client.UserEmail
+
+	if cached == nil {
+		g.Go(func() error {
+			var err error
+			user, err = s.client.GetCurrentUser(gctx)
+			return err
+		})
+	}
+
+	g.Go(func() error {
+		var err error
+		emails, err = fetchAll(func(page int) (*client.ApiResponse[client.UserEmail], error) {
+			return s.client.ListUserEmails(gctx, 100, page)
+		})
+		return err
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	current := &CurrentUser{Emails: MapList(emails, MapUserEmail)}
+	if cached != nil {
+		current.User = cached.User
+	} else {
+		current.User = *MapUser(user)
+	}
+	s.identities.put(key, current, true)
+	return current, nil
+}
+
+// currentUser retrieves the user the service acts as, like GetCurrentUser,
+// but without fetching their email addresses if the user is not cached yet.
+//
+// Returns the CurrentUser, possibly without emails, or an error if the request fails.
+func (s *Service) currentUser(ctx context.Context) (*CurrentUser, error) {
+	key, err := s.client.CredentialsKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if cached, _ := s.identities.get(key); cached != nil {
+		return cached, nil
+	}
+
+	user, err := s.client.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	current := MapCurrentUser(user, nil)
+	s.identities.put(key, current, false)
+	return current, nil
+}
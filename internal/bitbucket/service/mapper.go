@@ -1,6 +1,11 @@
 package service
 
-import "github.com/branow/mcp-bitbucket/internal/bitbucket/client"
+import (
+	"slices"
+	"strings"
+
+	"github.com/branow/mcp-bitbucket/internal/bitbucket/client"
+)
 
 // MapRepositoryDetails converts Bitbucket API data to domain RepositoryDetails type.
 // Returns nil if the input repository is nil.
@@ -152,9 +157,13 @@ func MapProject(project *client.Project) *Project {
 		return nil
 	}
 	return &Project{
-		Key:  project.Key,
-		UUID: project.UUID,
-		Name: project.Name,
+		Key:         project.Key,
+		UUID:        project.UUID,
+		Name:        project.Name,
+		Description: project.Description,
+		IsPrivate:   project.IsPrivate,
+		CreatedOn:   project.CreatedOn,
+		UpdatedOn:   project.UpdatedOn,
 	}
 }
 
@@ -197,6 +206,27 @@ func MapWorkspace(workspace *client.Workspace) *Workspace {
 	}
 }
 
+// MapWorkspaceMembership converts a Bitbucket API WorkspaceMembership of the authenticated user
+// to the domain Workspace type including the user's permission.
+// Returns nil if the input membership is nil.
+func MapWorkspaceMembership(membership *client.WorkspaceMembership) *Workspace {
+	if membership == nil {
+		return nil
+	}
+	workspace := MapWorkspace(&membership.Workspace)
+	workspace.Permission = membership.Permission
+	return workspace
+}
+
+// MapWorkspaceMember converts a Bitbucket API WorkspaceMembership to the domain User type of the member.
+// Returns nil if the input membership is nil.
+func MapWorkspaceMember(membership *client.WorkspaceMembership) *User {
+	if membership == nil {
+		return nil
+	}
+	return MapUser(&membership.User)
+}
+
 // MapStringPointer safely dereferences a string pointer, returning an empty string if nil.
 func MapStringPointer(value *string) string {
 	if value == nil {
@@ -206,8 +236,9 @@ func MapStringPointer(value *string) string {
 }
 
 // MapPullRequestDetails converts Bitbucket API data to domain PullRequestDetails type.
+// Comments are expected to be already arranged into threads.
 // Returns nil if the input pull request is nil.
-func MapPullRequestDetails(pr *client.PullRequest, commits *client.ApiResponse[client.Commit], diff *string, comments *client.ApiResponse[client.PullRequestComment]) *PullRequestDetails {
+func MapPullRequestDetails(pr *client.PullRequest, commits *client.ApiResponse[client.Commit], diff *string, comments *Page[PullRequestComment], tasks *Page[PullRequestTask]) *PullRequestDetails {
 	if pr == nil {
 		return nil
 	}
@@ -216,7 +247,8 @@ func MapPullRequestDetails(pr *client.PullRequest, commits *client.ApiResponse[c
 		PullRequest: MapPullRequest(pr),
 		Commits:     MapPage(commits, MapPullRequestCommit),
 		Diff:        diff,
-		Comments:    MapPage(comments, MapPullRequestComment),
+		Comments:    comments,
+		Tasks:       tasks,
 	}
 }
 
@@ -250,6 +282,95 @@ func MapPullRequest(pr *client.PullRequest) *PullRequest {
 	}
 }
 
+// MapBuildStatus aggregates the build statuses reported for a commit into the domain BuildStatus type.
+// Returns nil if no build status was reported.
+func MapBuildStatus(statuses []client.CommitStatus) *BuildStatus {
+	if len(statuses) == 0 {
+		return nil
+	}
+
+	result := &BuildStatus{Builds: MapList(statuses, MapCommitStatus)}
+	for _, status := range statuses {
+		switch status.State {
+		case BuildStateSuccessful:
+			result.Successful++
+		case BuildStateInProgress:
+			result.InProgress++
+		case BuildStateStopped:
+			result.Stopped++
+		default:
+			result.Failed++
+		}
+	}
+
+	switch {
+	case result.Failed > 0 || result.Stopped > 0:
+		result.State = BuildStateFailed
+	case result.InProgress > 0:
+		result.State = BuildStateInProgress
+	default:
+		result.State = BuildStateSuccessful
+	}
+	return result
+}
+
+// MapCommitStatus converts a Bitbucket API CommitStatus to the domain CommitStatus type.
+// Returns nil if the input status is nil.
+func MapCommitStatus(status *client.CommitStatus) *CommitStatus {
+	if status == nil {
+		return nil
+	}
+
+	return &CommitStatus{
+		Key:         status.Key,
+		Name:        status.Name,
+		State:       status.State,
+		URL:         status.URL,
+		Description: status.Description,
+		RefName:     status.RefName,
+		CreatedOn:   status.CreatedOn,
+		UpdatedOn:   status.UpdatedOn,
+	}
+}
+
+// MapUserPullRequest converts a Bitbucket API PullRequest to the domain UserPullRequest type,
+// summarizing participant approvals and the review of the user with the given UUID.
+// Returns nil if the input pull request is nil.
+func MapUserPullRequest(pr *client.PullRequest, userUUID string) *UserPullRequest {
+	if pr == nil {
+		return nil
+	}
+
+	res := &UserPullRequest{PullRequest: *MapPullRequest(pr)}
+	for _, participant := range pr.Participants {
+		if participant.Approved {
+			res.Approvals++
+		}
+		if participant.State != nil && *participant.State == "changes_requested" {
+			res.ChangesRequested++
+		}
+		if participant.User.UUID == userUUID && pr.Author.UUID != userUUID {
+			res.Approved = participant.Approved
+			res.ReviewState = participant.State
+		}
+	}
+	return res
+}
+
+// MapPullRequestReview converts a Bitbucket API PullRequest to the domain PullRequestReview type.
+// Returns nil if the input pull request is nil.
+func MapPullRequestReview(pr *client.PullRequest) *PullRequestReview {
+	if pr == nil {
+		return nil
+	}
+
+	return &PullRequestReview{
+		ID:           pr.ID,
+		State:        pr.State,
+		Participants: MapList(pr.Participants, MapParticipant),
+	}
+}
+
 // MapMergeCommit extracts the commit hash from a merge commit.
 // Returns nil if the commit is nil.
 func MapMergeCommit(commit *client.PullRequestCommit) *string {
@@ -319,6 +440,31 @@ func MapUser(user *client.User) *User {
 	}
 }
 
+// MapCurrentUser converts a Bitbucket API User and their email addresses to the domain CurrentUser type.
+// Returns nil if the input user is nil.
+func MapCurrentUser(user *client.User, emails []client.UserEmail) *CurrentUser {
+	if user == nil {
+		return nil
+	}
+	return &CurrentUser{
+		User:   *MapUser(user),
+		Emails: MapList(emails, MapUserEmail),
+	}
+}
+
+// MapUserEmail converts a Bitbucket API UserEmail to the domain UserEmail type.
+// Returns nil if the input email is nil.
+func MapUserEmail(email *client.UserEmail) *UserEmail {
+	if email == nil {
+		return nil
+	}
+	return &UserEmail{
+		Email:     email.Email,
+		Primary:   email.IsPrimary,
+		Confirmed: email.IsConfirmed,
+	}
+}
+
 // MapPullRequestCommit converts a Bitbucket API Commit to domain PullRequestCommit type.
 // Returns nil if the input commit is nil.
 func MapPullRequestCommit(commit *client.Commit) *PullRequestCommit {
@@ -340,6 +486,106 @@ func MapPullRequestCommit(commit *client.Commit) *PullRequestCommit {
 	}
 }
 
+// MapCommit converts a Bitbucket API Commit to the domain Commit type.
+// Returns nil if the input commit is nil.
+func MapCommit(commit *client.Commit) *Commit {
+	if commit == nil {
+		return nil
+	}
+
+	var author *User
+	if commit.Author.User.UUID != "" {
+		author = MapUser(&commit.Author.User)
+	}
+
+	parents := make([]string, len(commit.Parents))
+	for i, parent := range commit.Parents {
+		parents[i] = parent.Hash
+	}
+
+	return &Commit{
+		Hash:      commit.Hash,
+		Date:      commit.Date,
+		Author:    author,
+		AuthorRaw: commit.Author.Raw,
+		Message:   commit.Message,
+		Parents:   parents,
+	}
+}
+
+// MapBranch converts a Bitbucket API Branch to the domain Ref type.
+// Returns nil if the input branch is nil.
+func MapBranch(branch *client.Branch) *Ref {
+	if branch == nil {
+		return nil
+	}
+
+	return &Ref{
+		Type:   RefTypeBranch,
+		Name:   branch.Name,
+		Target: MapRefTarget(&branch.Target),
+	}
+}
+
+// MapTag converts a Bitbucket API Tag, or a branch listed among refs, to the domain Ref type.
+// Returns nil if the input tag is nil.
+func MapTag(tag *client.Tag) *Ref {
+	if tag == nil {
+		return nil
+	}
+
+	refType := RefTypeTag
+	if tag.Type == "branch" {
+		refType = RefTypeBranch
+	}
+
+	return &Ref{
+		Type:    refType,
+		Name:    tag.Name,
+		Target:  MapRefTarget(&tag.Target),
+		Message: strings.TrimSpace(tag.Message),
+	}
+}
+
+// MapRefTarget converts the target commit of a Bitbucket API branch or tag to the domain Commit type.
+// Returns nil if the input target is nil.
+func MapRefTarget(target *client.BranchTarget) *Commit {
+	if target == nil {
+		return nil
+	}
+
+	commit := &client.Commit{Hash: target.Hash, Date: target.Date, Message: target.Message, Parents: target.Parents}
+	if target.Author != nil {
+		commit.Author = *target.Author
+	}
+	return MapCommit(commit)
+}
+
+// MapFileChange converts a Bitbucket API DiffStat entry to the domain FileChange type.
+// Returns nil if the input entry is nil.
+func MapFileChange(diffstat *client.DiffStat) *FileChange {
+	if diffstat == nil {
+		return nil
+	}
+
+	change := &FileChange{
+		Status:       diffstat.Status,
+		LinesAdded:   diffstat.LinesAdded,
+		LinesRemoved: diffstat.LinesRemoved,
+	}
+	if diffstat.New != nil {
+		change.Path = diffstat.New.Path
+	}
+	if diffstat.Old != nil {
+		if change.Path == "" {
+			change.Path = diffstat.Old.Path
+		} else if diffstat.Old.Path != change.Path {
+			change.OldPath = diffstat.Old.Path
+		}
+	}
+	return change
+}
+
 // MapPullRequestComment converts a Bitbucket API PullRequestComment to domain PullRequestComment type.
 // Returns nil if the input comment is nil.
 func MapPullRequestComment(comment *client.PullRequestComment) *PullRequestComment {
@@ -348,14 +594,142 @@ func MapPullRequestComment(comment *client.PullRequestComment) *PullRequestComme
 	}
 
 	return &PullRequestComment{
-		ID:        comment.ID,
-		CreatedOn: comment.CreatedOn,
-		UpdatedOn: comment.UpdatedOn,
-		Content:   comment.Content.Raw,
-		User:      MapUser(&comment.User),
-		Deleted:   comment.Deleted,
-		Pending:   comment.Pending,
-		Inline:    MapInline(comment.Inline),
+		ID:         comment.ID,
+		CreatedOn:  comment.CreatedOn,
+		UpdatedOn:  comment.UpdatedOn,
+		Content:    comment.Content.Raw,
+		User:       MapUser(&comment.User),
+		Deleted:    comment.Deleted,
+		Pending:    comment.Pending,
+		Inline:     MapInline(comment.Inline),
+		Parent:     MapCommentParent(comment.Parent),
+		Resolved:   comment.Resolution != nil,
+		Resolution: MapResolution(comment.Resolution),
+	}
+}
+
+// MapCommentParent extracts the parent comment ID from a comment parent reference.
+// Returns nil if the parent is nil.
+func MapCommentParent(parent *client.PullRequestCommentParent) *int {
+	if parent == nil {
+		return nil
+	}
+	return &parent.ID
+}
+
+// MapResolution converts a Bitbucket API PullRequestCommentResolution to domain Resolution type.
+// Returns nil if the input resolution is nil.
+func MapResolution(resolution *client.PullRequestCommentResolution) *Resolution {
+	if resolution == nil {
+		return nil
+	}
+
+	return &Resolution{
+		User:      MapUser(resolution.User),
+		CreatedOn: resolution.CreatedOn,
+	}
+}
+
+// MapPullRequestTask converts a Bitbucket API PullRequestTask to domain PullRequestTask type.
+// Returns nil if the input task is nil.
+func MapPullRequestTask(task *client.PullRequestTask) *PullRequestTask {
+	if task == nil {
+		return nil
+	}
+
+	var comment *int
+	if task.Comment != nil {
+		comment = &task.Comment.ID
+	}
+
+	return &PullRequestTask{
+		ID:         task.ID,
+		State:      task.State,
+		Content:    task.Content.Raw,
+		Creator:    MapUser(&task.Creator),
+		CreatedOn:  task.CreatedOn,
+		UpdatedOn:  task.UpdatedOn,
+		Pending:    task.Pending,
+		ResolvedOn: task.ResolvedOn,
+		ResolvedBy: MapUser(task.ResolvedBy),
+		Comment:    comment,
+	}
+}
+
+// MapPullRequestActivity converts a Bitbucket API PullRequestActivity entry to the domain
+// PullRequestActivity type, normalizing updates, approvals, change requests, and comments
+// into a single event with its type, date, and user.
+// Returns nil if the input activity is nil or of an unknown kind.
+func MapPullRequestActivity(activity *client.PullRequestActivity) *PullRequestActivity {
+	switch {
+	case activity == nil:
+		return nil
+	case activity.Update != nil:
+		return &PullRequestActivity{
+			Type:   ActivityUpdate,
+			Date:   activity.Update.Date,
+			User:   MapUser(&activity.Update.Author),
+			Update: MapPullRequestUpdate(activity.Update),
+		}
+	case activity.Approval != nil:
+		return &PullRequestActivity{
+			Type: ActivityApproval,
+			Date: activity.Approval.Date,
+			User: MapUser(&activity.Approval.User),
+		}
+	case activity.ChangesRequested != nil:
+		return &PullRequestActivity{
+			Type: ActivityChangesRequested,
+			Date: activity.ChangesRequested.Date,
+			User: MapUser(&activity.ChangesRequested.User),
+		}
+	case activity.Comment != nil:
+		return &PullRequestActivity{
+			Type:    ActivityComment,
+			Date:    activity.Comment.CreatedOn,
+			User:    MapUser(&activity.Comment.User),
+			Comment: MapPullRequestComment(activity.Comment),
+		}
+	default:
+		return nil
+	}
+}
+
+// MapPullRequestUpdate converts a Bitbucket API PullRequestActivityUpdate to the domain
+// PullRequestUpdate type. The changed fields are listed in alphabetical order.
+// Returns nil if the input update is nil.
+func MapPullRequestUpdate(update *client.PullRequestActivityUpdate) *PullRequestUpdate {
+	if update == nil {
+		return nil
+	}
+
+	var changes []string
+	for field := range update.Changes {
+		changes = append(changes, field)
+	}
+	slices.Sort(changes)
+
+	return &PullRequestUpdate{
+		State:       update.State,
+		Title:       update.Title,
+		Draft:       update.Draft,
+		Reason:      update.Reason,
+		Source:      MapPullRequestBranch(&update.Source),
+		Destination: MapPullRequestBranch(&update.Destination),
+		Changes:     changes,
+	}
+}
+
+// MapDefaultReviewer converts a Bitbucket API DefaultReviewer to the domain DefaultReviewer type.
+// Returns nil if the input default reviewer is nil.
+func MapDefaultReviewer(reviewer *client.DefaultReviewer) *DefaultReviewer {
+	if reviewer == nil {
+		return nil
+	}
+
+	return &DefaultReviewer{
+		User:         MapUser(&reviewer.User),
+		ReviewerType: reviewer.ReviewerType,
 	}
 }
 
@@ -367,8 +741,266 @@ func MapInline(inline *client.PullRequestCommentInline) *Inline {
 	}
 
 	return &Inline{
-		Path: inline.Path,
-		To:   inline.To,
-		From: inline.From,
+		Path:      inline.Path,
+		To:        inline.To,
+		From:      inline.From,
+		StartTo:   inline.StartTo,
+		StartFrom: inline.StartFrom,
+	}
+}
+
+// MapCommentThreads arranges flat Bitbucket API comments into threads.
+// Each top-level comment holds its replies (recursively) ordered by ID, which matches
+// the creation order. Replies whose parent is missing are promoted to top-level comments.
+func MapCommentThreads(comments []client.PullRequestComment) []PullRequestComment {
+	mapped := MapList(comments, MapPullRequestComment)
+	slices.SortFunc(mapped, func(a, b PullRequestComment) int { return a.ID - b.ID })
+
+	ids := make(map[int]bool, len(mapped))
+	children := make(map[int][]PullRequestComment)
+	for _, comment := range mapped {
+		ids[comment.ID] = true
+	}
+
+	roots := []PullRequestComment{}
+	for _, comment := range mapped {
+		if comment.Parent != nil && ids[*comment.Parent] {
+			children[*comment.Parent] = append(children[*comment.Parent], comment)
+		} else {
+			roots = append(roots, comment)
+		}
+	}
+
+	var attach func(comment *PullRequestComment)
+	attach = func(comment *PullRequestComment) {
+		comment.Replies = children[comment.ID]
+		for i := range comment.Replies {
+			attach(&comment.Replies[i])
+		}
+	}
+	for i := range roots {
+		attach(&roots[i])
+	}
+
+	return roots
+}
+
+// MapPipeline converts a Bitbucket API Pipeline to the domain Pipeline type.
+// Returns nil if the input pipeline is nil.
+func MapPipeline(pipeline *client.Pipeline) *Pipeline {
+	if pipeline == nil {
+		return nil
+	}
+
+	result := &Pipeline{
+		UUID:            pipeline.UUID,
+		BuildNumber:     pipeline.BuildNumber,
+		State:           pipeline.State.Name,
+		RefType:         pipeline.Target.RefType,
+		RefName:         pipeline.Target.RefName,
+		Trigger:         pipeline.Trigger.Name,
+		CreatedOn:       pipeline.CreatedOn,
+		CompletedOn:     pipeline.CompletedOn,
+		DurationSeconds: pipeline.BuildSecondsUsed,
+	}
+	if pipeline.State.Result != nil {
+		result.Result = pipeline.State.Result.Name
+	}
+	if pipeline.State.Stage != nil {
+		result.Stage = pipeline.State.Stage.Name
+	}
+	if pipeline.Target.Commit != nil {
+		result.Commit = pipeline.Target.Commit.Hash
+	}
+	if pipeline.Target.Selector != nil {
+		result.Selector = pipeline.Target.Selector.Pattern
+	}
+	if pipeline.Creator != nil {
+		result.Creator = MapUser(pipeline.Creator)
+	}
+	return result
+}
+
+// MapPipelineStep converts a Bitbucket API PipelineStep to the domain PipelineStep type.
+// Returns nil if the input step is nil.
+func MapPipelineStep(step *client.PipelineStep) *PipelineStep {
+	if step == nil {
+		return nil
+	}
+
+	commands := make([]string, len(step.ScriptCommands))
+	for i, command := range step.ScriptCommands {
+		commands[i] = command.Command
+	}
+
+	result := &PipelineStep{
+		UUID:            step.UUID,
+		Name:            step.Name,
+		State:           step.State.Name,
+		StartedOn:       step.StartedOn,
+		CompletedOn:     step.CompletedOn,
+		DurationSeconds: step.DurationInSeconds,
+		Commands:        commands,
+	}
+	if step.State.Result != nil {
+		result.Result = step.State.Result.Name
+	}
+	if step.Image != nil {
+		result.Image = step.Image.Name
+	}
+	return result
+}
+
+// MapReport converts a Bitbucket API Report to the domain Report type.
+// Returns nil if the input report is nil.
+func MapReport(report *client.Report) *Report {
+	if report == nil {
+		return nil
+	}
+
+	result := &Report{
+		ID:        report.ExternalID,
+		Title:     report.Title,
+		Details:   report.Details,
+		Type:      report.ReportType,
+		Reporter:  report.Reporter,
+		Link:      report.Link,
+		Result:    report.Result,
+		CreatedOn: report.CreatedOn,
+		UpdatedOn: report.UpdatedOn,
+	}
+	for _, data := range report.Data {
+		result.Data = append(result.Data, ReportData{Title: data.Title, Type: data.Type, Value: data.Value})
+	}
+	return result
+}
+
+// MapAnnotation converts a Bitbucket API Annotation to the domain Annotation type.
+// Returns nil if the input annotation is nil.
+func MapAnnotation(annotation *client.Annotation) *Annotation {
+	if annotation == nil {
+		return nil
+	}
+
+	return &Annotation{
+		ID:       annotation.ExternalID,
+		Type:     annotation.AnnotationType,
+		Path:     annotation.Path,
+		Line:     annotation.Line,
+		Summary:  annotation.Summary,
+		Details:  annotation.Details,
+		Severity: annotation.Severity,
+		Result:   annotation.Result,
+		Link:     annotation.Link,
+	}
+}
+
+// MapIssue converts a Bitbucket API Issue to the domain Issue type.
+// Returns nil if the input issue is nil.
+func MapIssue(issue *client.Issue) *Issue {
+	if issue == nil {
+		return nil
+	}
+
+	result := &Issue{
+		ID:        issue.ID,
+		Title:     issue.Title,
+		State:     issue.State,
+		Kind:      issue.Kind,
+		Priority:  issue.Priority,
+		Reporter:  MapUser(issue.Reporter),
+		Assignee:  MapUser(issue.Assignee),
+		Component: MapIssueCategory(issue.Component),
+		Milestone: MapIssueCategory(issue.Milestone),
+		Version:   MapIssueCategory(issue.Version),
+		Votes:     issue.Votes,
+		CreatedOn: issue.CreatedOn,
+		UpdatedOn: issue.UpdatedOn,
+		Link:      issue.Links.HTML.Href,
+	}
+	if issue.Content != nil {
+		result.Content = issue.Content.Raw
+	}
+	return result
+}
+
+// MapIssueCategory extracts the name of an issue component, milestone, or version.
+// Returns nil if the category is nil.
+func MapIssueCategory(category *client.IssueCategory) *string {
+	if category == nil {
+		return nil
+	}
+	return &category.Name
+}
+
+// MapIssueComment converts a Bitbucket API IssueComment to the domain IssueComment type.
+// Returns nil if the input comment is nil.
+func MapIssueComment(comment *client.IssueComment) *IssueComment {
+	if comment == nil {
+		return nil
+	}
+
+	result := &IssueComment{
+		ID:        comment.ID,
+		User:      MapUser(comment.User),
+		CreatedOn: comment.CreatedOn,
+		UpdatedOn: comment.UpdatedOn,
+	}
+	if comment.Content != nil {
+		result.Content = comment.Content.Raw
+	}
+	return result
+}
+
+// MapCodeSearchResult converts a Bitbucket API CodeSearchResult to the domain CodeSearchResult type.
+// Returns nil if the input result is nil.
+func MapCodeSearchResult(result *client.CodeSearchResult) *CodeSearchResult {
+	if result == nil {
+		return nil
+	}
+
+	mapped := &CodeSearchResult{
+		Repository: result.File.Commit.Repository.FullName,
+		Path:       result.File.Path,
+		Commit:     result.File.Commit.Hash,
+		MatchCount: result.ContentMatchCount,
+		Matches:    MapList(result.ContentMatches, MapCodeSearchMatch),
+	}
+	for _, segment := range result.PathMatches {
+		if segment.Match {
+			mapped.PathMatch = true
+			break
+		}
+	}
+	return mapped
+}
+
+// MapCodeSearchMatch converts a Bitbucket API CodeSearchContentMatch to the domain CodeSearchMatch type.
+// Returns nil if the input match is nil.
+func MapCodeSearchMatch(match *client.CodeSearchContentMatch) *CodeSearchMatch {
+	if match == nil {
+		return nil
+	}
+	return &CodeSearchMatch{Lines: MapList(match.Lines, MapCodeSearchLine)}
+}
+
+// MapCodeSearchLine converts a Bitbucket API CodeSearchLine to the domain CodeSearchLine type,
+// joining its segments into the line text and collecting the matching segments as highlights.
+// Returns nil if the input line is nil.
+func MapCodeSearchLine(line *client.CodeSearchLine) *CodeSearchLine {
+	if line == nil {
+		return nil
+	}
+
+	var text strings.Builder
+	mapped := &CodeSearchLine{Line: line.Line}
+	for _, segment := range line.Segments {
+		text.WriteString(segment.Text)
+		if segment.Match {
+			mapped.Match = true
+			mapped.Highlights = append(mapped.Highlights, segment.Text)
+		}
 	}
+	mapped.Text = text.String()
+	return mapped
 }
@@ -0,0 +1,303 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/branow/mcp-bitbucket/internal/util"
+)
+
+// Code Insights report types, results, annotation types, and severities.
+const (
+	ReportTypeSecurity = "SECURITY"
+	ReportTypeCoverage = "COVERAGE"
+	ReportTypeTest     = "TEST"
+	ReportTypeBug      = "BUG"
+
+	ReportResultPassed  = "PASSED"
+	ReportResultFailed  = "FAILED"
+	ReportResultPending = "PENDING"
+
+	AnnotationTypeVulnerability = "VULNERABILITY"
+	AnnotationTypeCodeSmell     = "CODE_SMELL"
+	AnnotationTypeBug           = "BUG"
+
+	SeverityCritical = "CRITICAL"
+	SeverityHigh     = "HIGH"
+	SeverityMedium   = "MEDIUM"
+	SeverityLow      = "LOW"
+)
+
+// Limits of Code Insights: the number of annotations per report and the length of texts, in characters.
+const (
+	maxReportAnnotations = 1000
+	maxReportDetails     = 2000
+	maxAnnotationSummary = 450
+	maxAnnotationDetails = 2000
+)
+
+// sarifLog is the subset of a SARIF 2.1.0 log needed to build Code Insights reports.
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+type sarifLog struct {
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool struct {
+		Driver struct {
+			Name           string      `json:"name"`
+			InformationURI string      `json:"informationUri"`
+			Rules          []sarifRule `json:"rules"`
+		} `json:"driver"`
+	} `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifRule struct {
+	ID                   string        `json:"id"`
+	ShortDescription     *sarifMessage `json:"shortDescription"`
+	FullDescription      *sarifMessage `json:"fullDescription"`
+	HelpURI              string        `json:"helpUri"`
+	DefaultConfiguration *struct {
+		Level string `json:"level"`
+	} `json:"defaultConfiguration"`
+	Properties sarifProperties `json:"properties"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           *int              `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+	Properties          sarifProperties   `json:"properties"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *struct {
+		ArtifactLocation struct {
+			URI string `json:"uri"`
+		} `json:"artifactLocation"`
+		Region *struct {
+			StartLine int `json:"startLine"`
+		} `json:"region"`
+	} `json:"physicalLocation"`
+}
+
+type sarifProperties struct {
+	Tags             []string `json:"tags"`
+	SecuritySeverity string   `json:"security-severity"`
+}
+
+// nonSlugRegex matches the characters replaced when deriving a report ID from a tool name.
+var nonSlugRegex = regexp.MustCompile(`[^a-z0-9]+`)
+
+// ParseSARIF converts a SARIF 2.1.0 log into Code Insights reports, one per run.
+// The report ID is derived from the tool name, or "sarif" if the name has no letters or digits.
+// Every result becomes an annotation of the report of its run: results of level error
+// fail the report, results tagged "security" are vulnerabilities, and the severity
+// follows the security-severity score of the rule if present, or the level otherwise.
+// Reports are limited to the first 1000 results of a run.
+//
+// Parameters:
+//   - data: The SARIF log as JSON
+//
+// Returns the reports with their annotations, or an InvalidParamsError if the log is malformed.
+func ParseSARIF(data []byte) ([]Report, error) {
+	var log sarifLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		return nil, util.NewInvalidParamsError("sarif: " + err.Error())
+	}
+	if len(log.Runs) == 0 {
+		return nil, util.NewInvalidParamsError("sarif: the log has no runs")
+	}
+
+	reports := make([]Report, 0, len(log.Runs))
+	ids := make(map[string]int, len(log.Runs))
+	for _, run := range log.Runs {
+		driver := run.Tool.Driver
+		if strings.TrimSpace(driver.Name) == "" {
+			return nil, util.NewInvalidParamsError("sarif: run without tool.driver.name")
+		}
+
+		id := strings.Trim(nonSlugRegex.ReplaceAllString(strings.ToLower(driver.Name), "-"), "-")
+		if id == "" {
+			id = "sarif"
+		}
+		if ids[id]++; ids[id] > 1 {
+			id = fmt.Sprintf("%s-%d", id, ids[id])
+		}
+
+		report := Report{
+			ID:       id,
+			Title:    driver.Name,
+			Type:     ReportTypeBug,
+			Reporter: driver.Name,
+			Link:     driver.InformationURI,
+			Result:   ReportResultPassed,
+		}
+
+		counts := map[string]int{}
+		for i, result := range run.Results {
+			rule := findSarifRule(driver.Rules, result)
+			level := sarifLevel(result, rule)
+			counts[level]++
+			if level == "error" {
+				report.Result = ReportResultFailed
+			}
+			if len(report.Annotations) < maxReportAnnotations {
+				annotation := sarifAnnotation(result, rule, level, i)
+				if annotation.Type == AnnotationTypeVulnerability {
+					report.Type = ReportTypeSecurity
+				}
+				report.Annotations = append(report.Annotations, annotation)
+			}
+		}
+
+		report.Details = fmt.Sprintf("%d findings: %d errors, %d warnings, %d notes.",
+			len(run.Results), counts["error"], counts["warning"], counts["note"]+counts["none"])
+		if len(run.Results) > maxReportAnnotations {
+			report.Details += fmt.Sprintf(" Only the first %d findings are annotated.", maxReportAnnotations)
+		}
+		report.Data = []ReportData{
+			{Title: "Errors", Type: "NUMBER", Value: counts["error"]},
+			{Title: "Warnings", Type: "NUMBER", Value: counts["warning"]},
+			{Title: "Notes", Type: "NUMBER", Value: counts["note"] + counts["none"]},
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// findSarifRule finds the rule a result refers to by index or ID.
+//
+// Returns nil if the run does not describe the rule.
+func findSarifRule(rules []sarifRule, result sarifResult) *sarifRule {
+	if result.RuleIndex != nil && *result.RuleIndex >= 0 && *result.RuleIndex < len(rules) {
+		return &rules[*result.RuleIndex]
+	}
+	for i := range rules {
+		if rules[i].ID == result.RuleID {
+			return &rules[i]
+		}
+	}
+	return nil
+}
+
+// sarifLevel determines the level of a result, falling back to the default level of its rule
+// and to "warning", the default of SARIF.
+func sarifLevel(result sarifResult, rule *sarifRule) string {
+	if result.Level != "" {
+		return result.Level
+	}
+	if rule != nil && rule.DefaultConfiguration != nil && rule.DefaultConfiguration.Level != "" {
+		return rule.DefaultConfiguration.Level
+	}
+	return "warning"
+}
+
+// sarifAnnotation converts a SARIF result into an annotation.
+// The annotation ID is the first partial fingerprint of the result if present,
+// so that the annotation keeps its ID when the report is published again,
+// or the ID of its rule, "result" if the rule is unknown, followed by the result number.
+func sarifAnnotation(result sarifResult, rule *sarifRule, level string, index int) Annotation {
+	ruleID := result.RuleID
+	if ruleID == "" && rule != nil {
+		ruleID = rule.ID
+	}
+	if ruleID == "" {
+		ruleID = "result"
+	}
+	annotation := Annotation{
+		ID:      fmt.Sprintf("%s-%d", ruleID, index+1),
+		Type:    AnnotationTypeCodeSmell,
+		Summary: truncateText(result.Message.Text, maxAnnotationSummary),
+	}
+	if len(result.PartialFingerprints) > 0 {
+		keys := make([]string, 0, len(result.PartialFingerprints))
+		for key := range result.PartialFingerprints {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		annotation.ID = result.PartialFingerprints[keys[0]]
+	}
+
+	if len(result.Locations) > 0 && result.Locations[0].PhysicalLocation != nil {
+		location := result.Locations[0].PhysicalLocation
+		annotation.Path = sarifPath(location.ArtifactLocation.URI)
+		if location.Region != nil {
+			annotation.Line = location.Region.StartLine
+		}
+	}
+
+	tags := result.Properties.Tags
+	securitySeverity := result.Properties.SecuritySeverity
+	if rule != nil {
+		tags = append(tags, rule.Properties.Tags...)
+		if securitySeverity == "" {
+			securitySeverity = rule.Properties.SecuritySeverity
+		}
+		if rule.FullDescription != nil {
+			annotation.Details = truncateText(rule.FullDescription.Text, maxAnnotationDetails)
+		} else if rule.ShortDescription != nil {
+			annotation.Details = truncateText(rule.ShortDescription.Text, maxAnnotationDetails)
+		}
+		annotation.Link = rule.HelpURI
+	}
+
+	switch {
+	case slices.Contains(tags, "security"):
+		annotation.Type = AnnotationTypeVulnerability
+	case level == "error":
+		annotation.Type = AnnotationTypeBug
+	}
+
+	annotation.Severity = SeverityLow
+	if score, err := strconv.ParseFloat(securitySeverity, 64); err == nil {
+		switch {
+		case score >= 9:
+			annotation.Severity = SeverityCritical
+		case score >= 7:
+			annotation.Severity = SeverityHigh
+		case score >= 4:
+			annotation.Severity = SeverityMedium
+		}
+	} else {
+		switch level {
+		case "error":
+			annotation.Severity = SeverityHigh
+		case "warning":
+			annotation.Severity = SeverityMedium
+		}
+	}
+	return annotation
+}
+
+// sarifPath converts the URI of a SARIF artifact location into a path relative to the repository root.
+func sarifPath(uri string) string {
+	uri = strings.TrimPrefix(uri, "file://")
+	if path, err := url.PathUnescape(uri); err == nil {
+		uri = path
+	}
+	return strings.TrimPrefix(strings.TrimPrefix(uri, "./"), "/")
+}
+
+// truncateText cuts a text exceeding maxLength characters, ending it with an ellipsis.
+func truncateText(text string, maxLength int) string {
+	runes := []rune(text)
+	if len(runes) <= maxLength {
+		return text
+	}
+	return string(runes[:maxLength-1]) + "…"
+}
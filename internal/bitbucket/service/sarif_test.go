@@ -0,0 +1,139 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/branow/mcp-bitbucket/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSARIF(t *testing.T) {
+	counts := func(errors, warnings, notes int) []service.ReportData {
+		return []service.ReportData{
+			{Title: "Errors", Type: "NUMBER", Value: errors},
+			{Title: "Warnings", Type: "NUMBER", Value: warnings},
+			{Title: "Notes", Type: "NUMBER", Value: notes},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		sarif    string
+		expected []service.Report
+	}{
+		{
+			"run without results",
+			`{"version": "2.1.0", "runs": [{"tool": {"driver": {"name": "Go Vet"}}}]}`,
+			[]service.Report{{
+				ID: "go-vet", Title: "Go Vet", Type: service.ReportTypeBug, Reporter: "Go Vet", Result: service.ReportResultPassed,
+				Details: "0 findings: 0 errors, 0 warnings, 0 notes.", Data: counts(0, 0, 0),
+			}},
+		},
+		{
+			"rule of result by index",
+			`{"runs": [{
+				"tool": {"driver": {"name": "lint", "informationUri": "https://lint.example.com", "rules": [
+					{"id": "L001", "shortDescription": {"text": "Unused variable"}, "helpUri": "https://lint.example.com/L001", "defaultConfiguration": {"level": "note"}}
+				]}},
+				"results": [{"ruleIndex": 0, "message": {"text": "x is unused"},
+					"locations": [{"physicalLocation": {"artifactLocation": {"uri": "file:///src/main.go"}, "region": {"startLine": 7}}}]}]
+			}]}`,
+			[]service.Report{{
+				ID: "lint", Title: "lint", Type: service.ReportTypeBug, Reporter: "lint", Link: "https://lint.example.com", Result: service.ReportResultPassed,
+				Details: "1 findings: 0 errors, 0 warnings, 1 notes.", Data: counts(0, 0, 1),
+				Annotations: []service.Annotation{{
+					ID: "L001-1", Type: service.AnnotationTypeCodeSmell, Path: "src/main.go", Line: 7, Summary: "x is unused",
+					Details: "Unused variable", Severity: service.SeverityLow, Link: "https://lint.example.com/L001",
+				}},
+			}},
+		},
+		{
+			"error level fails the report",
+			`{"runs": [{"tool": {"driver": {"name": "lint"}}, "results": [
+				{"ruleId": "L002", "level": "error", "message": {"text": "nil dereference"}, "locations": [{"physicalLocation": {"artifactLocation": {"uri": "./pkg/a%20b.go"}}}]},
+				{"ruleId": "L003", "message": {"text": "long line"}}
+			]}]}`,
+			[]service.Report{{
+				ID: "lint", Title: "lint", Type: service.ReportTypeBug, Reporter: "lint", Result: service.ReportResultFailed,
+				Details: "2 findings: 1 errors, 1 warnings, 0 notes.", Data: counts(1, 1, 0),
+				Annotations: []service.Annotation{
+					{ID: "L002-1", Type: service.AnnotationTypeBug, Path: "pkg/a b.go", Summary: "nil dereference", Severity: service.SeverityHigh},
+					{ID: "L003-2", Type: service.AnnotationTypeCodeSmell, Summary: "long line", Severity: service.SeverityMedium},
+				},
+			}},
+		},
+		{
+			"security severity of rule",
+			`{"runs": [{"tool": {"driver": {"name": "scanner", "rules": [
+				{"id": "S1", "fullDescription": {"text": "SQL injection"}, "properties": {"tags": ["security"], "security-severity": "9.8"}}
+			]}}, "results": [{"ruleId": "S1", "level": "warning", "message": {"text": "query built from input"}, "partialFingerprints": {"primaryLocationLineHash": "abc123", "a": "first"}}]}]}`,
+			[]service.Report{{
+				ID: "scanner", Title: "scanner", Type: service.ReportTypeSecurity, Reporter: "scanner", Result: service.ReportResultPassed,
+				Details: "1 findings: 0 errors, 1 warnings, 0 notes.", Data: counts(0, 1, 0),
+				Annotations: []service.Annotation{
+					{ID: "first", Type: service.AnnotationTypeVulnerability, Summary: "query built from input", Details: "SQL injection", Severity: service.SeverityCritical},
+				},
+			}},
+		},
+		{
+			"result without rule ID",
+			`{"runs": [{"tool": {"driver": {"name": "lint"}}, "results": [{"level": "note", "message": {"text": "consider renaming"}}]}]}`,
+			[]service.Report{{
+				ID: "lint", Title: "lint", Type: service.ReportTypeBug, Reporter: "lint", Result: service.ReportResultPassed,
+				Details: "1 findings: 0 errors, 0 warnings, 1 notes.", Data: counts(0, 0, 1),
+				Annotations: []service.Annotation{
+					{ID: "result-1", Type: service.AnnotationTypeCodeSmell, Summary: "consider renaming", Severity: service.SeverityLow},
+				},
+			}},
+		},
+		{
+			"tool names without letters or digits and repeated tools",
+			`{"runs": [{"tool": {"driver": {"name": "静态分析"}}}, {"tool": {"driver": {"name": "Lint!"}}}, {"tool": {"driver": {"name": "lint"}}}]}`,
+			[]service.Report{
+				{
+					ID: "sarif", Title: "静态分析", Type: service.ReportTypeBug, Reporter: "静态分析", Result: service.ReportResultPassed,
+					Details: "0 findings: 0 errors, 0 warnings, 0 notes.", Data: counts(0, 0, 0),
+				},
+				{
+					ID: "lint", Title: "Lint!", Type: service.ReportTypeBug, Reporter: "Lint!", Result: service.ReportResultPassed,
+					Details: "0 findings: 0 errors, 0 warnings, 0 notes.", Data: counts(0, 0, 0),
+				},
+				{
+					ID: "lint-2", Title: "lint", Type: service.ReportTypeBug, Reporter: "lint", Result: service.ReportResultPassed,
+					Details: "0 findings: 0 errors, 0 warnings, 0 notes.", Data: counts(0, 0, 0),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reports, err := service.ParseSARIF([]byte(tt.sarif))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, reports)
+		})
+	}
+}
+
+func TestParseSARIF_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		sarif    string
+		expected string
+	}{
+		{"malformed JSON", `{"runs": [`, "sarif: unexpected end of JSON input"},
+		{"no runs", `{"version": "2.1.0", "runs": []}`, "sarif: the log has no runs"},
+		{"run without tool name", `{"runs": [{"tool": {"driver": {"name": " "}}}]}`, "sarif: run without tool.driver.name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.ParseSARIF([]byte(tt.sarif))
+			require.Error(t, err)
+			assert.True(t, util.IsInvalidParamsError(err))
+			assert.Equal(t, tt.expected, err.Error())
+		})
+	}
+}
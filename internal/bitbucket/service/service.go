@@ -6,35 +6,82 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
 
 	"github.com/branow/mcp-bitbucket/internal/bitbucket/client"
+	"github.com/branow/mcp-bitbucket/internal/util"
+	"github.com/branow/mcp-bitbucket/internal/util/bbql"
 	"golang.org/x/sync/errgroup"
 )
 
 // Service provides high-level operations for interacting with Bitbucket.
 // It wraps the Bitbucket API client and handles mapping between API types and domain types.
 type Service struct {
-	client *client.Client
+	client     *client.Client
+	identities *identityCache
 }
 
 // NewService creates a new Bitbucket service with the given client.
 func NewService(client *client.Client) *Service {
-	return &Service{client: client}
+	return &Service{client: client, identities: newIdentityCache()}
+}
+
+// Repository roles of the authenticated user accepted by ListRepositoriesOptions.
+const (
+	RepositoryRoleMember      = "member"      // Repositories the user can read
+	RepositoryRoleContributor = "contributor" // Repositories the user can write to
+	RepositoryRoleAdmin       = "admin"       // Repositories the user administers
+	RepositoryRoleOwner       = "owner"       // Repositories owned by the user
+)
+
+// ListRepositoriesOptions configures filtering, sorting, and paging of the repository listing.
+type ListRepositoriesOptions struct {
+	Name         string     // Part of the repository name to match (case-insensitive)
+	Projects     []string   // Keys of the projects whose repositories to include
+	Languages    []string   // Languages to include (e.g., "go")
+	Private      *bool      // Only private (true) or public (false) repositories; nil includes both
+	UpdatedSince *time.Time // Only repositories updated after this time
+	Role         string     // Only repositories the user has this role in; one of the RepositoryRole values
+	Query        string     // Additional raw BBQL expression combined with the other filters
+	Sort         string     // Field to sort by, prefixed with "-" for descending order
+	Page         int        // The page number (1-based)
+	Size         int        // The number of items per page
 }
 
 // ListRepositories retrieves a paginated list of repositories from the specified namespace.
+// The filters are translated into a BBQL query combined with the raw query from the options.
 // It returns the repositories mapped to the domain Repository type.
 //
 // Parameters:
 //   - ctx: Context for the request
 //   - namespace: The workspace slug or username
-//   - page: The page number (1-based)
-//   - size: The number of items per page
+//   - options: Filtering, sorting, and paging configuration
 //
 // Returns a Page containing Repository items, or an error if the request fails.
-func (s *Service) ListRepositories(ctx context.Context, namespace string, page, size int) (*Page[Repository], error) {
-	resp, err := s.client.ListRepositories(ctx, namespace, page, size)
+func (s *Service) ListRepositories(ctx context.Context, namespace string, options ListRepositoriesOptions) (*Page[Repository], error) {
+	query := bbql.New().WhereRaw(options.Query).Sort(options.Sort)
+	if options.Name != "" {
+		query.Where(bbql.Contains("name", options.Name))
+	}
+	query.Where(bbql.In("project.key", options.Projects...))
+	query.Where(bbql.In("language", options.Languages...))
+	if options.Private != nil {
+		query.Where(bbql.Eq("is_private", *options.Private))
+	}
+	if options.UpdatedSince != nil {
+		query.Where(bbql.Gt("updated_on", *options.UpdatedSince))
+	}
+
+	resp, err := s.client.ListRepositories(ctx, namespace, options.Size, options.Page, options.Role, query)
 	if err != nil {
 		return nil, err
 	}
@@ -111,13 +158,20 @@ func findReadmeInSource(items []client.SourceItem) *client.SourceItem {
 
 // GetPullRequestOptions configures what additional data to fetch with the pull request.
 type GetPullRequestOptions struct {
-	IncludeCommits  bool // Include the pull request commits
-	IncludeDiff     bool // Include the pull request diff
-	IncludeComments bool // Include the pull request comments
+	IncludeCommits  bool   // Include the pull request commits
+	IncludeDiff     bool   // Include the pull request diff
+	IncludeComments bool   // Include the pull request comment threads
+	UnresolvedOnly  bool   // Keep only unresolved comment threads
+	CommentsPath    string // Keep only comment threads anchored to this file path
+	IncludeTasks    bool   // Include the pull request tasks
 }
 
 // GetPullRequest retrieves detailed information about a specific pull request.
-// It can optionally fetch commits, diff, and comments in parallel.
+// It can optionally fetch commits, diff, comments, and tasks in parallel.
+// Comments are arranged into threads of top-level comments with nested replies
+// and can be filtered to unresolved threads or threads anchored to a specific file.
+// The build status is summarized from the first 100 build statuses and left empty if they
+// are not accessible (403 or 404), e.g. when the source repository of a fork is private.
 //
 // Parameters:
 //   - ctx: Context for the request
@@ -133,7 +187,9 @@ func (s *Service) GetPullRequest(ctx context.Context, namespace string, repoSlug
 	var pr *client.PullRequest
 	var commits *client.ApiResponse[client.Commit]
 	var diff *string
-	var comments *client.ApiResponse[client.PullRequestComment]
+	var comments []client.PullRequestComment
+	var tasks []client.PullRequestTask
+	var statuses []client.CommitStatus
 
 	g.Go(func() error {
 		var err error
@@ -141,6 +197,15 @@ func (s *Service) GetPullRequest(ctx context.Context, namespace string, repoSlug
 		return err
 	})
 
+	g.Go(func() error {
+		res, err := s.client.ListVisiblePullRequestStatuses(ctx, namespace, repoSlug, pullRequestId, 100, 1)
+		if err != nil {
+			return err
+		}
+		statuses = res.Values
+		return nil
+	})
+
 	if options.IncludeCommits {
 		g.Go(func() error {
 			var err error
@@ -160,7 +225,497 @@ func (s *Service) GetPullRequest(ctx context.Context, namespace string, repoSlug
 	if options.IncludeComments {
 		g.Go(func() error {
 			var err error
-			comments, err = s.client.ListPullRequestComments(ctx, namespace, repoSlug, pullRequestId, 50, 1)
+			comments, err = fetchAll(func(page int) (*client.ApiResponse[client.PullRequestComment], error) {
+				return s.client.ListPullRequestComments(ctx, namespace, repoSlug, pullRequestId, 100, page)
+			})
+			return err
+		})
+	}
+
+	if options.IncludeTasks {
+		g.Go(func() error {
+			var err error
+			tasks, err = fetchAll(func(page int) (*client.ApiResponse[client.PullRequestTask], error) {
+				return s.client.ListPullRequestTasks(ctx, namespace, repoSlug, pullRequestId, 100, page)
+			})
+			return err
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	var threads *Page[PullRequestComment]
+	if options.IncludeComments {
+		threads = filterCommentThreads(MapCommentThreads(comments), options)
+	}
+
+	var taskPage *Page[PullRequestTask]
+	if options.IncludeTasks {
+		taskPage = fullPage(MapList(tasks, MapPullRequestTask))
+	}
+
+	details := MapPullRequestDetails(pr, commits, diff, threads, taskPage)
+	details.PullRequest.BuildStatus = MapBuildStatus(statuses)
+	return details, nil
+}
+
+// sourceRepository determines the repository holding the source commit of a pull request.
+// Build statuses and reports of a pull request from a fork are reported in the fork repository.
+//
+// Returns the workspace and slug of the source repository.
+func sourceRepository(namespace string, repoSlug string, pr *client.PullRequest) (string, string) {
+	source := pr.Source.Repository
+	if source.UUID != "" && source.UUID != pr.Destination.Repository.UUID {
+		if owner, slug, ok := strings.Cut(source.FullName, "/"); ok {
+			return owner, slug
+		}
+	}
+	return namespace, repoSlug
+}
+
+// maxDiffLength limits the length of a diff returned to clients, in bytes,
+// so that large changes do not exceed what a model can read at once.
+const maxDiffLength = 200_000
+
+// truncateDiff cuts a diff exceeding maxDiffLength at the last complete line within the limit,
+// or at the last complete character if the diff has no line break within the limit.
+//
+// Returns the possibly truncated diff and whether it was truncated.
+func truncateDiff(diff *string) (*string, bool) {
+	if diff == nil || len(*diff) <= maxDiffLength {
+		return diff, false
+	}
+	end := maxDiffLength
+	for end > 0 && !utf8.RuneStart((*diff)[end]) {
+		end--
+	}
+	truncated := (*diff)[:end]
+	if i := strings.LastIndexByte(truncated, '\n'); i >= 0 {
+		truncated = truncated[:i+1]
+	}
+	return &truncated, true
+}
+
+// ListCommitsOptions configures filtering and paging of the commit listing.
+type ListCommitsOptions struct {
+	Include string // Branch, tag, or commit hash whose history to list; defaults to all branches
+	Exclude string // Branch, tag, or commit hash whose history to leave out
+	Path    string // File or directory path whose changes to list
+	Page    int    // The page number (1-based)
+	Size    int    // The number of items per page
+}
+
+// ListCommits retrieves a paginated list of commits of a repository, newest first.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - options: Filtering and paging configuration
+//
+// Returns a Page containing Commit items, or an error if the request fails.
+func (s *Service) ListCommits(ctx context.Context, namespace string, repoSlug string, options ListCommitsOptions) (*Page[Commit], error) {
+	resp, err := s.client.ListCommits(ctx, namespace, repoSlug, options.Size, options.Page, options.Include, options.Exclude, options.Path)
+	if err != nil {
+		return nil, err
+	}
+	return MapPage(resp, MapCommit), nil
+}
+
+// GetCommitOptions configures what additional data to fetch with the commit.
+type GetCommitOptions struct {
+	IncludeDiff     bool // Include the diff against the first parent
+	IncludeDiffStat bool // Include the changed files with the number of added and removed lines
+}
+
+// GetCommit retrieves a commit of a repository.
+// It can optionally fetch the diff and the changed files in parallel;
+// a diff exceeding the size limit is truncated.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - hash: The commit hash, full or abbreviated
+//   - options: Configuration for additional data to fetch
+//
+// Returns the CommitDetails, or an error if the request fails.
+func (s *Service) GetCommit(ctx context.Context, namespace string, repoSlug string, hash string, options GetCommitOptions) (*CommitDetails, error) {
+	g, ctx := errgroup.WithContext(ctx)
+
+	var commit *client.Commit
+	var diff *string
+	var diffstat []client.DiffStat
+
+	g.Go(func() error {
+		var err error
+		commit, err = s.client.GetCommit(ctx, namespace, repoSlug, hash)
+		return err
+	})
+
+	if options.IncludeDiff {
+		g.Go(func() error {
+			var err error
+			diff, err = s.client.GetDiff(ctx, namespace, repoSlug, hash, "")
+			return err
+		})
+	}
+
+	if options.IncludeDiffStat {
+		g.Go(func() error {
+			var err error
+			diffstat, err = fetchAll(func(page int) (*client.ApiResponse[client.DiffStat], error) {
+				return s.client.GetDiffStat(ctx, namespace, repoSlug, hash, "", 500, page)
+			})
+			return err
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	details := &CommitDetails{Commit: MapCommit(commit)}
+	details.Diff, details.DiffTruncated = truncateDiff(diff)
+	if options.IncludeDiffStat {
+		details.DiffStat = fullPage(MapList(diffstat, MapFileChange))
+	}
+	return details, nil
+}
+
+// CompareOptions configures what to include in a comparison of two revisions.
+type CompareOptions struct {
+	Path            string // File or directory path to limit the comparison to; empty includes all files
+	IncludeDiffStat bool   // Include the changed files with the number of added and removed lines
+}
+
+// Compare retrieves the changes between two revisions of a repository,
+// i.e. what the "to" revision adds since it diverged from the "from" revision.
+// The diff and the optional changed files are fetched in parallel;
+// a diff exceeding the size limit is truncated.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - from: The base branch, tag, or commit hash
+//   - to: The branch, tag, or commit hash to compare with the base
+//   - options: Configuration for the path filter and additional data to fetch
+//
+// Returns the Comparison, or an error if the request fails.
+func (s *Service) Compare(ctx context.Context, namespace string, repoSlug string, from string, to string, options CompareOptions) (*Comparison, error) {
+	g, ctx := errgroup.WithContext(ctx)
+
+	// Bitbucket diffs "a..b" as the changes of a that are not in b.
+	spec := to + ".." + from
+
+	var diff *string
+	var diffstat []client.DiffStat
+
+	g.Go(func() error {
+		var err error
+		diff, err = s.client.GetDiff(ctx, namespace, repoSlug, spec, options.Path)
+		return err
+	})
+
+	if options.IncludeDiffStat {
+		g.Go(func() error {
+			var err error
+			diffstat, err = fetchAll(func(page int) (*client.ApiResponse[client.DiffStat], error) {
+				return s.client.GetDiffStat(ctx, namespace, repoSlug, spec, options.Path, 500, page)
+			})
+			return err
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	comparison := &Comparison{From: from, To: to, Path: options.Path}
+	comparison.Diff, comparison.DiffTruncated = truncateDiff(diff)
+	if options.IncludeDiffStat {
+		comparison.DiffStat = fullPage(MapList(diffstat, MapFileChange))
+	}
+	return comparison, nil
+}
+
+// Ref types accepted by ListRefsOptions.
+const (
+	RefTypeBranch = "branch" // Branches only
+	RefTypeTag    = "tag"    // Tags only
+	RefTypeAll    = "all"    // Both branches and tags
+)
+
+// ListRefsOptions configures filtering, sorting, and paging of the branch and tag listing.
+type ListRefsOptions struct {
+	Type  string // One of RefTypeBranch, RefTypeTag, or RefTypeAll; empty lists both
+	Query string // Raw BBQL expression, e.g. name ~ "release"
+	Sort  string // Field to sort by, prefixed with "-" for descending order (e.g. -target.date)
+	Page  int    // The page number (1-based)
+	Size  int    // The number of items per page
+}
+
+// ListRefs retrieves a paginated list of branches, tags, or both of a repository.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - options: Filtering, sorting, and paging configuration
+//
+// Returns a Page containing Ref items, or an error if the request fails.
+func (s *Service) ListRefs(ctx context.Context, namespace string, repoSlug string, options ListRefsOptions) (*Page[Ref], error) {
+	query := bbql.New().WhereRaw(options.Query).Sort(options.Sort)
+
+	switch options.Type {
+	case RefTypeBranch:
+		resp, err := s.client.ListBranches(ctx, namespace, repoSlug, options.Size, options.Page, query)
+		if err != nil {
+			return nil, err
+		}
+		return MapPage(resp, MapBranch), nil
+	case RefTypeTag:
+		resp, err := s.client.ListTags(ctx, namespace, repoSlug, options.Size, options.Page, query)
+		if err != nil {
+			return nil, err
+		}
+		return MapPage(resp, MapTag), nil
+	default:
+		resp, err := s.client.ListRefs(ctx, namespace, repoSlug, options.Size, options.Page, query)
+		if err != nil {
+			return nil, err
+		}
+		return MapPage(resp, MapTag), nil
+	}
+}
+
+// CreateBranch creates a branch pointing to the target commit.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - name: The branch name without the refs/heads prefix
+//   - target: The commit hash the branch points to
+//
+// Returns the created Ref, or an error if the request fails.
+func (s *Service) CreateBranch(ctx context.Context, namespace string, repoSlug string, name string, target string) (*Ref, error) {
+	branch, err := s.client.CreateBranch(ctx, namespace, repoSlug, &client.CreateBranchRequest{
+		Name:   name,
+		Target: client.CreateBranchTarget{Hash: target},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return MapBranch(branch), nil
+}
+
+// DeleteBranch deletes a branch of a repository.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - name: The branch name
+//
+// Returns an error if the deletion fails.
+func (s *Service) DeleteBranch(ctx context.Context, namespace string, repoSlug string, name string) error {
+	return s.client.DeleteBranch(ctx, namespace, repoSlug, name)
+}
+
+// CreateTag creates a tag pointing to the target commit.
+// A non-empty message creates an annotated tag.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - name: The tag name without the refs/tags prefix
+//   - target: The commit hash the tag points to
+//   - message: The optional tag message
+//
+// Returns the created Ref, or an error if the request fails.
+func (s *Service) CreateTag(ctx context.Context, namespace string, repoSlug string, name string, target string, message string) (*Ref, error) {
+	tag, err := s.client.CreateTag(ctx, namespace, repoSlug, &client.CreateTagRequest{
+		Name:    name,
+		Target:  client.CreateBranchTarget{Hash: target},
+		Message: message,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return MapTag(tag), nil
+}
+
+// DeleteTag deletes a tag of a repository.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - name: The tag name
+//
+// Returns an error if the deletion fails.
+func (s *Service) DeleteTag(ctx context.Context, namespace string, repoSlug string, name string) error {
+	return s.client.DeleteTag(ctx, namespace, repoSlug, name)
+}
+
+// maxPages limits the number of pages fetched when collecting all items of a listing.
+const maxPages = 20
+
+// fetchAll fetches a listing page by page until the last page or maxPages is reached,
+// so that items such as comment threads can be assembled completely.
+func fetchAll[T any](fetch func(page int) (*client.ApiResponse[T], error)) ([]T, error) {
+	items := []T{}
+	for page := 1; page <= maxPages; page++ {
+		resp, err := fetch(page)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, resp.Values...)
+		if resp.Next == nil {
+			break
+		}
+	}
+	return items, nil
+}
+
+// fullPage wraps all items of a listing into a single page.
+func fullPage[T any](items []T) *Page[T] {
+	return &Page[T]{
+		PageSize: len(items),
+		Size:     len(items),
+		Page:     1,
+		Items:    items,
+	}
+}
+
+func filterCommentThreads(threads []PullRequestComment, options GetPullRequestOptions) *Page[PullRequestComment] {
+	filtered := []PullRequestComment{}
+	for _, thread := range threads {
+		if options.UnresolvedOnly && thread.Resolved {
+			continue
+		}
+		if options.CommentsPath != "" && (thread.Inline == nil || thread.Inline.Path != options.CommentsPath) {
+			continue
+		}
+		filtered = append(filtered, thread)
+	}
+
+	return fullPage(filtered)
+}
+
+// ListPullRequestsOptions configures filtering, sorting, and paging of the pull request listing.
+type ListPullRequestsOptions struct {
+	States   []string // Pull request states to include (e.g., "OPEN", "MERGED"); Bitbucket defaults to open ones
+	Author   string   // Author nickname, account ID, or UUID
+	Reviewer string   // Reviewer nickname, account ID, or UUID
+	Query    string   // Additional raw BBQL expression combined with the other filters
+	Sort     string   // Field to sort by, prefixed with "-" for descending order
+	Page     int      // The page number (1-based)
+	Size     int      // The number of items per page
+}
+
+// ListPullRequests retrieves a paginated list of pull requests in a repository.
+// The author and reviewer filters are translated into a BBQL query combined with
+// the raw query from the options.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - options: Filtering, sorting, and paging configuration
+//
+// Returns a Page containing PullRequest items, or an error if the request fails.
+func (s *Service) ListPullRequests(ctx context.Context, namespace string, repoSlug string, options ListPullRequestsOptions) (*Page[PullRequest], error) {
+	query := bbql.New().WhereRaw(options.Query).Sort(options.Sort)
+	if options.Author != "" {
+		query.Where(userCondition("author", options.Author))
+	}
+	if options.Reviewer != "" {
+		query.Where(userCondition("reviewers", options.Reviewer))
+	}
+
+	resp, err := s.client.ListPullRequests(ctx, namespace, repoSlug, options.Size, options.Page, options.States, query)
+	if err != nil {
+		return nil, err
+	}
+	return MapPage(resp, MapPullRequest), nil
+}
+
+// userCondition builds a BBQL condition matching a user field by UUID when the
+// identifier looks like one, otherwise by nickname or account ID.
+func userCondition(field string, user string) bbql.Condition {
+	if isUUID(user) {
+		return bbql.Eq(field+".uuid", user)
+	}
+	return bbql.Or(bbql.Eq(field+".nickname", user), bbql.Eq(field+".account_id", user))
+}
+
+// Pull request roles of a user accepted by ListUserPullRequestsOptions.
+const (
+	RoleAuthor   = "author"   // Pull requests authored by the user
+	RoleReviewer = "reviewer" // Pull requests the user is a reviewer of
+	RoleAll      = "all"      // Both authored and reviewed pull requests
+)
+
+// ListUserPullRequestsOptions configures filtering and paging of the current user's pull requests.
+type ListUserPullRequestsOptions struct {
+	Role      string   // One of RoleAuthor, RoleReviewer, or RoleAll
+	States    []string // Pull request states to include (e.g., "OPEN", "MERGED"); Bitbucket defaults to open ones
+	Namespace string   // The workspace to search for review requests; empty searches the first workspace of the user
+	Page      int      // The page number (1-based), applied to each role separately
+	Size      int      // The number of items per page, applied to each role separately
+}
+
+const (
+	maxReviewRepositories = 50 // The number of repositories searched for review requests
+	maxReviewSearches     = 8  // The number of repositories searched for review requests in parallel
+)
+
+// ListMyPullRequests retrieves the pull requests of the authenticated user: the ones they
+// authored across all repositories and the ones they are asked to review.
+// Both lists are fetched in parallel, sorted by the last update, and annotated with
+// participant approvals and the user's own review status.
+// Bitbucket lists pull requests across repositories only by author, so review requests
+// are collected from the repositories the user is a member of in one workspace, see listReviewRequests.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - options: Role, state, workspace, and paging configuration
+//
+// Returns the user's pull requests, or an error if the request fails.
+func (s *Service) ListMyPullRequests(ctx context.Context, options ListUserPullRequestsOptions) (*UserPullRequests, error) {
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	selectedUser := user.AccountId
+	if selectedUser == "" {
+		selectedUser = user.UUID
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+
+	var authored, reviewing *client.ApiResponse[client.PullRequest]
+
+	if options.Role == RoleAuthor || options.Role == RoleAll {
+		g.Go(func() error {
+			var err error
+			query := bbql.New().Where(bbql.Eq("author.uuid", user.UUID)).SortDesc("updated_on")
+			authored, err = s.client.ListUserPullRequests(ctx, selectedUser, options.Size, options.Page, options.States, query)
+			return err
+		})
+	}
+
+	if options.Role == RoleReviewer || options.Role == RoleAll {
+		g.Go(func() error {
+			var err error
+			reviewing, err = s.listReviewRequests(ctx, user.UUID, options)
 			return err
 		})
 	}
@@ -169,5 +724,2032 @@ func (s *Service) GetPullRequest(ctx context.Context, namespace string, repoSlug
 		return nil, err
 	}
 
-	return MapPullRequestDetails(pr, commits, diff, comments), nil
+	mapper := func(pr *client.PullRequest) *UserPullRequest {
+		return MapUserPullRequest(pr, user.UUID)
+	}
+
+	return &UserPullRequests{
+		User:      &user.User,
+		Authored:  MapPage(authored, mapper),
+		Reviewing: MapPage(reviewing, mapper),
+	}, nil
+}
+
+// listReviewRequests collects the pull requests the user is a reviewer of in a single workspace:
+// the one of the options or, if empty, the first workspace of the user.
+// Only the maxReviewRepositories most recently updated repositories the user is a member of
+// are searched, and only the first page of review requests is fetched from each of them,
+// so a call makes at most maxReviewRepositories+2 requests. The repositories are searched
+// in parallel, and the pull requests are merged, sorted by the last update, and paged locally.
+//
+// Returns the requested page of pull requests, or an error if any request fails.
+func (s *Service) listReviewRequests(ctx context.Context, userUUID string, options ListUserPullRequestsOptions) (*client.ApiResponse[client.PullRequest], error) {
+	namespace := options.Namespace
+	if namespace == "" {
+		memberships, err := s.client.ListWorkspaces(ctx, 1, 1)
+		if err != nil {
+			return nil, err
+		}
+		if len(memberships.Values) > 0 {
+			namespace = memberships.Values[0].Workspace.Slug
+		}
+	}
+
+	var repositories []client.Repository
+	if namespace != "" {
+		res, err := s.client.ListRepositories(ctx, namespace, maxReviewRepositories, 1, RepositoryRoleMember, bbql.New().SortDesc("updated_on"))
+		if err != nil {
+			return nil, err
+		}
+		repositories = res.Values
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(maxReviewSearches)
+
+	found := make([][]client.PullRequest, len(repositories))
+	query := bbql.New().Where(bbql.Eq("reviewers.uuid", userUUID)).SortDesc("updated_on")
+	for i, repository := range repositories {
+		g.Go(func() error {
+			res, err := s.client.ListPullRequests(ctx, namespace, repository.Slug, 50, 1, options.States, query)
+			if err != nil {
+				return err
+			}
+			found[i] = res.Values
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	prs := slices.Concat(found...)
+	slices.SortStableFunc(prs, func(a, b client.PullRequest) int {
+		return strings.Compare(b.UpdatedOn, a.UpdatedOn)
+	})
+
+	total := len(prs)
+	start := min((options.Page-1)*options.Size, total)
+	end := min(start+options.Size, total)
+	return &client.ApiResponse[client.PullRequest]{
+		Values:  prs[start:end],
+		Pagelen: options.Size,
+		Size:    &total,
+		Page:    &options.Page,
+	}, nil
+}
+
+// ListPullRequestActivityOptions configures filtering of the pull request activity timeline.
+type ListPullRequestActivityOptions struct {
+	Since *time.Time // Keep only activity after this time
+	Types []string   // Activity types to include (e.g., ActivityComment); empty includes all
+}
+
+// ListPullRequestActivity retrieves the activity timeline of a pull request: updates,
+// approvals, change requests, and comments in chronological order.
+// Bitbucket returns the newest activity first, so pages are fetched only until
+// activity older than Since is reached. Activity with an invalid date is skipped
+// when filtering by Since, so that it cannot end the paging early.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - pullRequestId: The pull request ID
+//   - options: Filtering configuration
+//
+// Returns a Page containing the PullRequestActivity items, oldest first, or an error if the request fails.
+func (s *Service) ListPullRequestActivity(ctx context.Context, namespace string, repoSlug string, pullRequestId int, options ListPullRequestActivityOptions) (*Page[PullRequestActivity], error) {
+	timeline := []PullRequestActivity{}
+	cursor := ""
+	for page := 1; page <= maxPages; page++ {
+		resp, err := s.client.ListPullRequestActivity(ctx, namespace, repoSlug, pullRequestId, 50, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		reachedSince := false
+		for i := range resp.Values {
+			activity := MapPullRequestActivity(&resp.Values[i])
+			if activity == nil {
+				continue
+			}
+			if options.Since != nil {
+				date, err := time.Parse(time.RFC3339Nano, activity.Date)
+				if err != nil {
+					slog.Warn("Skipping pull request activity with invalid date",
+						util.NewLogArgsExtractor().AddError(err).AddPullRequest(namespace, repoSlug, pullRequestId).Extract()...)
+					continue
+				}
+				if !date.After(*options.Since) {
+					reachedSince = true
+					continue
+				}
+			}
+			if len(options.Types) > 0 && !slices.Contains(options.Types, activity.Type) {
+				continue
+			}
+			timeline = append(timeline, *activity)
+		}
+
+		cursor = nextCursor(resp.Next)
+		if reachedSince || cursor == "" {
+			break
+		}
+	}
+
+	slices.SortStableFunc(timeline, func(a, b PullRequestActivity) int {
+		return parseDate(a.Date).Compare(parseDate(b.Date))
+	})
+	return fullPage(timeline), nil
+}
+
+// nextCursor extracts the "ctx" cursor parameter from the next page link of a
+// cursor-paginated listing. Returns an empty string if there is no next page.
+func nextCursor(next *string) string {
+	if next == nil {
+		return ""
+	}
+	link, err := url.Parse(*next)
+	if err != nil {
+		return ""
+	}
+	return link.Query().Get("ctx")
+}
+
+// parseDate parses a Bitbucket timestamp, returning the zero time if it is invalid.
+func parseDate(value string) time.Time {
+	date, _ := time.Parse(time.RFC3339Nano, value)
+	return date
+}
+
+// ListDefaultReviewers retrieves the effective default reviewers of a repository,
+// including the ones inherited from its project.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//
+// Returns a Page containing all DefaultReviewer items, or an error if the request fails.
+func (s *Service) ListDefaultReviewers(ctx context.Context, namespace string, repoSlug string) (*Page[DefaultReviewer], error) {
+	reviewers, err := fetchAll(func(page int) (*client.ApiResponse[client.DefaultReviewer], error) {
+		return s.client.ListEffectiveDefaultReviewers(ctx, namespace, repoSlug, 100, page)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fullPage(MapList(reviewers, MapDefaultReviewer)), nil
+}
+
+// CreatePullRequestOptions configures a pull request to create.
+type CreatePullRequestOptions struct {
+	Title                string   // Pull request title
+	Description          string   // Pull request description (Markdown)
+	Source               string   // Source branch name
+	Destination          string   // Destination branch name; defaults to the repository's main branch
+	CloseSourceBranch    *bool    // Whether to close the source branch after merging
+	Draft                *bool    // Whether the pull request is a draft
+	Reviewers            []string // Reviewers, by nickname, account ID, or UUID
+	SkipDefaultReviewers bool     // Do not add the repository's effective default reviewers
+}
+
+// CreatePullRequest creates a pull request in a repository.
+// Unless SkipDefaultReviewers is set, the effective default reviewers of the repository
+// are added along with the requested reviewers, excluding the authenticated user,
+// who becomes the author and cannot review their own pull request.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - options: The pull request to create
+//
+// Returns the created PullRequest, an InvalidParamsError if a reviewer cannot be found,
+// or an error if the request fails.
+func (s *Service) CreatePullRequest(ctx context.Context, namespace string, repoSlug string, options CreatePullRequestOptions) (*PullRequest, error) {
+	var defaults []client.DefaultReviewer
+	var user *CurrentUser
+
+	if !options.SkipDefaultReviewers {
+		g, gctx := errgroup.WithContext(ctx)
+
+		g.Go(func() error {
+			var err error
+			defaults, err = fetchAll(func(page int) (*client.ApiResponse[client.DefaultReviewer], error) {
+				return s.client.ListEffectiveDefaultReviewers(gctx, namespace, repoSlug, 100, page)
+			})
+			return err
+		})
+
+		g.Go(func() error {
+			var err error
+			user, err = s.currentUser(gctx)
+			return err
+		})
+
+		if err := g.Wait(); err != nil {
+			return nil, err
+		}
+	}
+
+	reviewers := make([]client.User, 0, len(defaults))
+	for _, reviewer := range defaults {
+		if user == nil || reviewer.User.UUID != user.UUID {
+			reviewers = append(reviewers, reviewer.User)
+		}
+	}
+
+	requested, err := s.resolveUsers(ctx, namespace, "reviewer", options.Reviewers, reviewers)
+	if err != nil {
+		return nil, err
+	}
+	reviewers = mergeUsers(reviewers, requested)
+
+	body := &client.CreatePullRequestRequest{
+		Title:       options.Title,
+		Description: options.Description,
+		Source: client.CreatePullRequestBranch{
+			Branch: client.CreatePullRequestBranchName{Name: options.Source},
+		},
+		CloseSourceBranch: options.CloseSourceBranch,
+		Draft:             options.Draft,
+		Reviewers:         reviewerRefs(reviewers),
+	}
+	if options.Destination != "" {
+		body.Destination = &client.CreatePullRequestBranch{
+			Branch: client.CreatePullRequestBranchName{Name: options.Destination},
+		}
+	}
+
+	pr, err := s.client.CreatePullRequest(ctx, namespace, repoSlug, body)
+	if err != nil {
+		return nil, err
+	}
+	return MapPullRequest(pr), nil
+}
+
+// UpdatePullRequestOptions configures the changes applied to a pull request.
+// Nil fields are left unchanged.
+type UpdatePullRequestOptions struct {
+	Title             *string  // New title
+	Description       *string  // New description (Markdown)
+	Destination       *string  // New destination branch name
+	CloseSourceBranch *bool    // Whether to close the source branch after merging
+	Draft             *bool    // Whether the pull request is a draft
+	AddReviewers      []string // Reviewers to add, by nickname, account ID, or UUID
+	RemoveReviewers   []string // Reviewers to remove, by nickname, account ID, or UUID
+}
+
+// UpdatePullRequest updates a pull request and adds or removes its reviewers.
+// Reviewers are resolved among the pull request participants and the workspace members
+// by nickname, account ID, or UUID.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - pullRequestId: The pull request ID
+//   - options: The changes to apply
+//
+// Returns the updated PullRequest, an InvalidParamsError if a reviewer to add cannot be found,
+// or an error if the request fails.
+func (s *Service) UpdatePullRequest(ctx context.Context, namespace string, repoSlug string, pullRequestId int, options UpdatePullRequestOptions) (*PullRequest, error) {
+	pr, err := s.client.GetPullRequest(ctx, namespace, repoSlug, pullRequestId)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewers, err := s.updateReviewers(ctx, namespace, pr, options.AddReviewers, options.RemoveReviewers)
+	if err != nil {
+		return nil, err
+	}
+
+	body := &client.UpdatePullRequestRequest{
+		Title:             pr.Title,
+		Description:       options.Description,
+		CloseSourceBranch: options.CloseSourceBranch,
+		Draft:             options.Draft,
+		Reviewers:         reviewerRefs(reviewers),
+	}
+	if options.Title != nil {
+		body.Title = *options.Title
+	}
+	if options.Destination != nil {
+		body.Destination = &client.CreatePullRequestBranch{
+			Branch: client.CreatePullRequestBranchName{Name: *options.Destination},
+		}
+	}
+	updated, err := s.client.UpdatePullRequest(ctx, namespace, repoSlug, pullRequestId, body)
+	if err != nil {
+		return nil, err
+	}
+	return MapPullRequest(updated), nil
+}
+
+// updateReviewers applies the reviewer additions and removals to the current reviewers
+// of a pull request. Users to add are looked up among the participants first.
+func (s *Service) updateReviewers(ctx context.Context, namespace string, pr *client.PullRequest, add []string, remove []string) ([]client.User, error) {
+	reviewers := slices.DeleteFunc(slices.Clone(pr.Reviewers), func(user client.User) bool {
+		return slices.ContainsFunc(remove, func(id string) bool { return matchUser(user, id) })
+	})
+
+	known := slices.Clone(pr.Reviewers)
+	for _, participant := range pr.Participants {
+		known = append(known, participant.User)
+	}
+
+	added, err := s.resolveUsers(ctx, namespace, "reviewer", add, known)
+	if err != nil {
+		return nil, err
+	}
+	return mergeUsers(reviewers, added), nil
+}
+
+// resolveUsers resolves user identifiers (nickname, account ID, or UUID) among the known users
+// and then among the workspace members, which are only fetched when needed.
+// A UUID that cannot be found is used as is. The role names the users in error messages.
+//
+// Returns an InvalidParamsError if any other identifier cannot be resolved.
+func (s *Service) resolveUsers(ctx context.Context, namespace string, role string, ids []string, known []client.User) ([]client.User, error) {
+	users := make([]client.User, 0, len(ids))
+	var members []client.User
+	for _, id := range ids {
+		match := func(user client.User) bool { return matchUser(user, id) }
+
+		if i := slices.IndexFunc(known, match); i >= 0 {
+			users = append(users, known[i])
+			continue
+		}
+
+		if members == nil {
+			memberships, err := fetchAll(func(page int) (*client.ApiResponse[client.WorkspaceMembership], error) {
+				return s.client.ListWorkspaceMembers(ctx, namespace, 100, page)
+			})
+			if err != nil {
+				return nil, err
+			}
+			members = make([]client.User, 0, len(memberships))
+			for _, membership := range memberships {
+				members = append(members, membership.User)
+			}
+		}
+
+		if i := slices.IndexFunc(members, match); i >= 0 {
+			users = append(users, members[i])
+			continue
+		}
+
+		if !isUUID(id) {
+			return nil, util.NewInvalidParamsError(role + " not found in workspace: " + id)
+		}
+		users = append(users, client.User{UUID: id})
+	}
+	return users, nil
+}
+
+// mergeUsers appends the users that are not in the list yet, comparing them by UUID.
+func mergeUsers(users []client.User, more []client.User) []client.User {
+	for _, user := range more {
+		if !slices.ContainsFunc(users, func(u client.User) bool { return u.UUID == user.UUID }) {
+			users = append(users, user)
+		}
+	}
+	return users
+}
+
+// reviewerRefs converts users into the reviewer references of a pull request request body.
+// The result is never nil, so an empty list clears the reviewers.
+func reviewerRefs(users []client.User) []client.CreatePullRequestReviewer {
+	refs := make([]client.CreatePullRequestReviewer, 0, len(users))
+	for _, user := range users {
+		refs = append(refs, client.CreatePullRequestReviewer{UUID: user.UUID})
+	}
+	return refs
+}
+
+// matchUser reports whether the user is identified by the given nickname, account ID, or UUID.
+// Nicknames are compared case-insensitively.
+func matchUser(user client.User, id string) bool {
+	return id == user.UUID || id == user.AccountID || (user.Nickname != "" && strings.EqualFold(id, user.Nickname))
+}
+
+// isUUID reports whether the user identifier is a Bitbucket UUID enclosed in braces.
+func isUUID(id string) bool {
+	return strings.HasPrefix(id, "{") && strings.HasSuffix(id, "}")
+}
+
+// ApprovePullRequest approves a pull request as the authenticated user.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - pullRequestId: The pull request ID
+//
+// Returns the updated review state with all participants, or an error if the request fails.
+func (s *Service) ApprovePullRequest(ctx context.Context, namespace string, repoSlug string, pullRequestId int) (*PullRequestReview, error) {
+	return s.review(ctx, namespace, repoSlug, pullRequestId, func() error {
+		_, err := s.client.ApprovePullRequest(ctx, namespace, repoSlug, pullRequestId)
+		return err
+	})
+}
+
+// UnapprovePullRequest withdraws the authenticated user's approval of a pull request.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - pullRequestId: The pull request ID
+//
+// Returns the updated review state with all participants, or an error if the request fails.
+func (s *Service) UnapprovePullRequest(ctx context.Context, namespace string, repoSlug string, pullRequestId int) (*PullRequestReview, error) {
+	return s.review(ctx, namespace, repoSlug, pullRequestId, func() error {
+		return s.client.UnapprovePullRequest(ctx, namespace, repoSlug, pullRequestId)
+	})
+}
+
+// RequestPullRequestChanges requests changes on a pull request as the authenticated user,
+// or withdraws the change request if remove is true.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - pullRequestId: The pull request ID
+//   - remove: Whether to withdraw a previous change request instead
+//
+// Returns the updated review state with all participants, or an error if the request fails.
+func (s *Service) RequestPullRequestChanges(ctx context.Context, namespace string, repoSlug string, pullRequestId int, remove bool) (*PullRequestReview, error) {
+	return s.review(ctx, namespace, repoSlug, pullRequestId, func() error {
+		if remove {
+			return s.client.RemovePullRequestChangeRequest(ctx, namespace, repoSlug, pullRequestId)
+		}
+		_, err := s.client.RequestPullRequestChanges(ctx, namespace, repoSlug, pullRequestId)
+		return err
+	})
+}
+
+// review performs a review action and fetches the pull request afterwards,
+// so that the returned participants reflect the action.
+func (s *Service) review(ctx context.Context, namespace string, repoSlug string, pullRequestId int, action func() error) (*PullRequestReview, error) {
+	if err := action(); err != nil {
+		return nil, err
+	}
+
+	pr, err := s.client.GetPullRequest(ctx, namespace, repoSlug, pullRequestId)
+	if err != nil {
+		return nil, err
+	}
+	return MapPullRequestReview(pr), nil
+}
+
+// ListPullRequestTasks retrieves all tasks of a pull request.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - pullRequestId: The pull request ID
+//
+// Returns a Page containing all PullRequestTask items, or an error if the request fails.
+func (s *Service) ListPullRequestTasks(ctx context.Context, namespace string, repoSlug string, pullRequestId int) (*Page[PullRequestTask], error) {
+	tasks, err := fetchAll(func(page int) (*client.ApiResponse[client.PullRequestTask], error) {
+		return s.client.ListPullRequestTasks(ctx, namespace, repoSlug, pullRequestId, 100, page)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fullPage(MapList(tasks, MapPullRequestTask)), nil
+}
+
+// CreatePullRequestTask creates a task on a pull request.
+// When commentId is set, the task is attached to that comment.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - pullRequestId: The pull request ID
+//   - content: The task description (Markdown)
+//   - commentId: Optional ID of the comment to attach the task to
+//
+// Returns the created PullRequestTask, or an error if the request fails.
+func (s *Service) CreatePullRequestTask(ctx context.Context, namespace string, repoSlug string, pullRequestId int, content string, commentId *int) (*PullRequestTask, error) {
+	body := &client.CreatePullRequestTaskRequest{
+		Content: client.CreatePullRequestCommentContent{Raw: content},
+	}
+	if commentId != nil {
+		body.Comment = &client.PullRequestTaskCommentRef{ID: *commentId}
+	}
+
+	task, err := s.client.CreatePullRequestTask(ctx, namespace, repoSlug, pullRequestId, body)
+	if err != nil {
+		return nil, err
+	}
+	return MapPullRequestTask(task), nil
+}
+
+// ResolvePullRequestTask marks a pull request task as resolved, or reopens it if resolved is false.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - pullRequestId: The pull request ID
+//   - taskId: The task ID
+//   - resolved: Whether the task should be resolved or reopened
+//
+// Returns the updated PullRequestTask, or an error if the request fails.
+func (s *Service) ResolvePullRequestTask(ctx context.Context, namespace string, repoSlug string, pullRequestId int, taskId int, resolved bool) (*PullRequestTask, error) {
+	state := "UNRESOLVED"
+	if resolved {
+		state = "RESOLVED"
+	}
+
+	task, err := s.client.UpdatePullRequestTask(ctx, namespace, repoSlug, pullRequestId, taskId, &client.UpdatePullRequestTaskRequest{State: state})
+	if err != nil {
+		return nil, err
+	}
+	return MapPullRequestTask(task), nil
+}
+
+// DeletePullRequestTask deletes a task from a pull request.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - pullRequestId: The pull request ID
+//   - taskId: The task ID
+//
+// Returns an error if the deletion fails.
+func (s *Service) DeletePullRequestTask(ctx context.Context, namespace string, repoSlug string, pullRequestId int, taskId int) error {
+	return s.client.DeletePullRequestTask(ctx, namespace, repoSlug, pullRequestId, taskId)
+}
+
+// Diffstat statuses of the files in conflict between the source and destination branches.
+var conflictStatuses = []string{"merge conflict", "local deleted", "remote deleted"}
+
+// Branch restriction kinds evaluated as merge checks, in the order they are reported.
+var mergeCheckKinds = []string{
+	"require_approvals_to_merge",
+	"require_default_reviewer_approvals_to_merge",
+	"require_passing_builds_to_merge",
+	"require_tasks_to_be_completed",
+	"require_no_changes_requested",
+}
+
+// GetMergeStatus checks whether a pull request can be merged into its destination branch.
+// It reports the merge strategies allowed on the destination branch, the files in conflict,
+// and the merge checks configured by the branch restrictions matching the destination branch:
+// required approvals, passing builds, completed tasks, and no change requests.
+// The merge checks are enforced only if the destination branch enforces them;
+// if the branch restrictions cannot be read, a warning is reported instead.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - pullRequestId: The pull request ID
+//
+// Returns the MergeStatus of the pull request, or an error if the request fails.
+func (s *Service) GetMergeStatus(ctx context.Context, namespace string, repoSlug string, pullRequestId int) (*MergeStatus, error) {
+	pr, err := s.client.GetPullRequest(ctx, namespace, repoSlug, pullRequestId)
+	if err != nil {
+		return nil, err
+	}
+
+	var branch *client.Branch
+	var diffstat []client.DiffStat
+	var statuses []client.CommitStatus
+	var restrictions []client.BranchRestriction
+	var restrictionsErr error
+
+	g, gctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		var err error
+		branch, err = s.client.GetBranch(gctx, namespace, repoSlug, pr.Destination.Branch.Name)
+		return err
+	})
+
+	g.Go(func() error {
+		var err error
+		diffstat, err = fetchAll(func(page int) (*client.ApiResponse[client.DiffStat], error) {
+			return s.client.GetPullRequestDiffStat(gctx, namespace, repoSlug, pullRequestId, 500, page)
+		})
+		return err
+	})
+
+	g.Go(func() error {
+		var err error
+		statuses, err = fetchAll(func(page int) (*client.ApiResponse[client.CommitStatus], error) {
+			return s.client.ListPullRequestStatuses(gctx, namespace, repoSlug, pullRequestId, 100, page)
+		})
+		return err
+	})
+
+	g.Go(func() error {
+		restrictions, restrictionsErr = fetchAll(func(page int) (*client.ApiResponse[client.BranchRestriction], error) {
+			return s.client.ListBranchRestrictions(gctx, namespace, repoSlug, 100, page)
+		})
+		if util.IsInvalidParamsError(restrictionsErr) {
+			return nil
+		}
+		return restrictionsErr
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	status := &MergeStatus{
+		ID:                   pr.ID,
+		State:                pr.State,
+		Destination:          branch.Name,
+		MergeStrategies:      branch.MergeStrategies,
+		DefaultMergeStrategy: branch.DefaultMergeStrategy,
+	}
+
+	for _, file := range diffstat {
+		if !slices.Contains(conflictStatuses, file.Status) {
+			continue
+		}
+		if file.New != nil {
+			status.Conflicts = append(status.Conflicts, file.New.Path)
+		} else if file.Old != nil {
+			status.Conflicts = append(status.Conflicts, file.Old.Path)
+		}
+	}
+
+	draft := "pull request is not a draft"
+	if pr.Draft {
+		draft = "pull request is a draft"
+	}
+	status.Checks = []MergeCheck{
+		{Name: "state", Passed: pr.State == "OPEN", Enforced: true, Message: "pull request is " + pr.State},
+		{Name: "draft", Passed: !pr.Draft, Enforced: true, Message: draft},
+		{Name: "conflicts", Passed: len(status.Conflicts) == 0, Enforced: true, Message: fmt.Sprintf("%d files have merge conflicts", len(status.Conflicts))},
+	}
+
+	if util.IsInvalidParamsError(restrictionsErr) {
+		status.Warnings = append(status.Warnings, "merge checks were not evaluated because the branch restrictions could not be read: "+restrictionsErr.Error())
+	} else {
+		checks, warnings, err := s.restrictionChecks(ctx, namespace, repoSlug, pr, restrictions, statuses)
+		if err != nil {
+			return nil, err
+		}
+		status.Checks = append(status.Checks, checks...)
+		status.Warnings = append(status.Warnings, warnings...)
+	}
+
+	status.Mergeable = true
+	for _, check := range status.Checks {
+		if check.Enforced && !check.Passed {
+			status.Mergeable = false
+		}
+	}
+	return status, nil
+}
+
+// restrictionChecks evaluates the merge checks of the branch restrictions
+// matching the destination branch of a pull request. When several restrictions
+// of the same kind match, the strictest one applies.
+func (s *Service) restrictionChecks(ctx context.Context, namespace string, repoSlug string, pr *client.PullRequest, restrictions []client.BranchRestriction, statuses []client.CommitStatus) ([]MergeCheck, []string, error) {
+	var model *client.BranchingModel
+	var warnings []string
+	for _, restriction := range restrictions {
+		if restriction.BranchMatchKind == "branching_model" {
+			var err error
+			model, err = s.client.GetEffectiveBranchingModel(ctx, namespace, repoSlug)
+			if util.IsInvalidParamsError(err) {
+				warnings = append(warnings, "branching model restrictions were not evaluated because the branching model could not be read: "+err.Error())
+			} else if err != nil {
+				return nil, nil, err
+			}
+			break
+		}
+	}
+
+	destination := pr.Destination.Branch.Name
+	enforced := false
+	values := map[string]int{}
+	for _, restriction := range restrictions {
+		if !matchesBranch(restriction, destination, model) {
+			continue
+		}
+		if restriction.Kind == "enforce_merge_checks" {
+			enforced = true
+		}
+		if slices.Contains(mergeCheckKinds, restriction.Kind) {
+			value := 1
+			if restriction.Value != nil {
+				value = *restriction.Value
+			}
+			values[restriction.Kind] = max(values[restriction.Kind], value)
+		}
+	}
+
+	var defaults []client.DefaultReviewer
+	if _, ok := values["require_default_reviewer_approvals_to_merge"]; ok {
+		var err error
+		defaults, err = fetchAll(func(page int) (*client.ApiResponse[client.DefaultReviewer], error) {
+			return s.client.ListEffectiveDefaultReviewers(ctx, namespace, repoSlug, 100, page)
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var checks []MergeCheck
+	for _, kind := range mergeCheckKinds {
+		required, ok := values[kind]
+		if !ok {
+			continue
+		}
+		check := MergeCheck{Name: kind, Enforced: enforced}
+		switch kind {
+		case "require_approvals_to_merge":
+			approvals := 0
+			for _, participant := range pr.Participants {
+				if participant.Approved && participant.User.UUID != pr.Author.UUID {
+					approvals++
+				}
+			}
+			check.Passed = approvals >= required
+			check.Message = fmt.Sprintf("%d of %d required approvals", approvals, required)
+		case "require_default_reviewer_approvals_to_merge":
+			approvals := 0
+			for _, participant := range pr.Participants {
+				if participant.Approved && participant.User.UUID != pr.Author.UUID && slices.ContainsFunc(defaults, func(reviewer client.DefaultReviewer) bool {
+					return reviewer.User.UUID == participant.User.UUID
+				}) {
+					approvals++
+				}
+			}
+			check.Passed = approvals >= required
+			check.Message = fmt.Sprintf("%d of %d required default reviewer approvals", approvals, required)
+		case "require_passing_builds_to_merge":
+			// Like Bitbucket, stopped builds neither count as successful nor block the merge as failed.
+			successful, failed, running, stopped := 0, 0, 0, 0
+			for _, build := range statuses {
+				switch build.State {
+				case BuildStateSuccessful:
+					successful++
+				case BuildStateInProgress:
+					running++
+				case BuildStateStopped:
+					stopped++
+				default:
+					failed++
+				}
+			}
+			check.Passed = successful >= required && failed == 0 && running == 0
+			check.Message = fmt.Sprintf("%d of %d required successful builds, %d failed, %d in progress", successful, required, failed, running)
+			if stopped > 0 {
+				check.Message += fmt.Sprintf(", %d stopped", stopped)
+			}
+		case "require_tasks_to_be_completed":
+			check.Passed = pr.TaskCount == 0
+			check.Message = fmt.Sprintf("%d open tasks", pr.TaskCount)
+		case "require_no_changes_requested":
+			requests := 0
+			for _, participant := range pr.Participants {
+				if participant.State != nil && *participant.State == "changes_requested" {
+					requests++
+				}
+			}
+			check.Passed = requests == 0
+			check.Message = fmt.Sprintf("%d participants requested changes", requests)
+		}
+		checks = append(checks, check)
+	}
+	return checks, warnings, nil
+}
+
+// matchesBranch checks whether a branch restriction applies to a branch,
+// either by glob pattern or by branch type of the branching model.
+func matchesBranch(restriction client.BranchRestriction, branch string, model *client.BranchingModel) bool {
+	if restriction.BranchMatchKind != "branching_model" {
+		return matchGlob(restriction.Pattern, branch)
+	}
+	if model == nil {
+		return false
+	}
+	switch restriction.BranchType {
+	case "development":
+		return model.Development != nil && modelBranchName(model.Development) == branch
+	case "production":
+		return model.Production != nil && modelBranchName(model.Production) == branch
+	}
+	for _, branchType := range model.BranchTypes {
+		if branchType.Kind == restriction.BranchType && strings.HasPrefix(branch, branchType.Prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// modelBranchName returns the name of the branch a branching model branch resolves to.
+func modelBranchName(branch *client.BranchingModelBranch) string {
+	if branch.Branch != nil {
+		return branch.Branch.Name
+	}
+	return branch.Name
+}
+
+// globPatterns caches the regular expressions of the branch patterns matched by matchGlob.
+var globPatterns sync.Map
+
+// matchGlob checks whether a branch name matches a Bitbucket branch pattern,
+// where '*' matches any sequence of characters, including '/', and '?' any single character.
+func matchGlob(pattern string, name string) bool {
+	if cached, ok := globPatterns.Load(pattern); ok {
+		return cached.(*regexp.Regexp).MatchString(name)
+	}
+
+	var expr strings.Builder
+	expr.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			expr.WriteString(".*")
+		case '?':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expr.WriteString("$")
+	re := regexp.MustCompile(expr.String())
+	globPatterns.Store(pattern, re)
+	return re.MatchString(name)
+}
+
+// MergePullRequestOptions configures how a pull request is merged.
+type MergePullRequestOptions struct {
+	Strategy          string       // Merge strategy; defaults to the destination branch's default strategy
+	Message           string       // Merge commit message; defaults to the one generated by Bitbucket
+	CloseSourceBranch *bool        // Whether to close the source branch; defaults to the pull request setting
+	Progress          ProgressFunc // Reports the progress of a merge that Bitbucket completes asynchronously; may be nil
+}
+
+// ProgressFunc reports the progress of a long-running operation.
+// Progress increases with every report; total is zero when it is unknown.
+type ProgressFunc func(progress float64, total float64, message string)
+
+// Backoff of polling the status of an asynchronous merge: the delay starts at
+// mergePollInitialDelay and doubles up to mergePollMaxDelay until mergePollTimeout elapses.
+var (
+	mergePollInitialDelay = 500 * time.Millisecond
+	mergePollMaxDelay     = 5 * time.Second
+	mergePollTimeout      = 5 * time.Minute
+)
+
+// MergePullRequest merges a pull request after checking that it can be merged.
+// If a check fails, the merge strategy is not allowed on the destination branch,
+// or Bitbucket rejects the merge, the pull request is left unmerged and the result
+// explains why instead of returning an error.
+// If Bitbucket continues the merge asynchronously, the merge task is polled
+// with backoff until it completes, reporting progress to options.Progress.
+// The context is checked between polls, so canceling it stops the waiting
+// but not a request in flight.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - pullRequestId: The pull request ID
+//   - options: The merge strategy, commit message, and source branch handling
+//
+// Returns the MergeResult with the merged PullRequest or the reasons it was not merged,
+// or an error if the request fails.
+func (s *Service) MergePullRequest(ctx context.Context, namespace string, repoSlug string, pullRequestId int, options MergePullRequestOptions) (*MergeResult, error) {
+	status, err := s.GetMergeStatus(ctx, namespace, repoSlug, pullRequestId)
+	if err != nil {
+		return nil, err
+	}
+
+	var reasons []string
+	for _, check := range status.Checks {
+		if check.Enforced && !check.Passed {
+			reasons = append(reasons, check.Message)
+		}
+	}
+	if options.Strategy != "" && len(status.MergeStrategies) > 0 && !slices.Contains(status.MergeStrategies, options.Strategy) {
+		reasons = append(reasons, fmt.Sprintf("merge strategy %s is not allowed on %s; allowed strategies: %s",
+			options.Strategy, status.Destination, strings.Join(status.MergeStrategies, ", ")))
+	}
+	if len(reasons) > 0 {
+		return &MergeResult{Reasons: reasons, Status: status}, nil
+	}
+
+	merge, err := s.client.MergePullRequest(ctx, namespace, repoSlug, pullRequestId, &client.MergePullRequestRequest{
+		Type:              "pullrequest_merge_parameters",
+		Message:           options.Message,
+		CloseSourceBranch: options.CloseSourceBranch,
+		MergeStrategy:     options.Strategy,
+	})
+	if util.IsInvalidParamsError(err) {
+		return &MergeResult{Reasons: []string{err.Error()}, Status: status}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	pr := merge.PullRequest
+	if pr == nil {
+		pr, err = s.awaitMerge(ctx, namespace, repoSlug, pullRequestId, merge.TaskID, options.Progress)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &MergeResult{Merged: true, PullRequest: MapPullRequest(pr)}, nil
+}
+
+// awaitMerge polls the status of an asynchronous merge task until the merge completes.
+//
+// The context is checked between polls; a status request in flight is not interrupted.
+//
+// Returns the merged pull request, a ResourceUnavailableError if the merge does not
+// complete within mergePollTimeout or its status cannot be fetched, since Bitbucket
+// has accepted the merge and its result is then unknown, or the context error
+// if the context is canceled.
+func (s *Service) awaitMerge(ctx context.Context, namespace string, repoSlug string, pullRequestId int, taskId string, progress ProgressFunc) (*client.PullRequest, error) {
+	pollCtx, cancel := context.WithTimeout(ctx, mergePollTimeout)
+	defer cancel()
+
+	stopped := func() error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return util.NewResourceUnavailableError(fmt.Sprintf("merge of pull request #%d is still in progress (task %s)", pullRequestId, taskId))
+	}
+
+	status := "PENDING"
+	delay := mergePollInitialDelay
+	for attempt := 1; ; attempt++ {
+		if progress != nil {
+			progress(float64(attempt), 0, fmt.Sprintf("Merging pull request #%d: task %s is %s", pullRequestId, taskId, status))
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-pollCtx.Done():
+			timer.Stop()
+			return nil, stopped()
+		case <-timer.C:
+		}
+
+		task, err := s.client.GetMergeTaskStatus(pollCtx, namespace, repoSlug, pullRequestId, taskId)
+		if pollCtx.Err() != nil {
+			return nil, stopped()
+		}
+		if err != nil {
+			return nil, util.NewResourceUnavailableError(fmt.Sprintf("merge of pull request #%d was accepted but its result is unknown (task %s): %v", pullRequestId, taskId, err))
+		}
+
+		status = task.TaskStatus
+		switch status {
+		case "PENDING":
+			delay = min(delay*2, mergePollMaxDelay)
+		case "SUCCESS":
+			if task.MergeResult != nil {
+				return task.MergeResult, nil
+			}
+			return s.client.GetPullRequest(ctx, namespace, repoSlug, pullRequestId)
+		default:
+			return nil, util.NewResourceUnavailableError(fmt.Sprintf("merge of pull request #%d ended with task status %s", pullRequestId, status))
+		}
+	}
+}
+
+// ListPipelinesOptions configures filtering and paging of the pipeline listing.
+type ListPipelinesOptions struct {
+	Branch string // Branch whose pipelines to list; empty includes all branches
+	Status string // Pipeline status to filter by (e.g. FAILED, SUCCESSFUL, IN_PROGRESS)
+	Page   int    // The page number (1-based)
+	Size   int    // The number of items per page
+}
+
+// ListPipelines retrieves a paginated list of pipelines of a repository, newest first.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - options: Filtering and paging configuration
+//
+// Returns a Page containing Pipeline items, or an error if the request fails.
+func (s *Service) ListPipelines(ctx context.Context, namespace string, repoSlug string, options ListPipelinesOptions) (*Page[Pipeline], error) {
+	resp, err := s.client.ListPipelines(ctx, namespace, repoSlug, options.Size, options.Page, options.Branch, options.Status)
+	if err != nil {
+		return nil, err
+	}
+	return MapPage(resp, MapPipeline), nil
+}
+
+// pipelineLogTailLength limits the size of the log tail included for failed steps, in bytes.
+const pipelineLogTailLength = 16 * 1024
+
+// Results of a pipeline step whose log tail is included in the pipeline details.
+var failedStepResults = []string{"FAILED", "ERROR"}
+
+// GetPipeline retrieves a pipeline of a repository with all of its steps.
+// For every failed step, the tail of its log is fetched in parallel,
+// so that the cause of a failure can be diagnosed without further requests.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - pipeline: The pipeline UUID or build number
+//
+// Returns the PipelineDetails, or an error if the request fails.
+func (s *Service) GetPipeline(ctx context.Context, namespace string, repoSlug string, pipeline string) (*PipelineDetails, error) {
+	resp, err := s.client.GetPipeline(ctx, namespace, repoSlug, pipelineRef(pipeline))
+	if err != nil {
+		return nil, err
+	}
+
+	steps, err := fetchAll(func(page int) (*client.ApiResponse[client.PipelineStep], error) {
+		return s.client.ListPipelineSteps(ctx, namespace, repoSlug, resp.UUID, 100, page)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	details := &PipelineDetails{
+		Pipeline: MapPipeline(resp),
+		Steps:    fullPage(MapList(steps, MapPipelineStep)),
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	for i := range details.Steps.Items {
+		step := &details.Steps.Items[i]
+		if !slices.Contains(failedStepResults, step.Result) {
+			continue
+		}
+		g.Go(func() error {
+			log, err := s.client.GetPipelineStepLog(gctx, namespace, repoSlug, resp.UUID, step.UUID, fmt.Sprintf("bytes=-%d", pipelineLogTailLength))
+			if util.IsResourceNotFoundError(err) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			step.LogTail = logTail(log.Content, pipelineLogTailLength)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return details, nil
+}
+
+// logTail cuts a log to its last maxLength bytes, in case Bitbucket ignored the requested range
+// and returned the whole log, and drops the partial first line of the cut log.
+func logTail(content string, maxLength int) *string {
+	content = content[max(len(content)-maxLength, 0):]
+	if len(content) >= maxLength {
+		if i := strings.IndexByte(content, '\n'); i >= 0 {
+			content = content[i+1:]
+		}
+	}
+	return &content
+}
+
+// PipelineStepLogOptions configures the byte range of a step log to retrieve.
+type PipelineStepLogOptions struct {
+	Offset *int64 // Offset of the first byte; nil retrieves the last Length bytes
+	Length int64  // The maximum number of bytes to retrieve
+}
+
+// GetPipelineStepLog retrieves a byte range of the log of a pipeline step.
+// When Bitbucket returns the whole log instead of the requested range,
+// the range is applied locally.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - pipeline: The pipeline UUID or build number
+//   - step: The step UUID
+//   - options: The byte range to retrieve
+//
+// Returns the PipelineStepLog, or an error if the request fails.
+func (s *Service) GetPipelineStepLog(ctx context.Context, namespace string, repoSlug string, pipeline string, step string, options PipelineStepLogOptions) (*PipelineStepLog, error) {
+	pipelineUUID := pipelineRef(pipeline)
+	if !isUUID(pipelineUUID) {
+		resp, err := s.client.GetPipeline(ctx, namespace, repoSlug, pipelineUUID)
+		if err != nil {
+			return nil, err
+		}
+		pipelineUUID = resp.UUID
+	}
+
+	byteRange := fmt.Sprintf("bytes=-%d", options.Length)
+	if options.Offset != nil {
+		byteRange = fmt.Sprintf("bytes=%d-%d", *options.Offset, *options.Offset+options.Length-1)
+	}
+
+	resp, err := s.client.GetPipelineStepLog(ctx, namespace, repoSlug, pipelineUUID, braced(step), byteRange)
+	if err != nil {
+		return nil, err
+	}
+
+	if start, size, ok := parseContentRange(resp.ContentRange); ok {
+		return &PipelineStepLog{Offset: start, Length: int64(len(resp.Content)), Size: size, Content: resp.Content}, nil
+	}
+
+	size := int64(len(resp.Content))
+	start := max(size-options.Length, 0)
+	if options.Offset != nil {
+		start = min(*options.Offset, size)
+	}
+	end := min(start+options.Length, size)
+	return &PipelineStepLog{Offset: start, Length: end - start, Size: size, Content: resp.Content[start:end]}, nil
+}
+
+// contentRangeRegex matches a Content-Range header value, e.g. "bytes 100-199/1000".
+var contentRangeRegex = regexp.MustCompile(`^bytes (\d+)-\d+/(\d+|\*)$`)
+
+// parseContentRange extracts the first byte offset and the total size from a Content-Range header value.
+// The size is 0 if the total size is unknown.
+//
+// Returns false if the value is empty or malformed.
+func parseContentRange(value string) (int64, int64, bool) {
+	matches := contentRangeRegex.FindStringSubmatch(value)
+	if matches == nil {
+		return 0, 0, false
+	}
+	start, _ := strconv.ParseInt(matches[1], 10, 64)
+	size, _ := strconv.ParseInt(matches[2], 10, 64)
+	return start, size, true
+}
+
+// pipelineRef converts a pipeline identifier into a path segment accepted by Bitbucket:
+// build numbers are kept as they are and UUIDs are enclosed in braces.
+func pipelineRef(id string) string {
+	if _, err := strconv.Atoi(id); err == nil {
+		return id
+	}
+	return braced(id)
+}
+
+// braced encloses a UUID in braces unless it already is.
+func braced(uuid string) string {
+	if isUUID(uuid) {
+		return uuid
+	}
+	return "{" + uuid + "}"
+}
+
+// TriggerPipelineOptions configures the target of a new pipeline run.
+// At least one of Branch and Commit must be set.
+type TriggerPipelineOptions struct {
+	Branch    string             // Branch to run the pipeline on
+	Commit    string             // Commit hash to run the pipeline on; pins the commit when combined with Branch
+	Custom    string             // Name of a custom pipeline to run instead of the one configured for the branch
+	Variables []PipelineVariable // Variables passed to the pipeline
+}
+
+// TriggerPipeline starts a new pipeline run on a branch or commit.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - options: The target of the run
+//
+// Returns the created Pipeline, or an error if the request fails.
+func (s *Service) TriggerPipeline(ctx context.Context, namespace string, repoSlug string, options TriggerPipelineOptions) (*Pipeline, error) {
+	target := client.PipelineTarget{Type: "pipeline_ref_target", RefType: "branch", RefName: options.Branch}
+	if options.Branch == "" {
+		target = client.PipelineTarget{Type: "pipeline_commit_target"}
+	}
+	if options.Commit != "" {
+		target.Commit = &client.PipelineCommit{Type: "commit", Hash: options.Commit}
+	}
+	if options.Custom != "" {
+		target.Selector = &client.PipelineSelector{Type: "custom", Pattern: options.Custom}
+	}
+
+	variables := make([]client.PipelineVariable, len(options.Variables))
+	for i, variable := range options.Variables {
+		variables[i] = client.PipelineVariable{Type: "pipeline_variable", Key: variable.Key, Value: variable.Value, Secured: variable.Secured}
+	}
+
+	pipeline, err := s.client.TriggerPipeline(ctx, namespace, repoSlug, &client.TriggerPipelineRequest{
+		Target:    target,
+		Variables: variables,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return MapPipeline(pipeline), nil
+}
+
+// StopPipeline stops a running pipeline.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - pipeline: The pipeline UUID or build number
+//
+// Returns the UUID of the stopped pipeline, or an error if the request fails.
+func (s *Service) StopPipeline(ctx context.Context, namespace string, repoSlug string, pipeline string) (string, error) {
+	pipelineUUID := pipelineRef(pipeline)
+	if !isUUID(pipelineUUID) {
+		resp, err := s.client.GetPipeline(ctx, namespace, repoSlug, pipelineUUID)
+		if err != nil {
+			return "", err
+		}
+		pipelineUUID = resp.UUID
+	}
+
+	if err := s.client.StopPipeline(ctx, namespace, repoSlug, pipelineUUID); err != nil {
+		return "", err
+	}
+	return pipelineUUID, nil
+}
+
+// RestartPipeline starts a new run of a completed pipeline on the same target,
+// selector, and commit with the same variables. Every step runs again, not only
+// the failed steps of the original run. Bitbucket does not return the values
+// of secured variables, so they are dropped unless passed again in variables,
+// which also override the values of the original run.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - pipeline: The pipeline UUID or build number
+//   - variables: Variables to add to or override in the original run
+//
+// Returns the created Pipeline, or an error if the request fails.
+func (s *Service) RestartPipeline(ctx context.Context, namespace string, repoSlug string, pipeline string, variables []PipelineVariable) (*Pipeline, error) {
+	original, err := s.client.GetPipeline(ctx, namespace, repoSlug, pipelineRef(pipeline))
+	if err != nil {
+		return nil, err
+	}
+	if original.State.Name != "COMPLETED" {
+		return nil, util.NewInvalidParamsError(fmt.Sprintf("pipeline: #%d is still %s", original.BuildNumber, strings.ToLower(original.State.Name)))
+	}
+
+	overrides := make(map[string]PipelineVariable, len(variables))
+	for _, variable := range variables {
+		overrides[variable.Key] = variable
+	}
+
+	runVariables := make([]client.PipelineVariable, 0, len(original.Variables)+len(variables))
+	for _, variable := range original.Variables {
+		if override, ok := overrides[variable.Key]; ok {
+			runVariables = append(runVariables, client.PipelineVariable{Type: "pipeline_variable", Key: override.Key, Value: override.Value, Secured: override.Secured})
+			delete(overrides, variable.Key)
+		} else if !variable.Secured {
+			runVariables = append(runVariables, client.PipelineVariable{Type: "pipeline_variable", Key: variable.Key, Value: variable.Value})
+		}
+	}
+	for _, variable := range variables {
+		if _, ok := overrides[variable.Key]; ok {
+			runVariables = append(runVariables, client.PipelineVariable{Type: "pipeline_variable", Key: variable.Key, Value: variable.Value, Secured: variable.Secured})
+		}
+	}
+
+	run, err := s.client.TriggerPipeline(ctx, namespace, repoSlug, &client.TriggerPipelineRequest{
+		Target:    original.Target,
+		Variables: runVariables,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return MapPipeline(run), nil
+}
+
+// WaitForPipelineOptions configures waiting for a pipeline to complete.
+type WaitForPipelineOptions struct {
+	Timeout  time.Duration // How long to wait; defaults to pipelinePollTimeout
+	Progress ProgressFunc  // Reports the state of the pipeline and its steps on every poll; may be nil
+}
+
+// Backoff of polling the state of a pipeline: the delay starts at pipelinePollInitialDelay
+// and doubles up to pipelinePollMaxDelay until the timeout elapses.
+var (
+	pipelinePollInitialDelay = time.Second
+	pipelinePollMaxDelay     = 30 * time.Second
+	pipelinePollTimeout      = 10 * time.Minute
+)
+
+// WaitForPipeline polls the state of a pipeline with backoff until it completes
+// or the timeout elapses, reporting the state of its steps to options.Progress.
+// The summary includes the log tails of failed steps, as in GetPipeline.
+//
+// Parameters:
+//   - ctx: Context for the request; canceling it stops waiting
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - pipeline: The pipeline UUID or build number
+//   - options: The timeout and progress reporting
+//
+// Returns the PipelineSummary, which is not completed if the timeout elapsed,
+// or an error if a request fails or the context is canceled.
+func (s *Service) WaitForPipeline(ctx context.Context, namespace string, repoSlug string, pipeline string, options WaitForPipelineOptions) (*PipelineSummary, error) {
+	timeout := options.Timeout
+	if timeout <= 0 {
+		timeout = pipelinePollTimeout
+	}
+	start := time.Now()
+	pollCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ref := pipelineRef(pipeline)
+	delay := pipelinePollInitialDelay
+	for attempt := 1; ; attempt++ {
+		current, err := s.client.GetPipeline(pollCtx, namespace, repoSlug, ref)
+		if pollCtx.Err() != nil {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		ref = current.UUID
+		if current.State.Name == "COMPLETED" {
+			break
+		}
+
+		if options.Progress != nil {
+			steps, err := fetchAll(func(page int) (*client.ApiResponse[client.PipelineStep], error) {
+				return s.client.ListPipelineSteps(pollCtx, namespace, repoSlug, current.UUID, 100, page)
+			})
+			if pollCtx.Err() != nil {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			options.Progress(float64(attempt), 0, pipelineProgressMessage(MapPipeline(current), MapList(steps, MapPipelineStep)))
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-pollCtx.Done():
+			timer.Stop()
+		case <-timer.C:
+		}
+		if pollCtx.Err() != nil {
+			break
+		}
+		delay = min(delay*2, pipelinePollMaxDelay)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	details, err := s.GetPipeline(ctx, namespace, repoSlug, ref)
+	if err != nil {
+		return nil, err
+	}
+	return &PipelineSummary{
+		Completed:     details.Pipeline.State == "COMPLETED",
+		Passed:        details.Pipeline.Result == "SUCCESSFUL",
+		WaitedSeconds: int(time.Since(start).Seconds()),
+		Pipeline:      details.Pipeline,
+		Steps:         details.Steps.Items,
+	}, nil
+}
+
+// pipelineProgressMessage describes the state of a pipeline and each of its steps,
+// e.g. "Pipeline #42 is IN_PROGRESS: Build SUCCESSFUL, Test RUNNING".
+func pipelineProgressMessage(pipeline *Pipeline, steps []PipelineStep) string {
+	state := pipeline.State
+	if pipeline.Stage != "" {
+		state = pipeline.Stage
+	}
+
+	statuses := make([]string, len(steps))
+	for i, step := range steps {
+		status := step.State
+		if step.Result != "" {
+			status = step.Result
+		}
+		statuses[i] = step.Name + " " + status
+	}
+
+	message := fmt.Sprintf("Pipeline #%d is %s", pipeline.BuildNumber, state)
+	if len(statuses) > 0 {
+		message += ": " + strings.Join(statuses, ", ")
+	}
+	return message
+}
+
+// ReportBuildStatusOptions describes a build status reported for a commit.
+type ReportBuildStatusOptions struct {
+	Key         string // Identifies the build; a status with the same key is overwritten
+	State       string // One of SUCCESSFUL, FAILED, INPROGRESS, or STOPPED
+	Name        string // Name of the build shown in Bitbucket; defaults to the key
+	URL         string // Link to the build results
+	Description string // Description of the build result
+	RefName     string // Branch or tag the build ran for
+}
+
+// Build states accepted when reporting a build status.
+const (
+	BuildStateSuccessful = "SUCCESSFUL"
+	BuildStateFailed     = "FAILED"
+	BuildStateInProgress = "INPROGRESS"
+	BuildStateStopped    = "STOPPED"
+)
+
+// ReportBuildStatus creates or updates the build status with the given key for a commit.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - commit: The commit hash
+//   - options: The build status to report
+//
+// Returns the reported CommitStatus, or an error if the request fails.
+func (s *Service) ReportBuildStatus(ctx context.Context, namespace string, repoSlug string, commit string, options ReportBuildStatusOptions) (*CommitStatus, error) {
+	status, err := s.client.CreateBuildStatus(ctx, namespace, repoSlug, commit, &client.CreateBuildStatusRequest{
+		Key:         options.Key,
+		State:       options.State,
+		Name:        options.Name,
+		URL:         options.URL,
+		Description: options.Description,
+		RefName:     options.RefName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return MapCommitStatus(status), nil
+}
+
+// maxAnnotationBatch limits the number of annotations created by a single request.
+const maxAnnotationBatch = 100
+
+// PublishReport creates or updates a Code Insights report for a commit together with its annotations.
+// Annotations are created in batches; annotations with IDs already present in the report are updated.
+// Repeated annotation IDs are made unique, since Bitbucket rejects a batch with duplicate IDs
+// after the report has already been created.
+// Reports are limited to 1000 annotations, and longer texts are truncated.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - commit: The commit hash
+//   - report: The report to publish with its annotations
+//
+// Returns the published Report with the created annotations, an InvalidParamsError if the report ID is blank,
+// or an error if any request fails.
+func (s *Service) PublishReport(ctx context.Context, namespace string, repoSlug string, commit string, report Report) (*Report, error) {
+	if strings.TrimSpace(report.ID) == "" {
+		return nil, util.NewInvalidParamsError("report.id: must not be blank")
+	}
+
+	body := &client.CreateReportRequest{
+		Title:      report.Title,
+		Details:    truncateText(report.Details, maxReportDetails),
+		ReportType: report.Type,
+		Reporter:   report.Reporter,
+		Link:       report.Link,
+		Result:     report.Result,
+	}
+	for _, data := range report.Data {
+		body.Data = append(body.Data, client.ReportData{Title: data.Title, Type: data.Type, Value: data.Value})
+	}
+
+	created, err := s.client.CreateOrUpdateReport(ctx, namespace, repoSlug, commit, report.ID, body)
+	if err != nil {
+		return nil, err
+	}
+	result := MapReport(created)
+
+	annotations := report.Annotations
+	if len(annotations) > maxReportAnnotations {
+		annotations = annotations[:maxReportAnnotations]
+	}
+	annotations = uniqueAnnotationIDs(annotations)
+	for batch := range slices.Chunk(annotations, maxAnnotationBatch) {
+		requests := make([]client.CreateAnnotationRequest, 0, len(batch))
+		for _, annotation := range batch {
+			requests = append(requests, client.CreateAnnotationRequest{
+				ExternalID:     annotation.ID,
+				AnnotationType: annotation.Type,
+				Path:           annotation.Path,
+				Line:           annotation.Line,
+				Summary:        truncateText(annotation.Summary, maxAnnotationSummary),
+				Details:        truncateText(annotation.Details, maxAnnotationDetails),
+				Severity:       annotation.Severity,
+				Result:         annotation.Result,
+				Link:           annotation.Link,
+			})
+		}
+		created, err := s.client.CreateAnnotations(ctx, namespace, repoSlug, commit, report.ID, requests)
+		if err != nil {
+			return nil, err
+		}
+		result.Annotations = append(result.Annotations, MapList(created, MapAnnotation)...)
+	}
+	return result, nil
+}
+
+// uniqueAnnotationIDs suffixes repeated annotation IDs with their occurrence, e.g. "rule-1-2",
+// skipping suffixed IDs that are already taken.
+//
+// Returns a copy of the annotations if any ID is changed, or the annotations otherwise.
+func uniqueAnnotationIDs(annotations []Annotation) []Annotation {
+	taken := make(map[string]bool, len(annotations))
+	for _, annotation := range annotations {
+		taken[annotation.ID] = true
+	}
+
+	seen := make(map[string]int, len(annotations))
+	var unique []Annotation
+	for i, annotation := range annotations {
+		if seen[annotation.ID]++; seen[annotation.ID] == 1 {
+			continue
+		}
+		if unique == nil {
+			unique = slices.Clone(annotations)
+		}
+		id := annotation.ID
+		for n := seen[annotation.ID]; taken[id]; n++ {
+			id = fmt.Sprintf("%s-%d", annotation.ID, n)
+		}
+		taken[id] = true
+		unique[i].ID = id
+	}
+	if unique == nil {
+		return annotations
+	}
+	return unique
+}
+
+// GetPullRequestReports retrieves the Code Insights reports of the source commit of a pull request.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - pullRequestId: The pull request ID
+//   - includeAnnotations: Whether to fetch the annotations of every report
+//
+// Returns the PullRequestReports, or an error if any request fails.
+func (s *Service) GetPullRequestReports(ctx context.Context, namespace string, repoSlug string, pullRequestId int, includeAnnotations bool) (*PullRequestReports, error) {
+	pr, err := s.client.GetPullRequest(ctx, namespace, repoSlug, pullRequestId)
+	if err != nil {
+		return nil, err
+	}
+
+	commit := pr.Source.Commit.Hash
+	namespace, repoSlug = sourceRepository(namespace, repoSlug, pr)
+	reports, err := fetchAll(func(page int) (*client.ApiResponse[client.Report], error) {
+		return s.client.ListReports(ctx, namespace, repoSlug, commit, 100, page)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PullRequestReports{
+		Commit:  commit,
+		Reports: MapList(reports, MapReport),
+	}
+	if !includeAnnotations {
+		return result, nil
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	for i := range result.Reports {
+		report := &result.Reports[i]
+		g.Go(func() error {
+			annotations, err := fetchAll(func(page int) (*client.ApiResponse[client.Annotation], error) {
+				return s.client.ListAnnotations(ctx, namespace, repoSlug, commit, report.ID, 100, page)
+			})
+			if err != nil {
+				return err
+			}
+			report.Annotations = MapList(annotations, MapAnnotation)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Issue states, kinds, and priorities of the issue tracker.
+const (
+	IssueStateNew       = "new"
+	IssueStateOpen      = "open"
+	IssueStateResolved  = "resolved"
+	IssueStateOnHold    = "on hold"
+	IssueStateInvalid   = "invalid"
+	IssueStateDuplicate = "duplicate"
+	IssueStateWontfix   = "wontfix"
+	IssueStateClosed    = "closed"
+
+	IssueKindBug         = "bug"
+	IssueKindEnhancement = "enhancement"
+	IssueKindProposal    = "proposal"
+	IssueKindTask        = "task"
+
+	IssuePriorityTrivial  = "trivial"
+	IssuePriorityMinor    = "minor"
+	IssuePriorityMajor    = "major"
+	IssuePriorityCritical = "critical"
+	IssuePriorityBlocker  = "blocker"
+)
+
+// ListIssuesOptions configures filtering, sorting, and paging of the issue listing.
+type ListIssuesOptions struct {
+	States     []string // Issue states to include (e.g., "new", "open"); empty includes all
+	Kinds      []string // Issue kinds to include (e.g., "bug", "task"); empty includes all
+	Priorities []string // Issue priorities to include (e.g., "major", "critical"); empty includes all
+	Assignee   string   // Assignee nickname, account ID, or UUID
+	Query      string   // Additional raw BBQL expression combined with the other filters
+	Sort       string   // Field to sort by, prefixed with "-" for descending order
+	Page       int      // The page number (1-based)
+	Size       int      // The number of items per page
+}
+
+// ListIssues retrieves a paginated list of the issues of a repository's issue tracker.
+// The state, kind, priority, and assignee filters are translated into a BBQL query
+// combined with the raw query from the options.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - options: Filtering, sorting, and paging configuration
+//
+// Returns a Page containing Issue items, or an error if the request fails.
+func (s *Service) ListIssues(ctx context.Context, namespace string, repoSlug string, options ListIssuesOptions) (*Page[Issue], error) {
+	query := bbql.New().WhereRaw(options.Query).Sort(options.Sort).
+		Where(bbql.In("state", options.States...)).
+		Where(bbql.In("kind", options.Kinds...)).
+		Where(bbql.In("priority", options.Priorities...))
+	if options.Assignee != "" {
+		query.Where(userCondition("assignee", options.Assignee))
+	}
+
+	resp, err := s.client.ListIssues(ctx, namespace, repoSlug, options.Size, options.Page, query)
+	if err != nil {
+		return nil, err
+	}
+	return MapPage(resp, MapIssue), nil
+}
+
+// GetIssue retrieves an issue of a repository's issue tracker,
+// optionally with all of its comments, which are fetched in parallel.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - issueId: The issue ID
+//   - includeComments: Whether to fetch the comments of the issue
+//
+// Returns the IssueDetails, or an error if any request fails.
+func (s *Service) GetIssue(ctx context.Context, namespace string, repoSlug string, issueId int, includeComments bool) (*IssueDetails, error) {
+	var issue *client.Issue
+	var comments []client.IssueComment
+
+	g, ctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		var err error
+		issue, err = s.client.GetIssue(ctx, namespace, repoSlug, issueId)
+		return err
+	})
+
+	if includeComments {
+		g.Go(func() error {
+			var err error
+			comments, err = fetchAll(func(page int) (*client.ApiResponse[client.IssueComment], error) {
+				return s.client.ListIssueComments(ctx, namespace, repoSlug, issueId, 100, page)
+			})
+			return err
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	details := &IssueDetails{Issue: MapIssue(issue)}
+	if includeComments {
+		details.Comments = fullPage(MapList(comments, MapIssueComment))
+	}
+	return details, nil
+}
+
+// CreateIssueOptions configures an issue to create.
+type CreateIssueOptions struct {
+	Title     string // Issue title
+	Content   string // Issue description (Markdown)
+	Kind      string // Issue kind; Bitbucket defaults to "bug"
+	Priority  string // Issue priority; Bitbucket defaults to "major"
+	Assignee  string // Assignee nickname, account ID, or UUID
+	Component string // Name of the component the issue belongs to
+	Milestone string // Name of the milestone the issue is planned for
+	Version   string // Name of the version the issue affects
+}
+
+// CreateIssue creates an issue in a repository's issue tracker.
+// The assignee is resolved among the workspace members by nickname, account ID, or UUID.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - options: The issue to create
+//
+// Returns the created Issue, an InvalidParamsError if the assignee cannot be found,
+// or an error if the request fails.
+func (s *Service) CreateIssue(ctx context.Context, namespace string, repoSlug string, options CreateIssueOptions) (*Issue, error) {
+	body := &client.CreateIssueRequest{
+		Title:    options.Title,
+		Kind:     options.Kind,
+		Priority: options.Priority,
+	}
+	if options.Content != "" {
+		body.Content = &client.CreatePullRequestCommentContent{Raw: options.Content}
+	}
+	if options.Assignee != "" {
+		assignee, err := s.resolveAssignee(ctx, namespace, options.Assignee)
+		if err != nil {
+			return nil, err
+		}
+		body.Assignee = assignee
+	}
+	if options.Component != "" {
+		body.Component = &client.IssueCategory{Name: options.Component}
+	}
+	if options.Milestone != "" {
+		body.Milestone = &client.IssueCategory{Name: options.Milestone}
+	}
+	if options.Version != "" {
+		body.Version = &client.IssueCategory{Name: options.Version}
+	}
+
+	issue, err := s.client.CreateIssue(ctx, namespace, repoSlug, body)
+	if err != nil {
+		return nil, err
+	}
+	return MapIssue(issue), nil
+}
+
+// UpdateIssueOptions configures the changes applied to an issue.
+// Nil fields are left unchanged.
+type UpdateIssueOptions struct {
+	Title    *string // New title
+	Content  *string // New description (Markdown)
+	State    *string // New state, e.g. "resolved"
+	Kind     *string // New kind
+	Priority *string // New priority
+	Assignee *string // New assignee nickname, account ID, or UUID
+}
+
+// UpdateIssue updates an issue of a repository's issue tracker.
+// The assignee is resolved among the workspace members by nickname, account ID, or UUID.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - issueId: The issue ID
+//   - options: The changes to apply
+//
+// Returns the updated Issue, an InvalidParamsError if the assignee cannot be found,
+// or an error if the request fails.
+func (s *Service) UpdateIssue(ctx context.Context, namespace string, repoSlug string, issueId int, options UpdateIssueOptions) (*Issue, error) {
+	body := &client.UpdateIssueRequest{}
+	if options.Title != nil {
+		body.Title = *options.Title
+	}
+	if options.Content != nil {
+		body.Content = &client.CreatePullRequestCommentContent{Raw: *options.Content}
+	}
+	if options.State != nil {
+		body.State = *options.State
+	}
+	if options.Kind != nil {
+		body.Kind = *options.Kind
+	}
+	if options.Priority != nil {
+		body.Priority = *options.Priority
+	}
+	if options.Assignee != nil {
+		assignee, err := s.resolveAssignee(ctx, namespace, *options.Assignee)
+		if err != nil {
+			return nil, err
+		}
+		body.Assignee = assignee
+	}
+
+	issue, err := s.client.UpdateIssue(ctx, namespace, repoSlug, issueId, body)
+	if err != nil {
+		return nil, err
+	}
+	return MapIssue(issue), nil
+}
+
+// resolveAssignee resolves the assignee of an issue among the workspace members.
+//
+// Returns an InvalidParamsError if the assignee cannot be found.
+func (s *Service) resolveAssignee(ctx context.Context, namespace string, id string) (*client.IssueUserRef, error) {
+	users, err := s.resolveUsers(ctx, namespace, "assignee", []string{id}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &client.IssueUserRef{UUID: users[0].UUID}, nil
+}
+
+// CreateIssueComment adds a comment to an issue.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug or username
+//   - repoSlug: The repository name/slug
+//   - issueId: The issue ID
+//   - content: The comment text (Markdown)
+//
+// Returns the created IssueComment, or an error if the request fails.
+func (s *Service) CreateIssueComment(ctx context.Context, namespace string, repoSlug string, issueId int, content string) (*IssueComment, error) {
+	comment, err := s.client.CreateIssueComment(ctx, namespace, repoSlug, issueId, &client.CreateIssueCommentRequest{
+		Content: client.CreatePullRequestCommentContent{Raw: content},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return MapIssueComment(comment), nil
+}
+
+// ListWorkspaces retrieves a paginated list of the workspaces the authenticated user is a member of,
+// including the user's permission in each of them.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - page: The page number (1-based)
+//   - size: The number of items per page
+//
+// Returns a Page containing Workspace items, or an error if the request fails.
+func (s *Service) ListWorkspaces(ctx context.Context, page, size int) (*Page[Workspace], error) {
+	resp, err := s.client.ListWorkspaces(ctx, size, page)
+	if err != nil {
+		return nil, err
+	}
+	return MapPage(resp, MapWorkspaceMembership), nil
+}
+
+// ListProjects retrieves a paginated list of the projects of a workspace.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug
+//   - page: The page number (1-based)
+//   - size: The number of items per page
+//
+// Returns a Page containing Project items, or an error if the request fails.
+func (s *Service) ListProjects(ctx context.Context, namespace string, page, size int) (*Page[Project], error) {
+	resp, err := s.client.ListProjects(ctx, namespace, size, page)
+	if err != nil {
+		return nil, err
+	}
+	return MapPage(resp, MapProject), nil
+}
+
+// GetProject retrieves a project of a workspace together with a page of its repositories.
+// The project and the repositories are fetched in parallel.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug
+//   - projectKey: The project key (e.g., "PROJ")
+//   - page: The page number of the repositories (1-based)
+//   - size: The number of repositories per page
+//
+// Returns the ProjectDetails, or an error if the request fails.
+func (s *Service) GetProject(ctx context.Context, namespace string, projectKey string, page, size int) (*ProjectDetails, error) {
+	g, ctx := errgroup.WithContext(ctx)
+
+	var project *client.Project
+	var repositories *client.ApiResponse[client.Repository]
+
+	g.Go(func() error {
+		var err error
+		project, err = s.client.GetProject(ctx, namespace, projectKey)
+		return err
+	})
+
+	g.Go(func() error {
+		var err error
+		query := bbql.New().Where(bbql.Eq("project.key", projectKey)).Sort("name")
+		repositories, err = s.client.ListRepositories(ctx, namespace, size, page, "", query)
+		return err
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return &ProjectDetails{
+		Project:      MapProject(project),
+		Repositories: MapPage(repositories, MapRepository),
+	}, nil
+}
+
+// ListWorkspaceMembers retrieves a paginated list of the members of a workspace.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug
+//   - page: The page number (1-based)
+//   - size: The number of items per page
+//
+// Returns a Page containing the member Users, or an error if the request fails.
+func (s *Service) ListWorkspaceMembers(ctx context.Context, namespace string, page, size int) (*Page[User], error) {
+	resp, err := s.client.ListWorkspaceMembers(ctx, namespace, size, page)
+	if err != nil {
+		return nil, err
+	}
+	return MapPage(resp, MapWorkspaceMember), nil
+}
+
+// SearchCodeOptions configures the query modifiers and paging of a code search.
+type SearchCodeOptions struct {
+	Query      string // The search terms, optionally with modifiers of the Bitbucket search syntax
+	Repository string // Only files of this repository (slug)
+	Language   string // Only files in this language (e.g., "go")
+	Extension  string // Only files with this extension, without the leading dot
+	Path       string // Only files under this path
+	Page       int    // The page number (1-based); defaults to 1
+	Size       int    // The number of items per page; defaults to 10
+}
+
+// SearchCode searches the code of all repositories of a workspace.
+// The repository, language, extension, and path filters are appended to the query as modifiers.
+// Values of modifiers containing spaces are quoted; values containing quotes are rejected,
+// since the search syntax cannot escape them.
+// Every result carries the matching lines of the file with their highlighted parts and surrounding context.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - namespace: The workspace slug
+//   - options: Query, filters, and paging configuration
+//
+// Returns the CodeSearchResults, an InvalidParamsError if a filter contains a quote,
+// or an error if the request fails.
+func (s *Service) SearchCode(ctx context.Context, namespace string, options SearchCodeOptions) (*CodeSearchResults, error) {
+	if options.Page <= 0 {
+		options.Page = 1
+	}
+	if options.Size <= 0 {
+		options.Size = 10
+	}
+
+	terms := []string{strings.TrimSpace(options.Query)}
+	modifiers := [][3]string{
+		{"repository", "repo", options.Repository},
+		{"language", "lang", options.Language},
+		{"extension", "ext", strings.TrimPrefix(options.Extension, ".")},
+		{"path", "path", options.Path},
+	}
+	for _, modifier := range modifiers {
+		field, name, value := modifier[0], modifier[1], modifier[2]
+		if value == "" {
+			continue
+		}
+		if strings.Contains(value, `"`) {
+			return nil, util.NewInvalidParamsError(field + ": must not contain quotes")
+		}
+		terms = append(terms, searchModifier(name, value))
+	}
+	query := strings.Join(terms, " ")
+
+	resp, err := s.client.SearchCode(ctx, namespace, query, options.Size, options.Page)
+	if err != nil {
+		return nil, err
+	}
+	return &CodeSearchResults{
+		Query:            query,
+		QuerySubstituted: resp.QuerySubstituted,
+		Results:          MapPage(&resp.ApiResponse, MapCodeSearchResult),
+	}, nil
+}
+
+// searchModifier formats a modifier of the Bitbucket search syntax, quoting values containing spaces.
+func searchModifier(name string, value string) string {
+	if strings.ContainsAny(value, " \t") {
+		value = `"` + value + `"`
+	}
+	return name + ":" + value
 }
@@ -41,10 +41,22 @@ type Repository struct {
 }
 
 // Project represents a Bitbucket project containing repositories.
+// Description, visibility, and dates are only known for projects retrieved on their own;
+// projects embedded in repositories report is_private as false.
 type Project struct {
-	Key  string `json:"key"`
-	UUID string `json:"uuid"`
-	Name string `json:"name"`
+	Key         string `json:"key"`
+	UUID        string `json:"uuid"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	IsPrivate   bool   `json:"is_private"`
+	CreatedOn   string `json:"created_on,omitempty"`
+	UpdatedOn   string `json:"updated_on,omitempty"`
+}
+
+// ProjectDetails represents a project with the first page of its repositories.
+type ProjectDetails struct {
+	Project      *Project          `json:"project"`
+	Repositories *Page[Repository] `json:"repositories"`
 }
 
 // OverrideSettings represents repository settings that override workspace defaults.
@@ -68,10 +80,12 @@ type Owner struct {
 }
 
 // Workspace represents a Bitbucket workspace containing projects and repositories.
+// Permission is the authenticated user's permission and is only set when listing their workspaces.
 type Workspace struct {
-	UUID string `json:"uuid"`
-	Name string `json:"name"`
-	Slug string `json:"slug"`
+	UUID       string `json:"uuid"`
+	Name       string `json:"name"`
+	Slug       string `json:"slug"`
+	Permission string `json:"permission,omitempty"`
 }
 
 // SourceFile represents a file from the repository source with its content.
@@ -100,6 +114,7 @@ type PullRequestDetails struct {
 	Commits     *Page[PullRequestCommit]  `json:"commits,omitempty"`
 	Diff        *string                   `json:"diff,omitempty"`
 	Comments    *Page[PullRequestComment] `json:"comments,omitempty"`
+	Tasks       *Page[PullRequestTask]    `json:"tasks,omitempty"`
 }
 
 // PullRequest represents a Bitbucket pull request with simplified fields for domain use.
@@ -123,6 +138,56 @@ type PullRequest struct {
 	Destination       *PullRequestBranch `json:"destination"`
 	Reviewers         []User             `json:"reviewers,omitempty"`
 	Participants      []Participant      `json:"participants,omitempty"`
+	BuildStatus       *BuildStatus       `json:"build_status,omitempty"`
+}
+
+// BuildStatus aggregates the build statuses reported for the source commit of a pull request.
+// State is FAILED if any build failed or was stopped, INPROGRESS if any build is still running,
+// and SUCCESSFUL otherwise.
+type BuildStatus struct {
+	State      string         `json:"state"`
+	Successful int            `json:"successful"`
+	Failed     int            `json:"failed"`
+	InProgress int            `json:"in_progress"`
+	Stopped    int            `json:"stopped"`
+	Builds     []CommitStatus `json:"builds"`
+}
+
+// CommitStatus represents a build status reported for a commit by a CI system.
+type CommitStatus struct {
+	Key         string  `json:"key"`
+	Name        string  `json:"name"`
+	State       string  `json:"state"`
+	URL         string  `json:"url"`
+	Description string  `json:"description,omitempty"`
+	RefName     *string `json:"refname,omitempty"`
+	CreatedOn   string  `json:"created_on"`
+	UpdatedOn   string  `json:"updated_on"`
+}
+
+// UserPullRequests represents the pull requests of a user across repositories,
+// split into the ones the user authored and the ones the user is asked to review.
+type UserPullRequests struct {
+	User      *User                  `json:"user"`
+	Authored  *Page[UserPullRequest] `json:"authored,omitempty"`
+	Reviewing *Page[UserPullRequest] `json:"reviewing,omitempty"`
+}
+
+// UserPullRequest represents a pull request with its review status summarized for a user.
+// Approved and ReviewState describe the user's own review; they are empty for authored pull requests.
+type UserPullRequest struct {
+	PullRequest
+	Approvals        int     `json:"approvals"`              // Number of participants who approved
+	ChangesRequested int     `json:"changes_requested"`      // Number of participants who requested changes
+	Approved         bool    `json:"approved"`               // Whether the user approved the pull request
+	ReviewState      *string `json:"review_state,omitempty"` // The user's participant state (e.g., "approved", "changes_requested")
+}
+
+// PullRequestReview represents the review state of a pull request after a review action.
+type PullRequestReview struct {
+	ID           int           `json:"id"`
+	State        string        `json:"state"`
+	Participants []Participant `json:"participants"`
 }
 
 // PullRequestBranch represents a source or destination branch in a pull request.
@@ -167,20 +232,359 @@ type PullRequestCommit struct {
 }
 
 // PullRequestComment represents a comment on a pull request.
+// Top-level comments start a thread and hold the nested replies.
 type PullRequestComment struct {
+	ID         int                  `json:"id"`
+	CreatedOn  string               `json:"created_on"`
+	UpdatedOn  string               `json:"updated_on"`
+	Content    string               `json:"content"`
+	User       *User                `json:"user"`
+	Deleted    bool                 `json:"deleted"`
+	Pending    bool                 `json:"pending"`
+	Inline     *Inline              `json:"inline,omitempty"`
+	Parent     *int                 `json:"parent,omitempty"`
+	Resolved   bool                 `json:"resolved"`
+	Resolution *Resolution          `json:"resolution,omitempty"`
+	Replies    []PullRequestComment `json:"replies,omitempty"`
+}
+
+// PullRequestTask represents a task on a pull request, optionally attached to a comment.
+type PullRequestTask struct {
+	ID         int     `json:"id"`
+	State      string  `json:"state"`
+	Content    string  `json:"content"`
+	Creator    *User   `json:"creator"`
+	CreatedOn  string  `json:"created_on"`
+	UpdatedOn  string  `json:"updated_on"`
+	Pending    bool    `json:"pending"`
+	ResolvedOn *string `json:"resolved_on,omitempty"`
+	ResolvedBy *User   `json:"resolved_by,omitempty"`
+	Comment    *int    `json:"comment,omitempty"`
+}
+
+// Pull request activity types of PullRequestActivity.
+const (
+	ActivityUpdate           = "update"            // The pull request state, details, or source commit changed
+	ActivityApproval         = "approval"          // A participant approved the pull request
+	ActivityChangesRequested = "changes_requested" // A participant requested changes
+	ActivityComment          = "comment"           // A participant commented
+)
+
+// PullRequestActivity represents an event in the history of a pull request.
+// Update is set for update events and Comment for comment events.
+type PullRequestActivity struct {
+	Type    string              `json:"type"`
+	Date    string              `json:"date"`
+	User    *User               `json:"user"`
+	Update  *PullRequestUpdate  `json:"update,omitempty"`
+	Comment *PullRequestComment `json:"comment,omitempty"`
+}
+
+// PullRequestUpdate represents the pull request as of an update event,
+// with the names of the fields changed by the update.
+type PullRequestUpdate struct {
+	State       string             `json:"state"`
+	Title       string             `json:"title"`
+	Draft       bool               `json:"draft"`
+	Reason      string             `json:"reason,omitempty"`
+	Source      *PullRequestBranch `json:"source"`
+	Destination *PullRequestBranch `json:"destination"`
+	Changes     []string           `json:"changes,omitempty"`
+}
+
+// DefaultReviewer represents a user added as a reviewer to new pull requests of a repository.
+// ReviewerType tells whether the user is configured on the repository or inherited from its project.
+type DefaultReviewer struct {
+	User         *User  `json:"user"`
+	ReviewerType string `json:"reviewer_type"`
+}
+
+// Inline represents inline comment anchor information (file path and line range).
+// From refers to a line in the old version of the file, To to a line in the new one.
+type Inline struct {
+	Path      string `json:"path"`
+	To        *int   `json:"to,omitempty"`
+	From      *int   `json:"from,omitempty"`
+	StartTo   *int   `json:"start_to,omitempty"`
+	StartFrom *int   `json:"start_from,omitempty"`
+}
+
+// Resolution represents who resolved a comment thread and when.
+type Resolution struct {
+	User      *User   `json:"user,omitempty"`
+	CreatedOn *string `json:"created_on,omitempty"`
+}
+
+// MergeCheck represents a condition evaluated before merging a pull request.
+// Enforced checks block the merge when they fail; the others are advisory.
+type MergeCheck struct {
+	Name     string `json:"name"`
+	Passed   bool   `json:"passed"`
+	Enforced bool   `json:"enforced"`
+	Message  string `json:"message"`
+}
+
+// MergeStatus represents whether a pull request can be merged into its destination branch,
+// along with the merge strategies allowed on that branch and the evaluated checks.
+// Conflicts lists the paths of the files in conflict, and Warnings the checks that could not be evaluated.
+type MergeStatus struct {
+	ID                   int          `json:"id"`
+	State                string       `json:"state"`
+	Mergeable            bool         `json:"mergeable"`
+	Destination          string       `json:"destination"`
+	MergeStrategies      []string     `json:"merge_strategies"`
+	DefaultMergeStrategy string       `json:"default_merge_strategy,omitempty"`
+	Checks               []MergeCheck `json:"checks"`
+	Conflicts            []string     `json:"conflicts,omitempty"`
+	Warnings             []string     `json:"warnings,omitempty"`
+}
+
+// MergeResult represents the outcome of a merge attempt.
+// When the pull request cannot be merged, Reasons explains why and Status holds the evaluated checks.
+type MergeResult struct {
+	Merged      bool         `json:"merged"`
+	Reasons     []string     `json:"reasons,omitempty"`
+	PullRequest *PullRequest `json:"pull_request,omitempty"`
+	Status      *MergeStatus `json:"status,omitempty"`
+}
+
+// Commit represents a commit of a repository.
+// AuthorRaw holds the author as recorded in the commit, which is the only author
+// information when the commit author is not linked to a Bitbucket account.
+type Commit struct {
+	Hash      string   `json:"hash"`
+	Date      string   `json:"date"`
+	Author    *User    `json:"author,omitempty"`
+	AuthorRaw string   `json:"author_raw"`
+	Message   string   `json:"message"`
+	Parents   []string `json:"parents"`
+}
+
+// CommitDetails represents a commit with its optional diff and changed files.
+// DiffTruncated is set when the diff was cut to fit the size limit.
+type CommitDetails struct {
+	Commit        *Commit           `json:"commit"`
+	Diff          *string           `json:"diff,omitempty"`
+	DiffTruncated bool              `json:"diff_truncated,omitempty"`
+	DiffStat      *Page[FileChange] `json:"diffstat,omitempty"`
+}
+
+// Ref represents a branch or a tag of a repository pointing to a target commit.
+// Message is the annotation of an annotated tag.
+type Ref struct {
+	Type    string  `json:"type"`
+	Name    string  `json:"name"`
+	Target  *Commit `json:"target"`
+	Message string  `json:"message,omitempty"`
+}
+
+// Comparison represents the changes between two revisions of a repository
+// with the optional changed files. DiffTruncated is set when the diff was cut to fit the size limit.
+type Comparison struct {
+	From          string            `json:"from"`
+	To            string            `json:"to"`
+	Path          string            `json:"path,omitempty"`
+	Diff          *string           `json:"diff"`
+	DiffTruncated bool              `json:"diff_truncated,omitempty"`
+	DiffStat      *Page[FileChange] `json:"diffstat,omitempty"`
+}
+
+// FileChange represents a file changed by a commit or between two revisions.
+// OldPath is set when the file was renamed.
+type FileChange struct {
+	Status       string `json:"status"`
+	Path         string `json:"path"`
+	OldPath      string `json:"old_path,omitempty"`
+	LinesAdded   int    `json:"lines_added"`
+	LinesRemoved int    `json:"lines_removed"`
+}
+
+// Pipeline represents a Bitbucket Pipelines run of a repository.
+// Result is set once the pipeline is completed (e.g. SUCCESSFUL, FAILED, STOPPED),
+// and Stage while it is in progress (e.g. RUNNING, PAUSED).
+type Pipeline struct {
+	UUID            string `json:"uuid"`
+	BuildNumber     int    `json:"build_number"`
+	State           string `json:"state"`
+	Result          string `json:"result,omitempty"`
+	Stage           string `json:"stage,omitempty"`
+	RefType         string `json:"ref_type,omitempty"`
+	RefName         string `json:"ref_name,omitempty"`
+	Commit          string `json:"commit,omitempty"`
+	Selector        string `json:"selector,omitempty"`
+	Trigger         string `json:"trigger"`
+	Creator         *User  `json:"creator,omitempty"`
+	CreatedOn       string `json:"created_on"`
+	CompletedOn     string `json:"completed_on,omitempty"`
+	DurationSeconds int    `json:"duration_seconds"`
+}
+
+// PipelineStep represents a step of a pipeline with the commands of its script.
+// LogTail holds the end of the log of a failed step.
+type PipelineStep struct {
+	UUID            string   `json:"uuid"`
+	Name            string   `json:"name"`
+	State           string   `json:"state"`
+	Result          string   `json:"result,omitempty"`
+	StartedOn       string   `json:"started_on,omitempty"`
+	CompletedOn     string   `json:"completed_on,omitempty"`
+	DurationSeconds int      `json:"duration_seconds"`
+	Image           string   `json:"image,omitempty"`
+	Commands        []string `json:"commands,omitempty"`
+	LogTail         *string  `json:"log_tail,omitempty"`
+}
+
+// PipelineDetails represents a pipeline with its steps.
+type PipelineDetails struct {
+	Pipeline *Pipeline           `json:"pipeline"`
+	Steps    *Page[PipelineStep] `json:"steps"`
+}
+
+// PipelineSummary represents the outcome of waiting for a pipeline to complete.
+// Completed is false if the wait timed out; Passed is true only if the pipeline completed successfully.
+type PipelineSummary struct {
+	Completed     bool           `json:"completed"`
+	Passed        bool           `json:"passed"`
+	WaitedSeconds int            `json:"waited_seconds"`
+	Pipeline      *Pipeline      `json:"pipeline"`
+	Steps         []PipelineStep `json:"steps"`
+}
+
+// Report represents a Code Insights report of a commit, e.g. the results of a static analysis.
+// Annotations are included only when requested.
+type Report struct {
+	ID          string       `json:"id"`
+	Title       string       `json:"title"`
+	Details     string       `json:"details"`
+	Type        string       `json:"type"`
+	Reporter    string       `json:"reporter,omitempty"`
+	Link        string       `json:"link,omitempty"`
+	Result      string       `json:"result,omitempty"`
+	Data        []ReportData `json:"data,omitempty"`
+	CreatedOn   string       `json:"created_on,omitempty"`
+	UpdatedOn   string       `json:"updated_on,omitempty"`
+	Annotations []Annotation `json:"annotations,omitempty"`
+}
+
+// ReportData represents a value shown in the summary of a report, e.g. the number of issues found.
+type ReportData struct {
+	Title string `json:"title"`
+	Type  string `json:"type"`
+	Value any    `json:"value"`
+}
+
+// Annotation represents a finding of a Code Insights report, optionally anchored to a file line.
+type Annotation struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Path     string `json:"path,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Summary  string `json:"summary"`
+	Details  string `json:"details,omitempty"`
+	Severity string `json:"severity,omitempty"`
+	Result   string `json:"result,omitempty"`
+	Link     string `json:"link,omitempty"`
+}
+
+// PullRequestReports represents the Code Insights reports of the source commit of a pull request.
+type PullRequestReports struct {
+	Commit  string   `json:"commit"`
+	Reports []Report `json:"reports"`
+}
+
+// PipelineVariable represents a variable passed to a pipeline run.
+// Values of secured variables are not returned by Bitbucket.
+type PipelineVariable struct {
+	Key     string `json:"key"`
+	Value   string `json:"value,omitempty"`
+	Secured bool   `json:"secured,omitempty"`
+}
+
+// PipelineStepLog represents a byte range of the log of a pipeline step.
+// Size is the total size of the log in bytes, or 0 if unknown.
+type PipelineStepLog struct {
+	Offset  int64  `json:"offset"`
+	Length  int64  `json:"length"`
+	Size    int64  `json:"size"`
+	Content string `json:"content"`
+}
+
+// IssueDetails represents an issue with its optional comments.
+type IssueDetails struct {
+	Issue    *Issue              `json:"issue"`
+	Comments *Page[IssueComment] `json:"comments,omitempty"`
+}
+
+// Issue represents an issue of a repository's issue tracker.
+type Issue struct {
 	ID        int     `json:"id"`
+	Title     string  `json:"title"`
+	Content   string  `json:"content"`
+	State     string  `json:"state"`
+	Kind      string  `json:"kind"`
+	Priority  string  `json:"priority"`
+	Reporter  *User   `json:"reporter,omitempty"`
+	Assignee  *User   `json:"assignee,omitempty"`
+	Component *string `json:"component,omitempty"`
+	Milestone *string `json:"milestone,omitempty"`
+	Version   *string `json:"version,omitempty"`
+	Votes     int     `json:"votes"`
 	CreatedOn string  `json:"created_on"`
 	UpdatedOn string  `json:"updated_on"`
+	Link      string  `json:"link,omitempty"`
+}
+
+// IssueComment represents a comment on an issue.
+type IssueComment struct {
+	ID        int     `json:"id"`
 	Content   string  `json:"content"`
-	User      *User   `json:"user"`
-	Deleted   bool    `json:"deleted"`
-	Pending   bool    `json:"pending"`
-	Inline    *Inline `json:"inline,omitempty"`
+	User      *User   `json:"user,omitempty"`
+	CreatedOn string  `json:"created_on"`
+	UpdatedOn *string `json:"updated_on,omitempty"`
 }
 
-// Inline represents inline comment information (file path and line number).
-type Inline struct {
-	Path string `json:"path"`
-	To   *int   `json:"to,omitempty"`
-	From *int   `json:"from,omitempty"`
+// CurrentUser represents the authenticated user with their email addresses.
+// Emails are empty if the credentials are not allowed to read them.
+type CurrentUser struct {
+	User
+	Emails []UserEmail `json:"emails"`
+}
+
+// UserEmail represents an email address of the authenticated user.
+type UserEmail struct {
+	Email     string `json:"email"`
+	Primary   bool   `json:"primary"`
+	Confirmed bool   `json:"confirmed"`
+}
+
+// CodeSearchResults represents a page of files matching a code search query.
+type CodeSearchResults struct {
+	Query            string                  `json:"query"`
+	QuerySubstituted bool                    `json:"query_substituted,omitempty"` // Bitbucket rewrote the query, e.g. to fix a syntax error
+	Results          *Page[CodeSearchResult] `json:"results"`
+}
+
+// CodeSearchResult represents a file matching a code search query.
+// Matches group the matching lines of the file with the lines around them as context.
+type CodeSearchResult struct {
+	Repository string            `json:"repository"`
+	Path       string            `json:"path"`
+	Commit     string            `json:"commit,omitempty"`
+	PathMatch  bool              `json:"path_match,omitempty"` // The query matched the file path
+	MatchCount int               `json:"match_count"`
+	Matches    []CodeSearchMatch `json:"matches"`
+}
+
+// CodeSearchMatch represents a block of consecutive lines containing content matches.
+type CodeSearchMatch struct {
+	Lines []CodeSearchLine `json:"lines"`
+}
+
+// CodeSearchLine represents a line of a content match.
+// Lines without highlights are context around the matching lines.
+type CodeSearchLine struct {
+	Line       int      `json:"line"`
+	Text       string   `json:"text"`
+	Match      bool     `json:"match"`
+	Highlights []string `json:"highlights,omitempty"` // Parts of the line matching the query
 }
@@ -1,6 +1,6 @@
 // Package mcp provides the MCP (Model Context Protocol) server implementation for Bitbucket.
 //
-// This package sets up the MCP server with resource templates and handlers
+// This package sets up the MCP server with resource templates, tools, and handlers
 // for interacting with Bitbucket repositories through the MCP protocol.
 package mcp
 
@@ -10,6 +10,7 @@ import (
 	"github.com/branow/mcp-bitbucket/internal/auth"
 	"github.com/branow/mcp-bitbucket/internal/bitbucket/service"
 	"github.com/branow/mcp-bitbucket/internal/mcp/templates"
+	"github.com/branow/mcp-bitbucket/internal/mcp/tools"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -19,7 +20,7 @@ type Dispatcher[T any] interface {
 }
 
 // NewHandler creates a new HTTP handler for the MCP server.
-// It initializes the MCP server with Bitbucket integration and resource templates.
+// It initializes the MCP server with Bitbucket integration, resource templates, and tools.
 //
 // Parameters:
 //   - bitbucket: The Bitbucket service for making API requests
@@ -32,6 +33,7 @@ func NewHandler(bitbucket *service.Service, authorize auth.Middleware) http.Hand
 	}, nil)
 
 	templates.NewResourceTemplateDispatcher(bitbucket).Dispatch(server)
+	tools.NewToolDispatcher(bitbucket).Dispatch(server)
 
 	mcpHandler := mcp.NewStreamableHTTPHandler(func(r *http.Request) *mcp.Server {
 		return server
@@ -0,0 +1,210 @@
+// Package markdown renders Bitbucket domain types into Markdown documents.
+//
+// Markdown is an alternative resource representation that is easier for models
+// to read than raw JSON. The layouts are defined as Go text templates embedded
+// into the binary.
+package markdown
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+
+	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+)
+
+//go:embed tmpl/*.md.tmpl
+var files embed.FS
+
+var templates = template.Must(template.New("markdown").Funcs(template.FuncMap{
+	"cell":     cell,
+	"fence":    fence,
+	"deref":    deref,
+	"derefInt": derefInt,
+	"yesno":    yesno,
+	"short":    short,
+	"indent":   indent,
+	"trim":     trim,
+	"threads":  threads,
+	"snippet":  snippet,
+}).ParseFS(files, "tmpl/*.md.tmpl"))
+
+// RenderRepositoryDetails renders repository details, including the optional
+// source listing and README content, as a Markdown document.
+//
+// Returns an error if the template execution fails.
+func RenderRepositoryDetails(details *bitbucket.RepositoryDetails) (string, error) {
+	return render("repository.md.tmpl", details)
+}
+
+// RenderPullRequestDetails renders pull request details, including the optional
+// commits, diff, and comments, as a Markdown document.
+//
+// Returns an error if the template execution fails.
+func RenderPullRequestDetails(details *bitbucket.PullRequestDetails) (string, error) {
+	return render("pull_request.md.tmpl", details)
+}
+
+// RenderIssueDetails renders issue details, including the optional comments,
+// as a Markdown document.
+//
+// Returns an error if the template execution fails.
+func RenderIssueDetails(details *bitbucket.IssueDetails) (string, error) {
+	return render("issue.md.tmpl", details)
+}
+
+// RenderComparison renders the changes between two revisions, including the
+// optional changed files and the diff, as a Markdown document.
+//
+// Returns an error if the template execution fails.
+func RenderComparison(comparison *bitbucket.Comparison) (string, error) {
+	return render("comparison.md.tmpl", comparison)
+}
+
+// RenderCodeSearch renders the results of a code search, including the matching
+// lines of every file with their line numbers, as a Markdown document.
+//
+// Returns an error if the template execution fails.
+func RenderCodeSearch(results *bitbucket.CodeSearchResults) (string, error) {
+	return render("code_search.md.tmpl", results)
+}
+
+func render(name string, data any) (string, error) {
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", name, err)
+	}
+	return strings.TrimSpace(buf.String()) + "\n", nil
+}
+
+// cell escapes a value so that it can be safely placed into a Markdown table cell.
+func cell(value string) string {
+	value = strings.ReplaceAll(strings.TrimSpace(value), "|", `\|`)
+	value = strings.ReplaceAll(value, "\r\n", " ")
+	return strings.ReplaceAll(value, "\n", " ")
+}
+
+// fence returns a code fence that is longer than any backtick sequence in the content,
+// so the content cannot terminate the code block prematurely.
+func fence(content string) string {
+	longest, current := 0, 0
+	for _, r := range content {
+		if r == '`' {
+			current++
+			longest = max(longest, current)
+		} else {
+			current = 0
+		}
+	}
+	return strings.Repeat("`", max(3, longest+1))
+}
+
+// trim removes trailing line breaks so that block content ends right before its closing fence.
+func trim(content string) string {
+	return strings.TrimRight(content, "\r\n")
+}
+
+// deref safely dereferences a string pointer, returning an empty string if nil.
+func deref(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// derefInt safely dereferences an integer pointer, returning an empty string if nil.
+func derefInt(value *int) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(*value)
+}
+
+// yesno converts a boolean into a human-readable "yes" or "no".
+func yesno(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
+
+// short truncates a commit hash to its conventional 12-character form.
+func short(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
+
+// indent prefixes every line after the first one with the given number of spaces,
+// keeping multi-line text inside a Markdown list item.
+func indent(spaces int, text string) string {
+	return strings.ReplaceAll(strings.TrimSpace(text), "\n", "\n"+strings.Repeat(" ", spaces))
+}
+
+// threads renders comment threads as a nested Markdown list, where replies are
+// indented under their parent comment and top-level comments show their resolution status.
+func threads(comments []bitbucket.PullRequestComment) string {
+	var b strings.Builder
+	writeComments(&b, comments, 0)
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func writeComments(b *strings.Builder, comments []bitbucket.PullRequestComment, depth int) {
+	pad := strings.Repeat("  ", depth)
+	for _, comment := range comments {
+		b.WriteString(pad + "- **")
+		if comment.User != nil {
+			b.WriteString(comment.User.DisplayName)
+		}
+		fmt.Fprintf(b, "** (%s)", comment.CreatedOn)
+		if inline := comment.Inline; inline != nil {
+			fmt.Fprintf(b, " on `%s%s`", inline.Path, anchor(inline))
+		}
+		if depth == 0 {
+			if comment.Resolved {
+				b.WriteString(" [resolved]")
+			} else {
+				b.WriteString(" [unresolved]")
+			}
+		}
+		if comment.Deleted {
+			b.WriteString(" _deleted_")
+		}
+		b.WriteString("\n" + pad + "  " + indent(len(pad)+2, comment.Content) + "\n")
+		writeComments(b, comment.Replies, depth+1)
+	}
+}
+
+// anchor formats the line an inline comment is attached to, preferring the new file version.
+func anchor(inline *bitbucket.Inline) string {
+	switch {
+	case inline.To != nil:
+		return fmt.Sprintf(":%d", *inline.To)
+	case inline.From != nil:
+		return fmt.Sprintf(":%d", *inline.From)
+	default:
+		return ""
+	}
+}
+
+// snippet renders the lines of a code search match with right-aligned line numbers,
+// marking the lines that match the query with ">" to set them apart from the context.
+func snippet(lines []bitbucket.CodeSearchLine) string {
+	width := 0
+	for _, line := range lines {
+		width = max(width, len(fmt.Sprint(line.Line)))
+	}
+
+	var b strings.Builder
+	for _, line := range lines {
+		marker := " "
+		if line.Match {
+			marker = ">"
+		}
+		b.WriteString(strings.TrimRight(fmt.Sprintf("%s %*d | %s", marker, width, line.Line, line.Text), " ") + "\n")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
@@ -0,0 +1,377 @@
+package markdown_test
+
+import (
+	"testing"
+
+	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/branow/mcp-bitbucket/internal/mcp/markdown"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderRepositoryDetails(t *testing.T) {
+	size := 42
+	readme := "# Hello\n\nWorld"
+
+	tests := []struct {
+		name     string
+		details  *bitbucket.RepositoryDetails
+		contains []string
+		excludes []string
+	}{
+		{
+			name: "repository only",
+			details: &bitbucket.RepositoryDetails{
+				Repository: &bitbucket.Repository{
+					FullName:    "workspace/repo",
+					Name:        "repo",
+					Description: "A | piped description",
+					MainBranch:  "main",
+					IsPrivate:   true,
+				},
+			},
+			contains: []string{"# workspace/repo", "| Main branch | `main` |", "| Private | yes |"},
+			excludes: []string{"## Source", "README"},
+		},
+		{
+			name: "with source and readme",
+			details: &bitbucket.RepositoryDetails{
+				Repository: &bitbucket.Repository{FullName: "workspace/repo"},
+				Source: &bitbucket.Page[bitbucket.SourceItem]{
+					Items: []bitbucket.SourceItem{
+						{Path: "src", Type: "commit_directory"},
+						{Path: "main.go", Type: "commit_file", Size: &size},
+					},
+				},
+				Readme: &bitbucket.SourceFile{Path: "README.md", Content: &readme},
+			},
+			contains: []string{"## Source", "| `src` | directory |  |", "| `main.go` | file | 42 |", "## README.md", "# Hello\n\nWorld"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := markdown.RenderRepositoryDetails(tt.details)
+			require.NoError(t, err)
+			for _, s := range tt.contains {
+				assert.Contains(t, actual, s)
+			}
+			for _, s := range tt.excludes {
+				assert.NotContains(t, actual, s)
+			}
+		})
+	}
+}
+
+func TestRenderPullRequestDetails(t *testing.T) {
+	approved := "approved"
+	line := 13
+	diff := "diff --git a/a.md b/a.md\n+```go\n+```\n"
+
+	tests := []struct {
+		name     string
+		details  *bitbucket.PullRequestDetails
+		contains []string
+		excludes []string
+	}{
+		{
+			name: "pull request only",
+			details: &bitbucket.PullRequestDetails{
+				PullRequest: &bitbucket.PullRequest{
+					ID:          7,
+					Title:       "Fix bug",
+					State:       "OPEN",
+					Draft:       true,
+					Author:      &bitbucket.User{DisplayName: "Author"},
+					Source:      &bitbucket.PullRequestBranch{Name: "fix", Hash: "0123456789abcdef"},
+					Destination: &bitbucket.PullRequestBranch{Name: "main", Hash: "fedcba9876543210"},
+					Participants: []bitbucket.Participant{
+						{User: &bitbucket.User{DisplayName: "Reviewer"}, Role: "REVIEWER", Approved: true, State: &approved},
+					},
+				},
+			},
+			contains: []string{
+				"# #7 Fix bug",
+				"| State | OPEN (draft) |",
+				"| Source | `fix` (0123456789ab) |",
+				"| Reviewer | REVIEWER | yes | approved |",
+			},
+			excludes: []string{"## Commits", "## Diff", "## Comments", "## Builds"},
+		},
+		{
+			name: "with diff containing code fences",
+			details: &bitbucket.PullRequestDetails{
+				PullRequest: &bitbucket.PullRequest{ID: 1, Source: &bitbucket.PullRequestBranch{}, Destination: &bitbucket.PullRequestBranch{}},
+				Diff:        &diff,
+			},
+			contains: []string{"<details>", "````diff\ndiff --git a/a.md b/a.md\n+```go\n+```\n````", "</details>"},
+		},
+		{
+			name: "with commits and comments",
+			details: &bitbucket.PullRequestDetails{
+				PullRequest: &bitbucket.PullRequest{ID: 1, Source: &bitbucket.PullRequestBranch{}, Destination: &bitbucket.PullRequestBranch{}},
+				Commits: &bitbucket.Page[bitbucket.PullRequestCommit]{
+					Items: []bitbucket.PullRequestCommit{
+						{Hash: "0123456789abcdef", Author: &bitbucket.User{DisplayName: "Dev"}, Message: "feat: add | pipe\n"},
+					},
+				},
+				Comments: &bitbucket.Page[bitbucket.PullRequestComment]{
+					Items: []bitbucket.PullRequestComment{
+						{
+							User:    &bitbucket.User{DisplayName: "Reviewer"},
+							Content: "Looks good\nThanks",
+							Inline:  &bitbucket.Inline{Path: "main.go", To: &line},
+							Replies: []bitbucket.PullRequestComment{
+								{User: &bitbucket.User{DisplayName: "Dev"}, Content: "Done", Replies: []bitbucket.PullRequestComment{
+									{User: &bitbucket.User{DisplayName: "Reviewer"}, Content: "Removed", Deleted: true},
+								}},
+							},
+						},
+						{User: &bitbucket.User{DisplayName: "Dev"}, Content: "Ready", Resolved: true},
+					},
+				},
+			},
+			contains: []string{
+				"| 0123456789ab | Dev |  | feat: add \\| pipe |",
+				"- **Reviewer** () on `main.go:13` [unresolved]\n  Looks good\n  Thanks\n" +
+					"  - **Dev** ()\n    Done\n" +
+					"    - **Reviewer** () _deleted_\n      Removed\n" +
+					"- **Dev** () [resolved]\n  Ready",
+			},
+		},
+		{
+			name: "with tasks",
+			details: &bitbucket.PullRequestDetails{
+				PullRequest: &bitbucket.PullRequest{ID: 1, Source: &bitbucket.PullRequestBranch{}, Destination: &bitbucket.PullRequestBranch{}},
+				Tasks: &bitbucket.Page[bitbucket.PullRequestTask]{
+					Items: []bitbucket.PullRequestTask{
+						{ID: 1, State: "RESOLVED", Content: "Add tests"},
+						{ID: 2, State: "UNRESOLVED", Content: "Update docs", Comment: &line},
+					},
+				},
+			},
+			contains: []string{"## Tasks\n\n- [x] Add tests\n- [ ] Update docs (comment #13)"},
+		},
+		{
+			name: "with build status",
+			details: &bitbucket.PullRequestDetails{
+				PullRequest: &bitbucket.PullRequest{
+					ID:          1,
+					Source:      &bitbucket.PullRequestBranch{},
+					Destination: &bitbucket.PullRequestBranch{},
+					BuildStatus: &bitbucket.BuildStatus{
+						State:      "FAILED",
+						Successful: 1,
+						Failed:     1,
+						Builds: []bitbucket.CommitStatus{
+							{Name: "CI build #42", State: "SUCCESSFUL", URL: "https://ci.example.com/builds/42", Description: "All tests passed"},
+							{Name: "Lint", State: "FAILED", URL: "https://ci.example.com/lint/7"},
+						},
+					},
+				},
+			},
+			contains: []string{
+				"| Builds | FAILED (1 successful, 1 failed, 0 in progress, 0 stopped) |",
+				"| [CI build #42](https://ci.example.com/builds/42) | SUCCESSFUL | All tests passed |",
+				"| [Lint](https://ci.example.com/lint/7) | FAILED |  |",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := markdown.RenderPullRequestDetails(tt.details)
+			require.NoError(t, err)
+			for _, s := range tt.contains {
+				assert.Contains(t, actual, s)
+			}
+			for _, s := range tt.excludes {
+				assert.NotContains(t, actual, s)
+			}
+		})
+	}
+}
+
+func TestRenderIssueDetails(t *testing.T) {
+	milestone := "v1.0"
+
+	tests := []struct {
+		name     string
+		details  *bitbucket.IssueDetails
+		contains []string
+		excludes []string
+	}{
+		{
+			name: "issue only",
+			details: &bitbucket.IssueDetails{
+				Issue: &bitbucket.Issue{
+					ID:        3,
+					Title:     "Login fails",
+					Content:   "Steps to reproduce",
+					State:     "open",
+					Kind:      "bug",
+					Priority:  "critical",
+					Reporter:  &bitbucket.User{DisplayName: "Reporter"},
+					Milestone: &milestone,
+				},
+			},
+			contains: []string{
+				"# #3 Login fails",
+				"| Kind | bug |",
+				"| Priority | critical |",
+				"| Reporter | Reporter |",
+				"| Milestone | v1.0 |",
+				"## Description\n\nSteps to reproduce",
+			},
+			excludes: []string{"| Assignee |", "| Component |", "## Comments"},
+		},
+		{
+			name: "with comments",
+			details: &bitbucket.IssueDetails{
+				Issue: &bitbucket.Issue{ID: 3},
+				Comments: &bitbucket.Page[bitbucket.IssueComment]{
+					Items: []bitbucket.IssueComment{
+						{User: &bitbucket.User{DisplayName: "Dev"}, Content: "Reproduced\nOn Chrome", CreatedOn: "2024-01-15"},
+						{User: &bitbucket.User{DisplayName: "Reporter"}, Content: "Thanks", CreatedOn: "2024-01-16"},
+					},
+				},
+			},
+			contains: []string{"## Comments\n\n- **Dev** (2024-01-15)\n  Reproduced\n  On Chrome\n- **Reporter** (2024-01-16)\n  Thanks"},
+			excludes: []string{"## Description"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := markdown.RenderIssueDetails(tt.details)
+			require.NoError(t, err)
+			for _, s := range tt.contains {
+				assert.Contains(t, actual, s)
+			}
+			for _, s := range tt.excludes {
+				assert.NotContains(t, actual, s)
+			}
+		})
+	}
+}
+
+func TestRenderComparison(t *testing.T) {
+	diff := "diff --git a/src/a.go b/src/a.go\n+package a\n"
+	empty := ""
+
+	tests := []struct {
+		name       string
+		comparison *bitbucket.Comparison
+		contains   []string
+		excludes   []string
+	}{
+		{
+			name:       "no changes",
+			comparison: &bitbucket.Comparison{From: "release-1.2", To: "main", Diff: &empty},
+			contains:   []string{"# Comparison `release-1.2`..`main`", "_No changes._"},
+			excludes:   []string{"## Changed files", "## Diff"},
+		},
+		{
+			name: "with changed files and truncated diff",
+			comparison: &bitbucket.Comparison{
+				From:          "release-1.2",
+				To:            "main",
+				Path:          "src",
+				Diff:          &diff,
+				DiffTruncated: true,
+				DiffStat: &bitbucket.Page[bitbucket.FileChange]{
+					Items: []bitbucket.FileChange{
+						{Status: "modified", Path: "src/a.go", LinesAdded: 1},
+						{Status: "renamed", Path: "src/c.go", OldPath: "src/b.go"},
+					},
+				},
+			},
+			contains: []string{
+				"Limited to `src`.",
+				"| modified | `src/a.go` | 1 | 0 |",
+				"| renamed | `src/c.go` (from `src/b.go`) | 0 | 0 |",
+				"## Diff\n\n_The diff is truncated._\n\n<details>",
+				"```diff\ndiff --git a/src/a.go b/src/a.go\n+package a\n```",
+			},
+			excludes: []string{"_No changes._"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := markdown.RenderComparison(tt.comparison)
+			require.NoError(t, err)
+			for _, s := range tt.contains {
+				assert.Contains(t, actual, s)
+			}
+			for _, s := range tt.excludes {
+				assert.NotContains(t, actual, s)
+			}
+		})
+	}
+}
+
+func TestRenderCodeSearch(t *testing.T) {
+	tests := []struct {
+		name     string
+		results  *bitbucket.CodeSearchResults
+		contains []string
+		excludes []string
+	}{
+		{
+			name: "no matches",
+			results: &bitbucket.CodeSearchResults{
+				Query:   "missing",
+				Results: &bitbucket.Page[bitbucket.CodeSearchResult]{Page: 1, Items: []bitbucket.CodeSearchResult{}},
+			},
+			contains: []string{"# Code search `missing`", "0 files found, page 1.", "_No matches._"},
+			excludes: []string{"## ", "adjusted the query"},
+		},
+		{
+			name: "with matches",
+			results: &bitbucket.CodeSearchResults{
+				Query:            "NewClient lang:go",
+				QuerySubstituted: true,
+				Results: &bitbucket.Page[bitbucket.CodeSearchResult]{
+					Size: 1,
+					Page: 1,
+					Items: []bitbucket.CodeSearchResult{
+						{
+							Repository: "test-workspace/test-repo",
+							Path:       "client.go",
+							Commit:     "a1b2c3d4e5f6a7b8c9d0",
+							PathMatch:  true,
+							MatchCount: 1,
+							Matches: []bitbucket.CodeSearchMatch{
+								{Lines: []bitbucket.CodeSearchLine{
+									{Line: 9},
+									{Line: 10, Text: "func NewClient() {}", Match: true, Highlights: []string{"NewClient"}},
+								}},
+							},
+						},
+					},
+				},
+			},
+			contains: []string{
+				"_Bitbucket adjusted the query to run the search._",
+				"## test-workspace/test-repo: `client.go`",
+				"Commit `a1b2c3d4e5f6`, 1 matches, path matches.",
+				"```\n   9 |\n> 10 | func NewClient() {}\n```",
+			},
+			excludes: []string{"_No matches._"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := markdown.RenderCodeSearch(tt.results)
+			require.NoError(t, err)
+			for _, s := range tt.contains {
+				assert.Contains(t, actual, s)
+			}
+			for _, s := range tt.excludes {
+				assert.NotContains(t, actual, s)
+			}
+		})
+	}
+}
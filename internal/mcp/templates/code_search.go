@@ -0,0 +1,112 @@
+package templates
+
+import (
+	"context"
+	"strings"
+
+	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/branow/mcp-bitbucket/internal/mcp/markdown"
+	"github.com/branow/mcp-bitbucket/internal/util"
+	sch "github.com/branow/mcp-bitbucket/internal/util/schema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// CodeSearchProvider implements the ResourceTemplateProvider interface
+// for searching the code of all repositories in a Bitbucket workspace.
+type CodeSearchProvider struct {
+	bitbucket *bitbucket.Service
+	template  string
+	uriParser *util.UriTemplateParser
+}
+
+// NewCodeSearchProvider creates a new provider for searching code.
+// The provider supports the URI template:
+// mcp://bitbucket/{namespace}/search/code?q={q}&repository={repository}&language={language}&extension={extension}&path={path}&page={page}&size={size}&format={format}
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured CodeSearchProvider.
+func NewCodeSearchProvider(bitbucket *bitbucket.Service) *CodeSearchProvider {
+	template := "mcp://bitbucket/{namespace}/search/code{?q,repository,language,extension,path,page,size,format}"
+	parser, err := util.NewUriTemplateParser(template)
+	if err != nil {
+		panic(err)
+	}
+
+	return &CodeSearchProvider{
+		bitbucket: bitbucket,
+		template:  template,
+		uriParser: parser,
+	}
+}
+
+// GetDefinition returns the MCP resource template definition for searching code.
+// The template includes URI pattern, title, and description; the MIME type of each content depends on the requested format.
+func (p *CodeSearchProvider) GetDefinition() *mcp.ResourceTemplate {
+	return &mcp.ResourceTemplate{
+		Name:        "codeSearch",
+		URITemplate: p.template,
+		Title:       "Search Code",
+		Description: "Searches the code of all repositories in a workspace of the configured Bitbucket account, on the default branch of every repository. The query (q) accepts the Bitbucket search syntax, e.g. exact phrases in quotes and the NOT operator, and can be narrowed to a repository (repository), a language (language=go), a file extension (extension=yaml), and a path (path=src/main). Returns the matching files with their repository, path, and commit, and the matching lines with their highlighted parts and the lines around them. Supports paging (page, size up to 50). The output format can be JSON (format=json, default), Markdown (format=markdown), or both (format=both). Code search must be enabled for the workspace.",
+	}
+}
+
+// Handler processes read resource requests for searching code.
+// It parses and validates the URI parameters, calls the Bitbucket service,
+// and returns the matching files in the requested format.
+//
+// URI Parameters:
+//   - namespace: The workspace slug (required, must not be blank)
+//   - q: The search query (required, must not be blank)
+//   - repository: The repository slug to search in (optional)
+//   - language: The language of the files to search in (optional)
+//   - extension: The extension of the files to search in (optional)
+//   - path: The path of the files to search in (optional)
+//   - page: The page number (optional, defaults to 1, must be positive)
+//   - size: The number of items per page (optional, defaults to 10, must be between 1 and 50)
+//   - format: Output format - json, markdown, or both (optional, defaults to json)
+//
+// Returns:
+//   - ReadResourceResult containing the search results as JSON and/or Markdown
+//   - InvalidParamsError if URI parsing or validation fails, or Bitbucket rejects the query
+//   - ResourceNotFoundError if the workspace doesn't exist
+//   - InternalError if internal logic fails
+func (p *CodeSearchProvider) Handler(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	params, err := p.uriParser.Parse(req.Params.URI)
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	namespace, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["namespace"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	query, err := sch.String().Must(sch.NotBlank()).Parse(params.Query["q"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError("q: " + err.Error())
+	}
+
+	page := sch.Int().Must(sch.Positive()).Optional(1).Parse(params.Query["page"])
+	size := sch.Int().Must(sch.Between(1, 50)).Optional(10).Parse(params.Query["size"])
+	format, err := ParseFormat(params.Query["format"])
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := p.bitbucket.SearchCode(ctx, namespace, bitbucket.SearchCodeOptions{
+		Query:      query,
+		Repository: strings.TrimSpace(params.Query["repository"]),
+		Language:   strings.TrimSpace(params.Query["language"]),
+		Extension:  strings.TrimSpace(params.Query["extension"]),
+		Path:       strings.TrimSpace(params.Query["path"]),
+		Page:       page,
+		Size:       size,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResourceResult(req.Params.URI, format, res, markdown.RenderCodeSearch)
+}
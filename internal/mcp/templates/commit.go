@@ -0,0 +1,101 @@
+package templates
+
+import (
+	"context"
+
+	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/branow/mcp-bitbucket/internal/util"
+	sch "github.com/branow/mcp-bitbucket/internal/util/schema"
+	"github.com/branow/mcp-bitbucket/internal/util/web"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// CommitProvider implements the ResourceTemplateProvider interface
+// for retrieving a single commit of a Bitbucket repository with its optional diff and changed files.
+type CommitProvider struct {
+	bitbucket *bitbucket.Service
+	template  string
+	uriParser *util.UriTemplateParser
+}
+
+// NewCommitProvider creates a new provider for retrieving a single commit.
+// The provider supports the URI template:
+// mcp://bitbucket/{namespace}/repositories/{repository}/commits/{hash}?diff={diff}&diffstat={diffstat}
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured CommitProvider.
+func NewCommitProvider(bitbucket *bitbucket.Service) *CommitProvider {
+	template := "mcp://bitbucket/{namespace}/repositories/{repository}/commits/{hash}{?diff,diffstat}"
+	parser, err := util.NewUriTemplateParser(template)
+	if err != nil {
+		panic(err)
+	}
+
+	return &CommitProvider{
+		bitbucket: bitbucket,
+		template:  template,
+		uriParser: parser,
+	}
+}
+
+// GetDefinition returns the MCP resource template definition for retrieving a commit.
+// The template includes URI pattern, title, description, and MIME type.
+func (p *CommitProvider) GetDefinition() *mcp.ResourceTemplate {
+	return &mcp.ResourceTemplate{
+		Name:        "commit",
+		URITemplate: p.template,
+		Title:       "Commit",
+		Description: "Retrieves a commit of a repository by its full or abbreviated hash, with the date, author, message, and parent hashes. Optionally includes the unified diff against the first parent (diff=true; truncated if very large, as indicated by diff_truncated) and the changed files with the number of added and removed lines (diffstat=true).",
+		MIMEType:    string(web.MimeApplicationJson),
+	}
+}
+
+// Handler processes read resource requests for retrieving a single commit.
+// It parses and validates the URI parameters, calls the Bitbucket service,
+// and returns the commit details as JSON.
+//
+// URI Parameters:
+//   - namespace: The workspace slug or username (required, must not be blank)
+//   - repository: The repository name/slug (required, must not be blank)
+//   - hash: The commit hash, full or abbreviated (required, must not be blank)
+//   - diff: Include the diff (optional, defaults to false)
+//   - diffstat: Include the changed files (optional, defaults to false)
+//
+// Returns:
+//   - ReadResourceResult containing the commit details as JSON
+//   - InvalidParamsError if URI parsing or validation fails
+//   - ResourceNotFoundError if the commit doesn't exist
+//   - InternalError if internal logic fails
+func (p *CommitProvider) Handler(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	params, err := p.uriParser.Parse(req.Params.URI)
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	namespace, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["namespace"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	repository, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["repository"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	hash, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["hash"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	res, err := p.bitbucket.GetCommit(ctx, namespace, repository, hash, bitbucket.GetCommitOptions{
+		IncludeDiff:     sch.Bool().Optional(false).Parse(params.Query["diff"]),
+		IncludeDiffStat: sch.Bool().Optional(false).Parse(params.Query["diffstat"]),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewJsonResourceResult(req.Params.URI, res)
+}
@@ -0,0 +1,106 @@
+package templates
+
+import (
+	"context"
+	"strings"
+
+	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/branow/mcp-bitbucket/internal/util"
+	sch "github.com/branow/mcp-bitbucket/internal/util/schema"
+	"github.com/branow/mcp-bitbucket/internal/util/web"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// commitsPageSize is the number of commits listed per page.
+const commitsPageSize = 50
+
+// ListCommitsProvider implements the ResourceTemplateProvider interface
+// for listing the commit history of a Bitbucket repository.
+type ListCommitsProvider struct {
+	bitbucket *bitbucket.Service
+	template  string
+	uriParser *util.UriTemplateParser
+}
+
+// NewCommitsProvider creates a new provider for listing commits.
+// The provider supports the URI template:
+// mcp://bitbucket/{namespace}/repositories/{repository}/commits?include={include}&exclude={exclude}&path={path}&page={page}
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured ListCommitsProvider.
+func NewCommitsProvider(bitbucket *bitbucket.Service) *ListCommitsProvider {
+	template := "mcp://bitbucket/{namespace}/repositories/{repository}/commits{?include,exclude,path,page}"
+	parser, err := util.NewUriTemplateParser(template)
+	if err != nil {
+		panic(err)
+	}
+
+	return &ListCommitsProvider{
+		bitbucket: bitbucket,
+		template:  template,
+		uriParser: parser,
+	}
+}
+
+// GetDefinition returns the MCP resource template definition for listing commits.
+// The template includes URI pattern, title, description, and MIME type.
+func (p *ListCommitsProvider) GetDefinition() *mcp.ResourceTemplate {
+	return &mcp.ResourceTemplate{
+		Name:        "commits",
+		URITemplate: p.template,
+		Title:       "List Commits",
+		Description: "Retrieves the commit history of a repository, newest first, with the hash, date, author, message, and parent hashes of each commit. Lists the history of a branch, tag, or commit with include=ref (defaults to all branches), leaves out the history of another ref with exclude=ref (e.g. include=feature&exclude=main for the commits of a feature branch), and can be limited to the commits changing a file or directory (path=src/app). Paged with page, 50 commits per page.",
+		MIMEType:    string(web.MimeApplicationJson),
+	}
+}
+
+// Handler processes read resource requests for listing commits.
+// It parses and validates the URI parameters, calls the Bitbucket service,
+// and returns the commits as JSON.
+//
+// URI Parameters:
+//   - namespace: The workspace slug or username (required, must not be blank)
+//   - repository: The repository name/slug (required, must not be blank)
+//   - include: Branch, tag, or commit hash whose history to list (optional, defaults to all branches)
+//   - exclude: Branch, tag, or commit hash whose history to leave out (optional)
+//   - path: File or directory path whose changes to list (optional)
+//   - page: The page number (optional, defaults to 1, must be positive)
+//
+// Returns:
+//   - ReadResourceResult containing the list of commits as JSON
+//   - InvalidParamsError if URI parsing or validation fails
+//   - ResourceNotFoundError if the repository or a ref doesn't exist
+//   - InternalError if internal logic fails
+func (p *ListCommitsProvider) Handler(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	params, err := p.uriParser.Parse(req.Params.URI)
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	namespace, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["namespace"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	repository, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["repository"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	page := sch.Int().Must(sch.Positive()).Optional(1).Parse(params.Query["page"])
+
+	res, err := p.bitbucket.ListCommits(ctx, namespace, repository, bitbucket.ListCommitsOptions{
+		Include: strings.TrimSpace(params.Query["include"]),
+		Exclude: strings.TrimSpace(params.Query["exclude"]),
+		Path:    strings.TrimSpace(params.Query["path"]),
+		Page:    page,
+		Size:    commitsPageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewJsonResourceResult(req.Params.URI, res)
+}
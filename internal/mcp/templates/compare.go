@@ -0,0 +1,112 @@
+package templates
+
+import (
+	"context"
+
+	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/branow/mcp-bitbucket/internal/mcp/markdown"
+	"github.com/branow/mcp-bitbucket/internal/util"
+	sch "github.com/branow/mcp-bitbucket/internal/util/schema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// CompareProvider implements the ResourceTemplateProvider interface
+// for comparing two revisions (branches, tags, or commits) of a Bitbucket repository.
+type CompareProvider struct {
+	bitbucket *bitbucket.Service
+	template  string
+	uriParser *util.UriTemplateParser
+}
+
+// NewCompareProvider creates a new provider for comparing two revisions.
+// The provider supports the URI template:
+// mcp://bitbucket/{namespace}/repositories/{repository}/compare/{from}..{to}?path={path}&diffstat={diffstat}&format={format}
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured CompareProvider.
+func NewCompareProvider(bitbucket *bitbucket.Service) *CompareProvider {
+	template := "mcp://bitbucket/{namespace}/repositories/{repository}/compare/{from}..{to}{?path,diffstat,format}"
+	parser, err := util.NewUriTemplateParser(template)
+	if err != nil {
+		panic(err)
+	}
+
+	return &CompareProvider{
+		bitbucket: bitbucket,
+		template:  template,
+		uriParser: parser,
+	}
+}
+
+// GetDefinition returns the MCP resource template definition for comparing two revisions.
+// The template includes URI pattern, title, and description; the MIME type of each content depends on the requested format.
+func (p *CompareProvider) GetDefinition() *mcp.ResourceTemplate {
+	return &mcp.ResourceTemplate{
+		Name:        "compare",
+		URITemplate: p.template,
+		Title:       "Compare Revisions",
+		Description: "Retrieves the unified diff of what the 'to' revision changes since it diverged from the 'from' revision, where both can be a branch, tag, or commit hash (e.g. compare/release-1.2..main). Branch names containing a slash must be URL-encoded (release%2F1.2). The diff is truncated if very large, as indicated by diff_truncated. Optionally limits the comparison to a file or directory (path=src/app) and includes the changed files with the number of added and removed lines (diffstat=true). The output format can be JSON (format=json, default), Markdown (format=markdown), or both (format=both).",
+	}
+}
+
+// Handler processes read resource requests for comparing two revisions.
+// It parses and validates the URI parameters, calls the Bitbucket service,
+// and returns the comparison in the requested format.
+//
+// URI Parameters:
+//   - namespace: The workspace slug or username (required, must not be blank)
+//   - repository: The repository name/slug (required, must not be blank)
+//   - from: The base branch, tag, or commit hash (required, must not be blank)
+//   - to: The branch, tag, or commit hash to compare with the base (required, must not be blank)
+//   - path: File or directory path to limit the comparison to (optional)
+//   - diffstat: Include the changed files (optional, defaults to false)
+//   - format: Output format - json, markdown, or both (optional, defaults to json)
+//
+// Returns:
+//   - ReadResourceResult containing the comparison in the requested format
+//   - InvalidParamsError if URI parsing or validation fails
+//   - ResourceNotFoundError if the repository or a revision doesn't exist
+//   - InternalError if internal logic fails
+func (p *CompareProvider) Handler(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	params, err := p.uriParser.Parse(req.Params.URI)
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	namespace, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["namespace"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	repository, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["repository"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	from, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["from"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	to, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["to"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	format, err := ParseFormat(params.Query["format"])
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := p.bitbucket.Compare(ctx, namespace, repository, from, to, bitbucket.CompareOptions{
+		Path:            params.Query["path"],
+		IncludeDiffStat: sch.Bool().Optional(false).Parse(params.Query["diffstat"]),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResourceResult(req.Params.URI, format, res, markdown.RenderComparison)
+}
@@ -0,0 +1,90 @@
+package templates
+
+import (
+	"context"
+
+	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/branow/mcp-bitbucket/internal/util"
+	sch "github.com/branow/mcp-bitbucket/internal/util/schema"
+	"github.com/branow/mcp-bitbucket/internal/util/web"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// DefaultReviewersProvider implements the ResourceTemplateProvider interface
+// for listing the effective default reviewers of a Bitbucket repository.
+type DefaultReviewersProvider struct {
+	bitbucket *bitbucket.Service
+	template  string
+	uriParser *util.UriTemplateParser
+}
+
+// NewDefaultReviewersProvider creates a new provider for listing default reviewers.
+// The provider supports the URI template:
+// mcp://bitbucket/{namespace}/repositories/{repository}/default-reviewers
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured DefaultReviewersProvider.
+func NewDefaultReviewersProvider(bitbucket *bitbucket.Service) *DefaultReviewersProvider {
+	template := "mcp://bitbucket/{namespace}/repositories/{repository}/default-reviewers"
+	parser, err := util.NewUriTemplateParser(template)
+	if err != nil {
+		panic(err)
+	}
+
+	return &DefaultReviewersProvider{
+		bitbucket: bitbucket,
+		template:  template,
+		uriParser: parser,
+	}
+}
+
+// GetDefinition returns the MCP resource template definition for default reviewers.
+// The template includes URI pattern, title, description, and MIME type.
+func (p *DefaultReviewersProvider) GetDefinition() *mcp.ResourceTemplate {
+	return &mcp.ResourceTemplate{
+		Name:        "defaultReviewers",
+		URITemplate: p.template,
+		Title:       "Default Reviewers",
+		Description: "Retrieves the effective default reviewers of a repository: the users configured on the repository and the ones inherited from its project (reviewer_type=repository or project). These reviewers are added automatically to pull requests created with the create_pull_request tool.",
+		MIMEType:    string(web.MimeApplicationJson),
+	}
+}
+
+// Handler processes read resource requests for default reviewers.
+// It parses and validates the URI parameters, calls the Bitbucket service,
+// and returns the default reviewers as JSON.
+//
+// URI Parameters:
+//   - namespace: The workspace slug or username (required, must not be blank)
+//   - repository: The repository name/slug (required, must not be blank)
+//
+// Returns:
+//   - ReadResourceResult containing the default reviewers as JSON
+//   - InvalidParamsError if URI parsing or validation fails
+//   - ResourceNotFoundError if the repository doesn't exist
+//   - InternalError if internal logic fails
+func (p *DefaultReviewersProvider) Handler(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	params, err := p.uriParser.Parse(req.Params.URI)
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	namespace, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["namespace"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	repository, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["repository"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	res, err := p.bitbucket.ListDefaultReviewers(ctx, namespace, repository)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewJsonResourceResult(req.Params.URI, res)
+}
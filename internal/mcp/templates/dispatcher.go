@@ -25,7 +25,7 @@ type ResourceTemplateDispatcher[T ResourceTemplateProvider] struct {
 }
 
 // NewResourceTemplateDispatcher creates a new dispatcher with all available resource template providers.
-// Currently includes repositories, repository, and pull request providers.
+// Currently includes current user, workspaces, projects, project, workspace members, repositories, repository, default reviewers, pull requests, pull request, pull request activity, pull request merge check, pull request reports, current user pull requests, commits, commit, compare, refs, pipelines, pipeline, pipeline step log, issues, issue, and code search providers.
 //
 // Parameters:
 //   - bitbucket: The Bitbucket service used by resource providers
@@ -34,9 +34,30 @@ type ResourceTemplateDispatcher[T ResourceTemplateProvider] struct {
 func NewResourceTemplateDispatcher(bitbucket *bitbucket.Service) *ResourceTemplateDispatcher[ResourceTemplateProvider] {
 	return &ResourceTemplateDispatcher[ResourceTemplateProvider]{
 		providers: []ResourceTemplateProvider{
+			NewCurrentUserProvider(bitbucket),
+			NewWorkspacesProvider(bitbucket),
+			NewProjectsProvider(bitbucket),
+			NewProjectProvider(bitbucket),
+			NewMembersProvider(bitbucket),
 			NewRepositoriesProvider(bitbucket),
 			NewRepositoryProvider(bitbucket),
+			NewDefaultReviewersProvider(bitbucket),
+			NewPullRequestsProvider(bitbucket),
 			NewPullRequestProvider(bitbucket),
+			NewPullRequestActivityProvider(bitbucket),
+			NewMergeStatusProvider(bitbucket),
+			NewPullRequestReportsProvider(bitbucket),
+			NewMyPullRequestsProvider(bitbucket),
+			NewCommitsProvider(bitbucket),
+			NewCommitProvider(bitbucket),
+			NewCompareProvider(bitbucket),
+			NewRefsProvider(bitbucket),
+			NewPipelinesProvider(bitbucket),
+			NewPipelineProvider(bitbucket),
+			NewPipelineStepLogProvider(bitbucket),
+			NewIssuesProvider(bitbucket),
+			NewIssueProvider(bitbucket),
+			NewCodeSearchProvider(bitbucket),
 		},
 	}
 }
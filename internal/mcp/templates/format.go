@@ -0,0 +1,83 @@
+package templates
+
+import (
+	"encoding/json"
+	"log/slog"
+
+	"github.com/branow/mcp-bitbucket/internal/util"
+	sch "github.com/branow/mcp-bitbucket/internal/util/schema"
+	"github.com/branow/mcp-bitbucket/internal/util/web"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Format is the output format of a resource's contents.
+type Format string
+
+const (
+	FormatJson     Format = "json"     // a single JSON content
+	FormatMarkdown Format = "markdown" // a single Markdown content
+	FormatBoth     Format = "both"     // a JSON content followed by a Markdown content
+)
+
+// ParseFormat parses the format URI parameter.
+// It falls back to FormatJson if the value is missing.
+//
+// Returns an InvalidParamsError if the value is not a supported format.
+func ParseFormat(value string) (Format, error) {
+	if value == "" {
+		return FormatJson, nil
+	}
+	format, err := sch.String().Must(sch.In(string(FormatJson), string(FormatMarkdown), string(FormatBoth))).Parse(value)
+	if err != nil {
+		return "", util.NewInvalidParamsError("format: " + err.Error())
+	}
+	return Format(format), nil
+}
+
+// NewResourceResult builds a read resource result for the given value in the requested format.
+// JSON content is produced by marshaling the value, Markdown content by the provided renderer.
+//
+// Parameters:
+//   - uri: The URI of the resource being read
+//   - format: The requested output format
+//   - value: The value to serialize
+//   - render: Function rendering the value as Markdown
+//
+// Returns an InternalError if serialization or rendering fails.
+func NewResourceResult[T any](uri string, format Format, value *T, render func(*T) (string, error)) (*mcp.ReadResourceResult, error) {
+	contents := []*mcp.ResourceContents{}
+
+	if format == FormatJson || format == FormatBoth {
+		bytes, err := json.Marshal(value)
+		if err != nil {
+			return nil, util.NewInternalError()
+		}
+		contents = append(contents, &mcp.ResourceContents{
+			URI:      uri,
+			MIMEType: string(web.MimeApplicationJson),
+			Text:     string(bytes),
+		})
+	}
+
+	if format == FormatMarkdown || format == FormatBoth {
+		text, err := render(value)
+		if err != nil {
+			slog.Error("Failed to render markdown", util.NewLogArgsExtractor().AddError(err).AddUrl(uri).Extract()...)
+			return nil, util.NewInternalError()
+		}
+		contents = append(contents, &mcp.ResourceContents{
+			URI:      uri,
+			MIMEType: string(web.MimeTextMarkdown),
+			Text:     text,
+		})
+	}
+
+	return &mcp.ReadResourceResult{Contents: contents}, nil
+}
+
+// NewJsonResourceResult builds a read resource result with a single JSON content of the given value.
+//
+// Returns an InternalError if serialization fails.
+func NewJsonResourceResult[T any](uri string, value *T) (*mcp.ReadResourceResult, error) {
+	return NewResourceResult(uri, FormatJson, value, nil)
+}
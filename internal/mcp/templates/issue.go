@@ -0,0 +1,104 @@
+package templates
+
+import (
+	"context"
+	"fmt"
+
+	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/branow/mcp-bitbucket/internal/mcp/markdown"
+	"github.com/branow/mcp-bitbucket/internal/util"
+	sch "github.com/branow/mcp-bitbucket/internal/util/schema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// IssueProvider implements the ResourceTemplateProvider interface
+// for retrieving a single Bitbucket issue with optional comments.
+type IssueProvider struct {
+	bitbucket *bitbucket.Service
+	template  string
+	uriParser *util.UriTemplateParser
+}
+
+// NewIssueProvider creates a new provider for retrieving a single issue.
+// The provider supports the URI template:
+// mcp://bitbucket/{namespace}/repositories/{repository}/issues/{issueId}?comments={comments}&format={format}
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured IssueProvider.
+func NewIssueProvider(bitbucket *bitbucket.Service) *IssueProvider {
+	template := "mcp://bitbucket/{namespace}/repositories/{repository}/issues/{issueId}{?comments,format}"
+	parser, err := util.NewUriTemplateParser(template)
+	if err != nil {
+		panic(err)
+	}
+
+	return &IssueProvider{
+		bitbucket: bitbucket,
+		template:  template,
+		uriParser: parser,
+	}
+}
+
+// GetDefinition returns the MCP resource template definition for retrieving an issue.
+// The template includes URI pattern, title, and description; the MIME type of each content depends on the requested format.
+func (p *IssueProvider) GetDefinition() *mcp.ResourceTemplate {
+	return &mcp.ResourceTemplate{
+		Name:        "issue",
+		URITemplate: p.template,
+		Title:       "Issue",
+		Description: "Retrieves an issue from the issue tracker of a repository in the configured Bitbucket workspace, including its title, description, state, kind, priority, reporter, assignee, component, milestone, and version. Optionally includes the comments (comments=true). The output format can be JSON (format=json, default), Markdown (format=markdown), or both (format=both).",
+	}
+}
+
+// Handler processes read resource requests for retrieving a single issue.
+// It parses and validates the URI parameters, calls the Bitbucket service,
+// and returns the issue details in the requested format.
+//
+// URI Parameters:
+//   - namespace: The workspace slug or username (required, must not be blank)
+//   - repository: The repository name/slug (required, must not be blank)
+//   - issueId: The issue ID (required, must be positive)
+//   - comments: Include comments (optional, defaults to false)
+//   - format: Output format - json, markdown, or both (optional, defaults to json)
+//
+// Returns:
+//   - ReadResourceResult containing the issue details as JSON and/or Markdown
+//   - InvalidParamsError if URI parsing or validation fails
+//   - ResourceNotFoundError if the issue doesn't exist
+//   - InternalError if internal logic fails
+func (p *IssueProvider) Handler(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	params, err := p.uriParser.Parse(req.Params.URI)
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	namespace, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["namespace"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	repository, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["repository"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	issueId, err := sch.Int().Must(sch.Positive()).Parse(params.Path["issueId"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(fmt.Sprintf("issueId: %s", err.Error()))
+	}
+
+	comments := sch.Bool().Optional(false).Parse(params.Query["comments"])
+	format, err := ParseFormat(params.Query["format"])
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := p.bitbucket.GetIssue(ctx, namespace, repository, issueId, comments)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResourceResult(req.Params.URI, format, res, markdown.RenderIssueDetails)
+}
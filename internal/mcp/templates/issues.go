@@ -0,0 +1,158 @@
+package templates
+
+import (
+	"context"
+	"strings"
+
+	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/branow/mcp-bitbucket/internal/util"
+	sch "github.com/branow/mcp-bitbucket/internal/util/schema"
+	"github.com/branow/mcp-bitbucket/internal/util/web"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Issue states, kinds, and priorities accepted by the issue filters.
+var (
+	issueStates = []string{
+		bitbucket.IssueStateNew, bitbucket.IssueStateOpen, bitbucket.IssueStateResolved, bitbucket.IssueStateOnHold,
+		bitbucket.IssueStateInvalid, bitbucket.IssueStateDuplicate, bitbucket.IssueStateWontfix, bitbucket.IssueStateClosed,
+	}
+	issueKinds = []string{
+		bitbucket.IssueKindBug, bitbucket.IssueKindEnhancement, bitbucket.IssueKindProposal, bitbucket.IssueKindTask,
+	}
+	issuePriorities = []string{
+		bitbucket.IssuePriorityTrivial, bitbucket.IssuePriorityMinor, bitbucket.IssuePriorityMajor,
+		bitbucket.IssuePriorityCritical, bitbucket.IssuePriorityBlocker,
+	}
+)
+
+// ListIssuesProvider implements the ResourceTemplateProvider interface
+// for listing issues of a Bitbucket repository's issue tracker.
+type ListIssuesProvider struct {
+	bitbucket *bitbucket.Service
+	template  string
+	uriParser *util.UriTemplateParser
+}
+
+// NewIssuesProvider creates a new provider for listing issues.
+// The provider supports the URI template:
+// mcp://bitbucket/{namespace}/repositories/{repository}/issues?state={state}&kind={kind}&priority={priority}&assignee={assignee}&q={q}&sort={sort}&page={page}&size={size}
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured ListIssuesProvider.
+func NewIssuesProvider(bitbucket *bitbucket.Service) *ListIssuesProvider {
+	template := "mcp://bitbucket/{namespace}/repositories/{repository}/issues{?state,kind,priority,assignee,q,sort,page,size}"
+	parser, err := util.NewUriTemplateParser(template)
+	if err != nil {
+		panic(err)
+	}
+
+	return &ListIssuesProvider{
+		bitbucket: bitbucket,
+		template:  template,
+		uriParser: parser,
+	}
+}
+
+// GetDefinition returns the MCP resource template definition for listing issues.
+// The template includes URI pattern, title, description, and MIME type.
+func (p *ListIssuesProvider) GetDefinition() *mcp.ResourceTemplate {
+	return &mcp.ResourceTemplate{
+		Name:        "issues",
+		URITemplate: p.template,
+		Title:       "List Issues",
+		Description: "Retrieves a list of issues from the issue tracker of a repository in the configured Bitbucket workspace. Supports filtering by comma-separated states (state=new,open,resolved,on hold,invalid,duplicate,wontfix,closed), kinds (kind=bug,enhancement,proposal,task), and priorities (priority=trivial,minor,major,critical,blocker), by assignee (nickname, account ID, or UUID), an additional BBQL expression (q), sorting (sort=-updated_on), and paging (page, size up to 50).",
+		MIMEType:    string(web.MimeApplicationJson),
+	}
+}
+
+// Handler processes read resource requests for listing issues.
+// It parses and validates the URI parameters, calls the Bitbucket service,
+// and returns the issues as JSON.
+//
+// URI Parameters:
+//   - namespace: The workspace slug or username (required, must not be blank)
+//   - repository: The repository name/slug (required, must not be blank)
+//   - state: Comma-separated issue states (optional, must be one of issueStates)
+//   - kind: Comma-separated issue kinds (optional, must be one of issueKinds)
+//   - priority: Comma-separated issue priorities (optional, must be one of issuePriorities)
+//   - assignee: Assignee nickname, account ID, or UUID (optional)
+//   - q: Additional BBQL filter expression (optional)
+//   - sort: Field to sort by, "-" prefix for descending order (optional)
+//   - page: The page number (optional, defaults to 1, must be positive)
+//   - size: The number of items per page (optional, defaults to 50, must be between 1 and 50)
+//
+// Returns:
+//   - ReadResourceResult containing the list of issues as JSON
+//   - InvalidParamsError if URI parsing or validation fails
+//   - ResourceNotFoundError if the repository or its issue tracker doesn't exist
+//   - InternalError if internal logic fails
+func (p *ListIssuesProvider) Handler(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	params, err := p.uriParser.Parse(req.Params.URI)
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	namespace, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["namespace"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	repository, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["repository"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	states, err := parseIssueFilter("state", params.Query["state"], issueStates)
+	if err != nil {
+		return nil, err
+	}
+
+	kinds, err := parseIssueFilter("kind", params.Query["kind"], issueKinds)
+	if err != nil {
+		return nil, err
+	}
+
+	priorities, err := parseIssueFilter("priority", params.Query["priority"], issuePriorities)
+	if err != nil {
+		return nil, err
+	}
+
+	page := sch.Int().Must(sch.Positive()).Optional(1).Parse(params.Query["page"])
+	size := sch.Int().Must(sch.Between(1, 50)).Optional(50).Parse(params.Query["size"])
+
+	res, err := p.bitbucket.ListIssues(ctx, namespace, repository, bitbucket.ListIssuesOptions{
+		States:     states,
+		Kinds:      kinds,
+		Priorities: priorities,
+		Assignee:   strings.TrimSpace(params.Query["assignee"]),
+		Query:      strings.TrimSpace(params.Query["q"]),
+		Sort:       strings.TrimSpace(params.Query["sort"]),
+		Page:       page,
+		Size:       size,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewJsonResourceResult(req.Params.URI, res)
+}
+
+// parseIssueFilter parses a comma-separated issue filter URI parameter.
+// Values are case-insensitive and must be one of the allowed values.
+//
+// Returns an InvalidParamsError naming the parameter if any of the values is not supported.
+func parseIssueFilter(name string, value string, allowed []string) ([]string, error) {
+	values, err := sch.List(",").Parse(strings.ToLower(value))
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+	for _, value := range values {
+		if err := sch.In(allowed...)(value); err != nil {
+			return nil, util.NewInvalidParamsError(name + ": " + err.Error())
+		}
+	}
+	return values, nil
+}
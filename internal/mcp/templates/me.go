@@ -0,0 +1,72 @@
+package templates
+
+import (
+	"context"
+
+	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/branow/mcp-bitbucket/internal/util"
+	"github.com/branow/mcp-bitbucket/internal/util/web"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// CurrentUserProvider implements the ResourceTemplateProvider interface
+// for retrieving the authenticated user.
+type CurrentUserProvider struct {
+	bitbucket *bitbucket.Service
+	template  string
+	uriParser *util.UriTemplateParser
+}
+
+// NewCurrentUserProvider creates a new provider for retrieving the current user.
+// The provider supports the URI template:
+// mcp://bitbucket/me
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured CurrentUserProvider.
+func NewCurrentUserProvider(bitbucket *bitbucket.Service) *CurrentUserProvider {
+	template := "mcp://bitbucket/me"
+	parser, err := util.NewUriTemplateParser(template)
+	if err != nil {
+		panic(err)
+	}
+
+	return &CurrentUserProvider{
+		bitbucket: bitbucket,
+		template:  template,
+		uriParser: parser,
+	}
+}
+
+// GetDefinition returns the MCP resource template definition for the current user.
+// The template includes URI pattern, title, description, and MIME type.
+func (p *CurrentUserProvider) GetDefinition() *mcp.ResourceTemplate {
+	return &mcp.ResourceTemplate{
+		Name:        "me",
+		URITemplate: p.template,
+		Title:       "Current User",
+		Description: "Retrieves the Bitbucket user the server acts as: display name, nickname, account ID, UUID, and email addresses (empty if the credentials lack the email scope). Use it to find out whose pull requests, reviews, and commits are meant by \"my\".",
+		MIMEType:    string(web.MimeApplicationJson),
+	}
+}
+
+// Handler processes read resource requests for the current user.
+// It calls the Bitbucket service and returns the user as JSON.
+//
+// Returns:
+//   - ReadResourceResult containing the current user as JSON
+//   - InvalidParamsError if URI parsing fails or the credentials are rejected
+//   - InternalError if internal logic fails
+func (p *CurrentUserProvider) Handler(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	if _, err := p.uriParser.Parse(req.Params.URI); err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	res, err := p.bitbucket.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewJsonResourceResult(req.Params.URI, res)
+}
@@ -0,0 +1,89 @@
+package templates
+
+import (
+	"context"
+
+	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/branow/mcp-bitbucket/internal/util"
+	sch "github.com/branow/mcp-bitbucket/internal/util/schema"
+	"github.com/branow/mcp-bitbucket/internal/util/web"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ListMembersProvider implements the ResourceTemplateProvider interface
+// for listing the members of a Bitbucket workspace.
+type ListMembersProvider struct {
+	bitbucket *bitbucket.Service
+	template  string
+	uriParser *util.UriTemplateParser
+}
+
+// NewMembersProvider creates a new provider for listing workspace members.
+// The provider supports the URI template:
+// mcp://bitbucket/{namespace}/members?page={page}&size={size}
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured ListMembersProvider.
+func NewMembersProvider(bitbucket *bitbucket.Service) *ListMembersProvider {
+	template := "mcp://bitbucket/{namespace}/members{?page,size}"
+	parser, err := util.NewUriTemplateParser(template)
+	if err != nil {
+		panic(err)
+	}
+
+	return &ListMembersProvider{
+		bitbucket: bitbucket,
+		template:  template,
+		uriParser: parser,
+	}
+}
+
+// GetDefinition returns the MCP resource template definition for listing workspace members.
+// The template includes URI pattern, title, description, and MIME type.
+func (p *ListMembersProvider) GetDefinition() *mcp.ResourceTemplate {
+	return &mcp.ResourceTemplate{
+		Name:        "members",
+		URITemplate: p.template,
+		Title:       "List Workspace Members",
+		Description: "Retrieves the members of a workspace with the display name, nickname, account ID, and UUID of each, e.g. to pick reviewers or assignees. Paged with page and size (1-100, defaults to 50).",
+		MIMEType:    string(web.MimeApplicationJson),
+	}
+}
+
+// Handler processes read resource requests for listing workspace members.
+// It parses and validates the URI parameters, calls the Bitbucket service,
+// and returns the members as JSON.
+//
+// URI Parameters:
+//   - namespace: The workspace slug (required, must not be blank)
+//   - page: The page number (optional, defaults to 1, must be positive)
+//   - size: The number of items per page (optional, defaults to 50, must be between 1 and 100)
+//
+// Returns:
+//   - ReadResourceResult containing the list of members as JSON
+//   - InvalidParamsError if URI parsing or validation fails
+//   - ResourceNotFoundError if the workspace doesn't exist
+//   - InternalError if internal logic fails
+func (p *ListMembersProvider) Handler(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	params, err := p.uriParser.Parse(req.Params.URI)
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	namespace, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["namespace"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	page := sch.Int().Must(sch.Positive()).Optional(1).Parse(params.Query["page"])
+	size := sch.Int().Must(sch.Between(1, 100)).Optional(50).Parse(params.Query["size"])
+
+	res, err := p.bitbucket.ListWorkspaceMembers(ctx, namespace, page, size)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewJsonResourceResult(req.Params.URI, res)
+}
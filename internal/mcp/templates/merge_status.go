@@ -0,0 +1,97 @@
+package templates
+
+import (
+	"context"
+	"fmt"
+
+	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/branow/mcp-bitbucket/internal/util"
+	sch "github.com/branow/mcp-bitbucket/internal/util/schema"
+	"github.com/branow/mcp-bitbucket/internal/util/web"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// MergeStatusProvider implements the ResourceTemplateProvider interface
+// for checking whether a Bitbucket pull request can be merged.
+type MergeStatusProvider struct {
+	bitbucket *bitbucket.Service
+	template  string
+	uriParser *util.UriTemplateParser
+}
+
+// NewMergeStatusProvider creates a new provider for checking pull request merge status.
+// The provider supports the URI template:
+// mcp://bitbucket/{namespace}/repositories/{repository}/pullrequests/{pullRequestId}/merge-check
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured MergeStatusProvider.
+func NewMergeStatusProvider(bitbucket *bitbucket.Service) *MergeStatusProvider {
+	template := "mcp://bitbucket/{namespace}/repositories/{repository}/pullrequests/{pullRequestId}/merge-check"
+	parser, err := util.NewUriTemplateParser(template)
+	if err != nil {
+		panic(err)
+	}
+
+	return &MergeStatusProvider{
+		bitbucket: bitbucket,
+		template:  template,
+		uriParser: parser,
+	}
+}
+
+// GetDefinition returns the MCP resource template definition for pull request merge status.
+// The template includes URI pattern, title, description, and MIME type.
+func (p *MergeStatusProvider) GetDefinition() *mcp.ResourceTemplate {
+	return &mcp.ResourceTemplate{
+		Name:        "pullRequestMergeCheck",
+		URITemplate: p.template,
+		Title:       "Pull Request Merge Check",
+		Description: "Checks whether a pull request can be merged into its destination branch. Reports the merge strategies allowed on the destination branch and its default strategy, the files in conflict, and the merge checks of the matching branch restrictions (required approvals, default reviewer approvals, passing builds, completed tasks, no change requests). Failed checks block the merge only if they are enforced; checks that cannot be evaluated, e.g. without admin access to the branch restrictions, are reported as warnings.",
+		MIMEType:    string(web.MimeApplicationJson),
+	}
+}
+
+// Handler processes read resource requests for pull request merge status.
+// It parses and validates the URI parameters, calls the Bitbucket service,
+// and returns the merge status as JSON.
+//
+// URI Parameters:
+//   - namespace: The workspace slug or username (required, must not be blank)
+//   - repository: The repository name/slug (required, must not be blank)
+//   - pullRequestId: The pull request ID (required, must be positive)
+//
+// Returns:
+//   - ReadResourceResult containing the merge status as JSON
+//   - InvalidParamsError if URI parsing or validation fails
+//   - ResourceNotFoundError if the pull request doesn't exist
+//   - InternalError if internal logic fails
+func (p *MergeStatusProvider) Handler(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	params, err := p.uriParser.Parse(req.Params.URI)
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	namespace, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["namespace"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	repository, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["repository"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	pullRequestId, err := sch.Int().Must(sch.Positive()).Parse(params.Path["pullRequestId"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(fmt.Sprintf("pullRequestId: %s", err.Error()))
+	}
+
+	res, err := p.bitbucket.GetMergeStatus(ctx, namespace, repository, pullRequestId)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewJsonResourceResult(req.Params.URI, res)
+}
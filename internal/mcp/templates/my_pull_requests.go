@@ -0,0 +1,105 @@
+package templates
+
+import (
+	"context"
+	"strings"
+
+	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/branow/mcp-bitbucket/internal/util"
+	sch "github.com/branow/mcp-bitbucket/internal/util/schema"
+	"github.com/branow/mcp-bitbucket/internal/util/web"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// MyPullRequestsProvider implements the ResourceTemplateProvider interface
+// for listing the authenticated user's pull requests across repositories.
+type MyPullRequestsProvider struct {
+	bitbucket *bitbucket.Service
+	template  string
+	uriParser *util.UriTemplateParser
+}
+
+// NewMyPullRequestsProvider creates a new provider for listing the current user's pull requests.
+// The provider supports the URI template:
+// mcp://bitbucket/me/pullrequests?role={role}&state={state}&workspace={workspace}&page={page}&size={size}
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured MyPullRequestsProvider.
+func NewMyPullRequestsProvider(bitbucket *bitbucket.Service) *MyPullRequestsProvider {
+	template := "mcp://bitbucket/me/pullrequests{?role,state,workspace,page,size}"
+	parser, err := util.NewUriTemplateParser(template)
+	if err != nil {
+		panic(err)
+	}
+
+	return &MyPullRequestsProvider{
+		bitbucket: bitbucket,
+		template:  template,
+		uriParser: parser,
+	}
+}
+
+// GetDefinition returns the MCP resource template definition for the current user's pull requests.
+// The template includes URI pattern, title, description, and MIME type.
+func (p *MyPullRequestsProvider) GetDefinition() *mcp.ResourceTemplate {
+	return &mcp.ResourceTemplate{
+		Name:        "myPullRequests",
+		URITemplate: p.template,
+		Title:       "My Pull Requests",
+		Description: "Retrieves the pull requests of the authenticated user in a single call: the ones they authored across all repositories and the ones they are asked to review in the repositories they are a member of, with approval counts and the user's own review status. Review requests are searched repository by repository in a single workspace (workspace; defaults to the first workspace of the user): only the 50 most recently updated repositories are searched and only the 50 most recently updated review requests of each, so reading them costs up to 52 Bitbucket API requests; prefer role=author when review requests are not needed. Supports filtering by role (role=author, reviewer, or all; defaults to all), comma-separated states (state=OPEN,MERGED,DECLINED,SUPERSEDED; defaults to OPEN), and paging (page, size up to 50) applied to each role.",
+		MIMEType:    string(web.MimeApplicationJson),
+	}
+}
+
+// Handler processes read resource requests for the current user's pull requests.
+// It parses and validates the URI parameters, calls the Bitbucket service,
+// and returns the authored and reviewed pull requests as JSON.
+//
+// URI Parameters:
+//   - role: Which pull requests to list (optional, defaults to all, must be author, reviewer, or all)
+//   - state: Comma-separated pull request states (optional, must be OPEN, MERGED, DECLINED, or SUPERSEDED)
+//   - workspace: The workspace slug to search for review requests (optional, defaults to the first workspace of the user)
+//   - page: The page number (optional, defaults to 1, must be positive)
+//   - size: The number of items per page (optional, defaults to 50, must be between 1 and 50)
+//
+// Returns:
+//   - ReadResourceResult containing the user's pull requests as JSON
+//   - InvalidParamsError if URI parsing or validation fails
+//   - InternalError if internal logic fails
+func (p *MyPullRequestsProvider) Handler(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	params, err := p.uriParser.Parse(req.Params.URI)
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	role := bitbucket.RoleAll
+	if value := strings.TrimSpace(params.Query["role"]); value != "" {
+		role, err = sch.String().Must(sch.In(bitbucket.RoleAuthor, bitbucket.RoleReviewer, bitbucket.RoleAll)).Parse(strings.ToLower(value))
+		if err != nil {
+			return nil, util.NewInvalidParamsError("role: " + err.Error())
+		}
+	}
+
+	states, err := parsePullRequestStates(params.Query["state"])
+	if err != nil {
+		return nil, err
+	}
+
+	page := sch.Int().Must(sch.Positive()).Optional(1).Parse(params.Query["page"])
+	size := sch.Int().Must(sch.Between(1, 50)).Optional(50).Parse(params.Query["size"])
+
+	res, err := p.bitbucket.ListMyPullRequests(ctx, bitbucket.ListUserPullRequestsOptions{
+		Role:      role,
+		States:    states,
+		Namespace: strings.TrimSpace(params.Query["workspace"]),
+		Page:      page,
+		Size:      size,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewJsonResourceResult(req.Params.URI, res)
+}
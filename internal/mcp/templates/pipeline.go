@@ -0,0 +1,96 @@
+package templates
+
+import (
+	"context"
+
+	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/branow/mcp-bitbucket/internal/util"
+	sch "github.com/branow/mcp-bitbucket/internal/util/schema"
+	"github.com/branow/mcp-bitbucket/internal/util/web"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// PipelineProvider implements the ResourceTemplateProvider interface
+// for retrieving a single Bitbucket pipeline with its steps.
+type PipelineProvider struct {
+	bitbucket *bitbucket.Service
+	template  string
+	uriParser *util.UriTemplateParser
+}
+
+// NewPipelineProvider creates a new provider for retrieving a pipeline.
+// The provider supports the URI template:
+// mcp://bitbucket/{namespace}/repositories/{repository}/pipelines/{pipeline}
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured PipelineProvider.
+func NewPipelineProvider(bitbucket *bitbucket.Service) *PipelineProvider {
+	template := "mcp://bitbucket/{namespace}/repositories/{repository}/pipelines/{pipeline}"
+	parser, err := util.NewUriTemplateParser(template)
+	if err != nil {
+		panic(err)
+	}
+
+	return &PipelineProvider{
+		bitbucket: bitbucket,
+		template:  template,
+		uriParser: parser,
+	}
+}
+
+// GetDefinition returns the MCP resource template definition for retrieving a pipeline.
+// The template includes URI pattern, title, description, and MIME type.
+func (p *PipelineProvider) GetDefinition() *mcp.ResourceTemplate {
+	return &mcp.ResourceTemplate{
+		Name:        "pipeline",
+		URITemplate: p.template,
+		Title:       "Get Pipeline",
+		Description: "Retrieves a Bitbucket Pipelines run by build number (e.g. 42) or UUID (braces percent-encoded, e.g. %7B0f3c...%7D) with all of its steps, including the state, result, duration, image, and script commands of each step. For every failed step, the last 16 KB of its log are included as logTail to diagnose why the build is red; read the step log resource for more of the log.",
+		MIMEType:    string(web.MimeApplicationJson),
+	}
+}
+
+// Handler processes read resource requests for retrieving a pipeline.
+// It parses and validates the URI parameters, calls the Bitbucket service,
+// and returns the pipeline with its steps as JSON.
+//
+// URI Parameters:
+//   - namespace: The workspace slug or username (required, must not be blank)
+//   - repository: The repository name/slug (required, must not be blank)
+//   - pipeline: The pipeline build number or UUID (required, must not be blank)
+//
+// Returns:
+//   - ReadResourceResult containing the pipeline details as JSON
+//   - InvalidParamsError if URI parsing or validation fails
+//   - ResourceNotFoundError if the repository or pipeline doesn't exist
+//   - InternalError if internal logic fails
+func (p *PipelineProvider) Handler(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	params, err := p.uriParser.Parse(req.Params.URI)
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	namespace, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["namespace"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	repository, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["repository"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	pipeline, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["pipeline"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	res, err := p.bitbucket.GetPipeline(ctx, namespace, repository, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewJsonResourceResult(req.Params.URI, res)
+}
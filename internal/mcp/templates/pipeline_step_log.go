@@ -0,0 +1,124 @@
+package templates
+
+import (
+	"context"
+	"strings"
+
+	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/branow/mcp-bitbucket/internal/util"
+	sch "github.com/branow/mcp-bitbucket/internal/util/schema"
+	"github.com/branow/mcp-bitbucket/internal/util/web"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Limits of the number of step log bytes retrieved at once.
+const (
+	defaultStepLogLength = 64 * 1024
+	maxStepLogLength     = 1024 * 1024
+)
+
+// PipelineStepLogProvider implements the ResourceTemplateProvider interface
+// for retrieving the log of a Bitbucket pipeline step.
+type PipelineStepLogProvider struct {
+	bitbucket *bitbucket.Service
+	template  string
+	uriParser *util.UriTemplateParser
+}
+
+// NewPipelineStepLogProvider creates a new provider for retrieving pipeline step logs.
+// The provider supports the URI template:
+// mcp://bitbucket/{namespace}/repositories/{repository}/pipelines/{pipeline}/steps/{step}/log?offset={offset}&length={length}
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured PipelineStepLogProvider.
+func NewPipelineStepLogProvider(bitbucket *bitbucket.Service) *PipelineStepLogProvider {
+	template := "mcp://bitbucket/{namespace}/repositories/{repository}/pipelines/{pipeline}/steps/{step}/log{?offset,length}"
+	parser, err := util.NewUriTemplateParser(template)
+	if err != nil {
+		panic(err)
+	}
+
+	return &PipelineStepLogProvider{
+		bitbucket: bitbucket,
+		template:  template,
+		uriParser: parser,
+	}
+}
+
+// GetDefinition returns the MCP resource template definition for retrieving a pipeline step log.
+// The template includes URI pattern, title, description, and MIME type.
+func (p *PipelineStepLogProvider) GetDefinition() *mcp.ResourceTemplate {
+	return &mcp.ResourceTemplate{
+		Name:        "pipelineStepLog",
+		URITemplate: p.template,
+		Title:       "Get Pipeline Step Log",
+		Description: "Retrieves a byte range of the log of a Bitbucket Pipelines step. The pipeline is given by build number or UUID and the step by UUID (braces percent-encoded). Without offset, returns the last length bytes of the log; with offset, returns length bytes starting at that byte. length defaults to 65536 and is capped at 1048576. The result includes the offset, length, and total size of the log to page through it.",
+		MIMEType:    string(web.MimeApplicationJson),
+	}
+}
+
+// Handler processes read resource requests for retrieving a pipeline step log.
+// It parses and validates the URI parameters, calls the Bitbucket service,
+// and returns the requested part of the log as JSON.
+//
+// URI Parameters:
+//   - namespace: The workspace slug or username (required, must not be blank)
+//   - repository: The repository name/slug (required, must not be blank)
+//   - pipeline: The pipeline build number or UUID (required, must not be blank)
+//   - step: The step UUID (required, must not be blank)
+//   - offset: Offset of the first byte to retrieve (optional, must not be negative, defaults to the log tail)
+//   - length: The number of bytes to retrieve (optional, defaults to 65536, must be between 1 and 1048576)
+//
+// Returns:
+//   - ReadResourceResult containing the step log as JSON
+//   - InvalidParamsError if URI parsing or validation fails
+//   - ResourceNotFoundError if the repository, pipeline, step, or log doesn't exist
+//   - InternalError if internal logic fails
+func (p *PipelineStepLogProvider) Handler(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	params, err := p.uriParser.Parse(req.Params.URI)
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	namespace, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["namespace"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	repository, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["repository"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	pipeline, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["pipeline"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	step, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["step"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	options := bitbucket.PipelineStepLogOptions{}
+	if value := strings.TrimSpace(params.Query["offset"]); value != "" {
+		offset, err := sch.Int().Must(sch.NonNegative()).Parse(value)
+		if err != nil {
+			return nil, util.NewInvalidParamsError("offset: " + err.Error())
+		}
+		options.Offset = new(int64)
+		*options.Offset = int64(offset)
+	}
+
+	length := sch.Int().Must(sch.Between(1, maxStepLogLength)).Optional(defaultStepLogLength).Parse(params.Query["length"])
+	options.Length = int64(length)
+
+	res, err := p.bitbucket.GetPipelineStepLog(ctx, namespace, repository, pipeline, step, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewJsonResourceResult(req.Params.URI, res)
+}
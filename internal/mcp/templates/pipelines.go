@@ -0,0 +1,103 @@
+package templates
+
+import (
+	"context"
+	"strings"
+
+	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/branow/mcp-bitbucket/internal/util"
+	sch "github.com/branow/mcp-bitbucket/internal/util/schema"
+	"github.com/branow/mcp-bitbucket/internal/util/web"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ListPipelinesProvider implements the ResourceTemplateProvider interface
+// for listing the pipelines of a Bitbucket repository.
+type ListPipelinesProvider struct {
+	bitbucket *bitbucket.Service
+	template  string
+	uriParser *util.UriTemplateParser
+}
+
+// NewPipelinesProvider creates a new provider for listing pipelines.
+// The provider supports the URI template:
+// mcp://bitbucket/{namespace}/repositories/{repository}/pipelines?branch={branch}&status={status}&page={page}&size={size}
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured ListPipelinesProvider.
+func NewPipelinesProvider(bitbucket *bitbucket.Service) *ListPipelinesProvider {
+	template := "mcp://bitbucket/{namespace}/repositories/{repository}/pipelines{?branch,status,page,size}"
+	parser, err := util.NewUriTemplateParser(template)
+	if err != nil {
+		panic(err)
+	}
+
+	return &ListPipelinesProvider{
+		bitbucket: bitbucket,
+		template:  template,
+		uriParser: parser,
+	}
+}
+
+// GetDefinition returns the MCP resource template definition for listing pipelines.
+// The template includes URI pattern, title, description, and MIME type.
+func (p *ListPipelinesProvider) GetDefinition() *mcp.ResourceTemplate {
+	return &mcp.ResourceTemplate{
+		Name:        "pipelines",
+		URITemplate: p.template,
+		Title:       "List Pipelines",
+		Description: "Retrieves the Bitbucket Pipelines runs of a repository, newest first, with the UUID, build number, state, result, target ref and commit, trigger, creator, and duration of each run. Can be filtered by branch (branch=main) and status (status=FAILED). Paged with page and size (1-100, defaults to 20). Read a single pipeline via the pipeline resource to see its steps and the log tails of failed steps.",
+		MIMEType:    string(web.MimeApplicationJson),
+	}
+}
+
+// Handler processes read resource requests for listing pipelines.
+// It parses and validates the URI parameters, calls the Bitbucket service,
+// and returns the pipelines as JSON.
+//
+// URI Parameters:
+//   - namespace: The workspace slug or username (required, must not be blank)
+//   - repository: The repository name/slug (required, must not be blank)
+//   - branch: Branch whose pipelines to list (optional)
+//   - status: Pipeline status to filter by (optional, e.g. FAILED or SUCCESSFUL)
+//   - page: The page number (optional, defaults to 1, must be positive)
+//   - size: The number of items per page (optional, defaults to 20, must be between 1 and 100)
+//
+// Returns:
+//   - ReadResourceResult containing the list of pipelines as JSON
+//   - InvalidParamsError if URI parsing or validation fails
+//   - ResourceNotFoundError if the repository doesn't exist
+//   - InternalError if internal logic fails
+func (p *ListPipelinesProvider) Handler(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	params, err := p.uriParser.Parse(req.Params.URI)
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	namespace, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["namespace"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	repository, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["repository"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	page := sch.Int().Must(sch.Positive()).Optional(1).Parse(params.Query["page"])
+	size := sch.Int().Must(sch.Between(1, 100)).Optional(20).Parse(params.Query["size"])
+
+	res, err := p.bitbucket.ListPipelines(ctx, namespace, repository, bitbucket.ListPipelinesOptions{
+		Branch: strings.TrimSpace(params.Query["branch"]),
+		Status: strings.ToUpper(strings.TrimSpace(params.Query["status"])),
+		Page:   page,
+		Size:   size,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewJsonResourceResult(req.Params.URI, res)
+}
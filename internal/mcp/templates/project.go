@@ -0,0 +1,95 @@
+package templates
+
+import (
+	"context"
+
+	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/branow/mcp-bitbucket/internal/util"
+	sch "github.com/branow/mcp-bitbucket/internal/util/schema"
+	"github.com/branow/mcp-bitbucket/internal/util/web"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ProjectProvider implements the ResourceTemplateProvider interface
+// for retrieving a single project of a Bitbucket workspace with its repositories.
+type ProjectProvider struct {
+	bitbucket *bitbucket.Service
+	template  string
+	uriParser *util.UriTemplateParser
+}
+
+// NewProjectProvider creates a new provider for retrieving a project.
+// The provider supports the URI template:
+// mcp://bitbucket/{namespace}/projects/{projectKey}?page={page}&size={size}
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured ProjectProvider.
+func NewProjectProvider(bitbucket *bitbucket.Service) *ProjectProvider {
+	template := "mcp://bitbucket/{namespace}/projects/{projectKey}{?page,size}"
+	parser, err := util.NewUriTemplateParser(template)
+	if err != nil {
+		panic(err)
+	}
+
+	return &ProjectProvider{
+		bitbucket: bitbucket,
+		template:  template,
+		uriParser: parser,
+	}
+}
+
+// GetDefinition returns the MCP resource template definition for retrieving a project.
+// The template includes URI pattern, title, description, and MIME type.
+func (p *ProjectProvider) GetDefinition() *mcp.ResourceTemplate {
+	return &mcp.ResourceTemplate{
+		Name:        "project",
+		URITemplate: p.template,
+		Title:       "Get Project",
+		Description: "Retrieves a project of a workspace by its key, with its name, description, visibility, and dates, together with the repositories of the project sorted by name. The repositories are paged with page and size (1-100, defaults to 50).",
+		MIMEType:    string(web.MimeApplicationJson),
+	}
+}
+
+// Handler processes read resource requests for retrieving a project.
+// It parses and validates the URI parameters, calls the Bitbucket service,
+// and returns the project with its repositories as JSON.
+//
+// URI Parameters:
+//   - namespace: The workspace slug (required, must not be blank)
+//   - projectKey: The project key (required, must not be blank)
+//   - page: The page number of the repositories (optional, defaults to 1, must be positive)
+//   - size: The number of repositories per page (optional, defaults to 50, must be between 1 and 100)
+//
+// Returns:
+//   - ReadResourceResult containing the project details as JSON
+//   - InvalidParamsError if URI parsing or validation fails
+//   - ResourceNotFoundError if the workspace or project doesn't exist
+//   - InternalError if internal logic fails
+func (p *ProjectProvider) Handler(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	params, err := p.uriParser.Parse(req.Params.URI)
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	namespace, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["namespace"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	projectKey, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["projectKey"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	page := sch.Int().Must(sch.Positive()).Optional(1).Parse(params.Query["page"])
+	size := sch.Int().Must(sch.Between(1, 100)).Optional(50).Parse(params.Query["size"])
+
+	res, err := p.bitbucket.GetProject(ctx, namespace, projectKey, page, size)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewJsonResourceResult(req.Params.URI, res)
+}
@@ -2,18 +2,17 @@ package templates
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/branow/mcp-bitbucket/internal/mcp/markdown"
 	"github.com/branow/mcp-bitbucket/internal/util"
 	sch "github.com/branow/mcp-bitbucket/internal/util/schema"
-	"github.com/branow/mcp-bitbucket/internal/util/web"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 // PullRequestProvider implements the ResourceTemplateProvider interface
-// for retrieving a single Bitbucket pull request with optional commits, diff, and comments.
+// for retrieving a single Bitbucket pull request with optional commits, diff, comments, and tasks.
 type PullRequestProvider struct {
 	bitbucket *bitbucket.Service
 	template  string
@@ -22,14 +21,14 @@ type PullRequestProvider struct {
 
 // NewPullRequestProvider creates a new provider for retrieving a single pull request.
 // The provider supports the URI template:
-// mcp://bitbucket/{namespace}/repositories/{repository}/pullrequests/{pullRequestId}?commits={commits}&diff={diff}&comments={comments}
+// mcp://bitbucket/{namespace}/repositories/{repository}/pullrequests/{pullRequestId}?commits={commits}&diff={diff}&comments={comments}&unresolved={unresolved}&file={file}&tasks={tasks}&format={format}
 //
 // Parameters:
 //   - bitbucket: The Bitbucket service for making API requests
 //
 // Returns a configured PullRequestProvider.
 func NewPullRequestProvider(bitbucket *bitbucket.Service) *PullRequestProvider {
-	template := "mcp://bitbucket/{namespace}/repositories/{repository}/pullrequests/{pullRequestId}{?commits,diff,comments}"
+	template := "mcp://bitbucket/{namespace}/repositories/{repository}/pullrequests/{pullRequestId}{?commits,diff,comments,unresolved,file,tasks,format}"
 	parser, err := util.NewUriTemplateParser(template)
 	if err != nil {
 		panic(err)
@@ -43,20 +42,19 @@ func NewPullRequestProvider(bitbucket *bitbucket.Service) *PullRequestProvider {
 }
 
 // GetDefinition returns the MCP resource template definition for retrieving a pull request.
-// The template includes URI pattern, title, description, and MIME type.
+// The template includes URI pattern, title, and description; the MIME type of each content depends on the requested format.
 func (p *PullRequestProvider) GetDefinition() *mcp.ResourceTemplate {
 	return &mcp.ResourceTemplate{
 		Name:        "pullRequest",
 		URITemplate: p.template,
 		Title:       "Pull Request",
-		Description: "Retrieves a pull request from the configured Bitbucket workspace, including metadata such as title, state, and reviewers. Optionally includes commits (commits=true), diff (diff=true), and comments (comments=true).",
-		MIMEType:    string(web.MimeApplicationJson),
+		Description: "Retrieves a pull request from the configured Bitbucket workspace, including metadata such as title, state, and reviewers, and the aggregated build status of the source commit with the reported builds. Optionally includes commits (commits=true), diff (diff=true), and comment threads (comments=true). Comment threads can be narrowed to unresolved ones (unresolved=true) or to those anchored to a file (file=path/to/file). Tasks can be included with tasks=true. The output format can be JSON (format=json, default), Markdown (format=markdown), or both (format=both).",
 	}
 }
 
 // Handler processes read resource requests for retrieving a single pull request.
 // It parses and validates the URI parameters, calls the Bitbucket service,
-// and returns the pull request details as JSON.
+// and returns the pull request details in the requested format.
 //
 // URI Parameters:
 //   - namespace: The workspace slug or username (required, must not be blank)
@@ -64,10 +62,14 @@ func (p *PullRequestProvider) GetDefinition() *mcp.ResourceTemplate {
 //   - pullRequestId: The pull request ID (required, must be positive)
 //   - commits: Include commits (optional, defaults to false)
 //   - diff: Include diff (optional, defaults to false)
-//   - comments: Include comments (optional, defaults to false)
+//   - comments: Include comment threads (optional, defaults to false)
+//   - unresolved: Keep only unresolved comment threads (optional, defaults to false)
+//   - file: Keep only comment threads anchored to the file path (optional, defaults to all files)
+//   - tasks: Include tasks (optional, defaults to false)
+//   - format: Output format - json, markdown, or both (optional, defaults to json)
 //
 // Returns:
-//   - ReadResourceResult containing the pull request details as JSON
+//   - ReadResourceResult containing the pull request details as JSON and/or Markdown
 //   - InvalidParamsError if URI parsing or validation fails
 //   - ResourceNotFoundError if the pull request doesn't exist
 //   - InternalError if internal logic fails
@@ -95,28 +97,25 @@ func (p *PullRequestProvider) Handler(ctx context.Context, req *mcp.ReadResource
 	commits := sch.Bool().Optional(false).Parse(params.Query["commits"])
 	diff := sch.Bool().Optional(false).Parse(params.Query["diff"])
 	comments := sch.Bool().Optional(false).Parse(params.Query["comments"])
+	unresolved := sch.Bool().Optional(false).Parse(params.Query["unresolved"])
+	file := params.Query["file"]
+	tasks := sch.Bool().Optional(false).Parse(params.Query["tasks"])
+	format, err := ParseFormat(params.Query["format"])
+	if err != nil {
+		return nil, err
+	}
 
 	res, err := p.bitbucket.GetPullRequest(ctx, namespace, repository, pullRequestId, bitbucket.GetPullRequestOptions{
 		IncludeCommits:  commits,
 		IncludeDiff:     diff,
 		IncludeComments: comments,
+		UnresolvedOnly:  unresolved,
+		CommentsPath:    file,
+		IncludeTasks:    tasks,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	bytes, err := json.Marshal(res)
-	if err != nil {
-		return nil, util.NewInternalError()
-	}
-
-	return &mcp.ReadResourceResult{
-		Contents: []*mcp.ResourceContents{
-			{
-				URI:      req.Params.URI,
-				MIMEType: string(web.MimeApplicationJson),
-				Text:     string(bytes),
-			},
-		},
-	}, nil
+	return NewResourceResult(req.Params.URI, format, res, markdown.RenderPullRequestDetails)
 }
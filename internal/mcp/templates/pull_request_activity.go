@@ -0,0 +1,131 @@
+package templates
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/branow/mcp-bitbucket/internal/util"
+	sch "github.com/branow/mcp-bitbucket/internal/util/schema"
+	"github.com/branow/mcp-bitbucket/internal/util/web"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// activityTypes lists the activity types accepted by the type filter.
+var activityTypes = []string{
+	bitbucket.ActivityUpdate,
+	bitbucket.ActivityApproval,
+	bitbucket.ActivityChangesRequested,
+	bitbucket.ActivityComment,
+}
+
+// PullRequestActivityProvider implements the ResourceTemplateProvider interface
+// for retrieving the activity timeline of a pull request.
+type PullRequestActivityProvider struct {
+	bitbucket *bitbucket.Service
+	template  string
+	uriParser *util.UriTemplateParser
+}
+
+// NewPullRequestActivityProvider creates a new provider for retrieving pull request activity.
+// The provider supports the URI template:
+// mcp://bitbucket/{namespace}/repositories/{repository}/pullrequests/{pullRequestId}/activity?since={since}&type={type}
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured PullRequestActivityProvider.
+func NewPullRequestActivityProvider(bitbucket *bitbucket.Service) *PullRequestActivityProvider {
+	template := "mcp://bitbucket/{namespace}/repositories/{repository}/pullrequests/{pullRequestId}/activity{?since,type}"
+	parser, err := util.NewUriTemplateParser(template)
+	if err != nil {
+		panic(err)
+	}
+
+	return &PullRequestActivityProvider{
+		bitbucket: bitbucket,
+		template:  template,
+		uriParser: parser,
+	}
+}
+
+// GetDefinition returns the MCP resource template definition for pull request activity.
+// The template includes URI pattern, title, description, and MIME type.
+func (p *PullRequestActivityProvider) GetDefinition() *mcp.ResourceTemplate {
+	return &mcp.ResourceTemplate{
+		Name:        "pullRequestActivity",
+		URITemplate: p.template,
+		Title:       "Pull Request Activity",
+		Description: "Retrieves the activity timeline of a pull request in chronological order: updates (state, title, or source commit changes), approvals, change requests, and comments. Use since (a URL-encoded RFC 3339 timestamp or a date, e.g., since=2024-01-15) to see only what happened after a point in time, and comma-separated types (type=update,approval,changes_requested,comment) to narrow the events.",
+		MIMEType:    string(web.MimeApplicationJson),
+	}
+}
+
+// Handler processes read resource requests for pull request activity.
+// It parses and validates the URI parameters, calls the Bitbucket service,
+// and returns the activity timeline as JSON.
+//
+// URI Parameters:
+//   - namespace: The workspace slug or username (required, must not be blank)
+//   - repository: The repository name/slug (required, must not be blank)
+//   - pullRequestId: The pull request ID (required, must be positive)
+//   - since: Keep only activity after this time (optional, RFC 3339 timestamp or date)
+//   - type: Comma-separated activity types (optional, must be update, approval, changes_requested, or comment)
+//
+// Returns:
+//   - ReadResourceResult containing the activity timeline as JSON
+//   - InvalidParamsError if URI parsing or validation fails
+//   - ResourceNotFoundError if the pull request doesn't exist
+//   - InternalError if internal logic fails
+func (p *PullRequestActivityProvider) Handler(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	params, err := p.uriParser.Parse(req.Params.URI)
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	namespace, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["namespace"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	repository, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["repository"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	pullRequestId, err := sch.Int().Must(sch.Positive()).Parse(params.Path["pullRequestId"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(fmt.Sprintf("pullRequestId: %s", err.Error()))
+	}
+
+	var since *time.Time
+	if value := params.Query["since"]; value != "" {
+		parsed, err := sch.Time().Parse(value)
+		if err != nil {
+			return nil, util.NewInvalidParamsError("since: " + err.Error())
+		}
+		since = &parsed
+	}
+
+	types, err := sch.List(",").Parse(strings.ToLower(params.Query["type"]))
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+	for _, activityType := range types {
+		if err := sch.In(activityTypes...)(activityType); err != nil {
+			return nil, util.NewInvalidParamsError("type: " + err.Error())
+		}
+	}
+
+	res, err := p.bitbucket.ListPullRequestActivity(ctx, namespace, repository, pullRequestId, bitbucket.ListPullRequestActivityOptions{
+		Since: since,
+		Types: types,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewJsonResourceResult(req.Params.URI, res)
+}
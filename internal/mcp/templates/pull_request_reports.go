@@ -0,0 +1,98 @@
+package templates
+
+import (
+	"context"
+	"fmt"
+
+	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/branow/mcp-bitbucket/internal/util"
+	sch "github.com/branow/mcp-bitbucket/internal/util/schema"
+	"github.com/branow/mcp-bitbucket/internal/util/web"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// PullRequestReportsProvider implements the ResourceTemplateProvider interface
+// for retrieving the Code Insights reports of a Bitbucket pull request.
+type PullRequestReportsProvider struct {
+	bitbucket *bitbucket.Service
+	template  string
+	uriParser *util.UriTemplateParser
+}
+
+// NewPullRequestReportsProvider creates a new provider for retrieving pull request Code Insights reports.
+// The provider supports the URI template:
+// mcp://bitbucket/{namespace}/repositories/{repository}/pullrequests/{pullRequestId}/reports{?annotations}
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured PullRequestReportsProvider.
+func NewPullRequestReportsProvider(bitbucket *bitbucket.Service) *PullRequestReportsProvider {
+	template := "mcp://bitbucket/{namespace}/repositories/{repository}/pullrequests/{pullRequestId}/reports{?annotations}"
+	parser, err := util.NewUriTemplateParser(template)
+	if err != nil {
+		panic(err)
+	}
+
+	return &PullRequestReportsProvider{
+		bitbucket: bitbucket,
+		template:  template,
+		uriParser: parser,
+	}
+}
+
+// GetDefinition returns the MCP resource template definition for pull request Code Insights reports.
+// The template includes URI pattern, title, description, and MIME type.
+func (p *PullRequestReportsProvider) GetDefinition() *mcp.ResourceTemplate {
+	return &mcp.ResourceTemplate{
+		Name:        "pullRequestReports",
+		URITemplate: p.template,
+		Title:       "Pull Request Code Insights Reports",
+		Description: "Retrieves the Code Insights reports of the source commit of a pull request, e.g. static analysis, security scan, or test results, with their result, type, and summary data. The annotations of every report, the findings with their file, line, and severity, are included only when the annotations query parameter is true.",
+		MIMEType:    string(web.MimeApplicationJson),
+	}
+}
+
+// Handler processes read resource requests for pull request Code Insights reports.
+// It parses and validates the URI parameters, calls the Bitbucket service,
+// and returns the reports as JSON.
+//
+// URI Parameters:
+//   - namespace: The workspace slug or username (required, must not be blank)
+//   - repository: The repository name/slug (required, must not be blank)
+//   - pullRequestId: The pull request ID (required, must be positive)
+//   - annotations: Whether to include the annotations of every report (optional, default: false)
+//
+// Returns:
+//   - ReadResourceResult containing the reports as JSON
+//   - InvalidParamsError if URI parsing or validation fails
+//   - ResourceNotFoundError if the pull request doesn't exist
+//   - InternalError if internal logic fails
+func (p *PullRequestReportsProvider) Handler(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	params, err := p.uriParser.Parse(req.Params.URI)
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	namespace, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["namespace"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	repository, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["repository"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	pullRequestId, err := sch.Int().Must(sch.Positive()).Parse(params.Path["pullRequestId"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(fmt.Sprintf("pullRequestId: %s", err.Error()))
+	}
+
+	res, err := p.bitbucket.GetPullRequestReports(ctx, namespace, repository, pullRequestId, sch.Bool().Optional(false).Parse(params.Query["annotations"]))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewJsonResourceResult(req.Params.URI, res)
+}
@@ -0,0 +1,134 @@
+package templates
+
+import (
+	"context"
+	"strings"
+
+	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/branow/mcp-bitbucket/internal/util"
+	sch "github.com/branow/mcp-bitbucket/internal/util/schema"
+	"github.com/branow/mcp-bitbucket/internal/util/web"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// pullRequestStates lists the pull request states accepted by the state filter.
+var pullRequestStates = []string{"OPEN", "MERGED", "DECLINED", "SUPERSEDED"}
+
+// ListPullRequestsProvider implements the ResourceTemplateProvider interface
+// for listing pull requests of a Bitbucket repository.
+type ListPullRequestsProvider struct {
+	bitbucket *bitbucket.Service
+	template  string
+	uriParser *util.UriTemplateParser
+}
+
+// NewPullRequestsProvider creates a new provider for listing pull requests.
+// The provider supports the URI template:
+// mcp://bitbucket/{namespace}/repositories/{repository}/pullrequests?state={state}&author={author}&reviewer={reviewer}&q={q}&sort={sort}&page={page}&size={size}
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured ListPullRequestsProvider.
+func NewPullRequestsProvider(bitbucket *bitbucket.Service) *ListPullRequestsProvider {
+	template := "mcp://bitbucket/{namespace}/repositories/{repository}/pullrequests{?state,author,reviewer,q,sort,page,size}"
+	parser, err := util.NewUriTemplateParser(template)
+	if err != nil {
+		panic(err)
+	}
+
+	return &ListPullRequestsProvider{
+		bitbucket: bitbucket,
+		template:  template,
+		uriParser: parser,
+	}
+}
+
+// GetDefinition returns the MCP resource template definition for listing pull requests.
+// The template includes URI pattern, title, description, and MIME type.
+func (p *ListPullRequestsProvider) GetDefinition() *mcp.ResourceTemplate {
+	return &mcp.ResourceTemplate{
+		Name:        "pullRequests",
+		URITemplate: p.template,
+		Title:       "List Pull Requests",
+		Description: "Retrieves a list of pull requests from a repository in the configured Bitbucket workspace. Supports filtering by comma-separated states (state=OPEN,MERGED,DECLINED,SUPERSEDED; defaults to OPEN), author and reviewer (nickname, account ID, or UUID), an additional BBQL expression (q), sorting (sort=-updated_on), and paging (page, size up to 50).",
+		MIMEType:    string(web.MimeApplicationJson),
+	}
+}
+
+// Handler processes read resource requests for listing pull requests.
+// It parses and validates the URI parameters, calls the Bitbucket service,
+// and returns the pull requests as JSON.
+//
+// URI Parameters:
+//   - namespace: The workspace slug or username (required, must not be blank)
+//   - repository: The repository name/slug (required, must not be blank)
+//   - state: Comma-separated pull request states (optional, must be OPEN, MERGED, DECLINED, or SUPERSEDED)
+//   - author: Author nickname, account ID, or UUID (optional)
+//   - reviewer: Reviewer nickname, account ID, or UUID (optional)
+//   - q: Additional BBQL filter expression (optional)
+//   - sort: Field to sort by, "-" prefix for descending order (optional)
+//   - page: The page number (optional, defaults to 1, must be positive)
+//   - size: The number of items per page (optional, defaults to 50, must be between 1 and 50)
+//
+// Returns:
+//   - ReadResourceResult containing the list of pull requests as JSON
+//   - InvalidParamsError if URI parsing or validation fails
+//   - ResourceNotFoundError if the repository doesn't exist
+//   - InternalError if internal logic fails
+func (p *ListPullRequestsProvider) Handler(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	params, err := p.uriParser.Parse(req.Params.URI)
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	namespace, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["namespace"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	repository, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["repository"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	states, err := parsePullRequestStates(params.Query["state"])
+	if err != nil {
+		return nil, err
+	}
+
+	page := sch.Int().Must(sch.Positive()).Optional(1).Parse(params.Query["page"])
+	size := sch.Int().Must(sch.Between(1, 50)).Optional(50).Parse(params.Query["size"])
+
+	res, err := p.bitbucket.ListPullRequests(ctx, namespace, repository, bitbucket.ListPullRequestsOptions{
+		States:   states,
+		Author:   strings.TrimSpace(params.Query["author"]),
+		Reviewer: strings.TrimSpace(params.Query["reviewer"]),
+		Query:    strings.TrimSpace(params.Query["q"]),
+		Sort:     strings.TrimSpace(params.Query["sort"]),
+		Page:     page,
+		Size:     size,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewJsonResourceResult(req.Params.URI, res)
+}
+
+// parsePullRequestStates parses the comma-separated state URI parameter.
+// States are case-insensitive and must be one of pullRequestStates.
+//
+// Returns an InvalidParamsError if any of the states is not supported.
+func parsePullRequestStates(value string) ([]string, error) {
+	states, err := sch.List(",").Parse(strings.ToUpper(value))
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+	for _, state := range states {
+		if err := sch.In(pullRequestStates...)(state); err != nil {
+			return nil, util.NewInvalidParamsError("state: " + err.Error())
+		}
+	}
+	return states, nil
+}
@@ -0,0 +1,118 @@
+package templates
+
+import (
+	"context"
+	"strings"
+
+	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/branow/mcp-bitbucket/internal/util"
+	sch "github.com/branow/mcp-bitbucket/internal/util/schema"
+	"github.com/branow/mcp-bitbucket/internal/util/web"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ListRefsProvider implements the ResourceTemplateProvider interface
+// for listing branches and tags of a Bitbucket repository.
+type ListRefsProvider struct {
+	bitbucket *bitbucket.Service
+	template  string
+	uriParser *util.UriTemplateParser
+}
+
+// NewRefsProvider creates a new provider for listing branches and tags.
+// The provider supports the URI template:
+// mcp://bitbucket/{namespace}/repositories/{repository}/refs?type={type}&q={q}&sort={sort}&page={page}&size={size}
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured ListRefsProvider.
+func NewRefsProvider(bitbucket *bitbucket.Service) *ListRefsProvider {
+	template := "mcp://bitbucket/{namespace}/repositories/{repository}/refs{?type,q,sort,page,size}"
+	parser, err := util.NewUriTemplateParser(template)
+	if err != nil {
+		panic(err)
+	}
+
+	return &ListRefsProvider{
+		bitbucket: bitbucket,
+		template:  template,
+		uriParser: parser,
+	}
+}
+
+// GetDefinition returns the MCP resource template definition for listing branches and tags.
+// The template includes URI pattern, title, description, and MIME type.
+func (p *ListRefsProvider) GetDefinition() *mcp.ResourceTemplate {
+	return &mcp.ResourceTemplate{
+		Name:        "refs",
+		URITemplate: p.template,
+		Title:       "List Branches and Tags",
+		Description: "Retrieves branches and tags of a repository with their target commits, newest first. Supports narrowing to branches or tags (type=branch, type=tag, or type=all; defaults to all), a BBQL filter expression (q=name ~ \"release\"), sorting (sort=name; defaults to -target.date), and paging (page, size up to 100).",
+		MIMEType:    string(web.MimeApplicationJson),
+	}
+}
+
+// Handler processes read resource requests for listing branches and tags.
+// It parses and validates the URI parameters, calls the Bitbucket service,
+// and returns the refs as JSON.
+//
+// URI Parameters:
+//   - namespace: The workspace slug or username (required, must not be blank)
+//   - repository: The repository name/slug (required, must not be blank)
+//   - type: Which refs to list (optional, defaults to all, must be branch, tag, or all)
+//   - q: BBQL filter expression (optional)
+//   - sort: Field to sort by, "-" prefix for descending order (optional, defaults to -target.date)
+//   - page: The page number (optional, defaults to 1, must be positive)
+//   - size: The number of items per page (optional, defaults to 50, must be between 1 and 100)
+//
+// Returns:
+//   - ReadResourceResult containing the list of refs as JSON
+//   - InvalidParamsError if URI parsing or validation fails
+//   - ResourceNotFoundError if the repository doesn't exist
+//   - InternalError if internal logic fails
+func (p *ListRefsProvider) Handler(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	params, err := p.uriParser.Parse(req.Params.URI)
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	namespace, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["namespace"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	repository, err := sch.String().Must(sch.NotBlank()).Parse(params.Path["repository"])
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	refType := bitbucket.RefTypeAll
+	if value := strings.TrimSpace(params.Query["type"]); value != "" {
+		refType, err = sch.String().Must(sch.In(bitbucket.RefTypeBranch, bitbucket.RefTypeTag, bitbucket.RefTypeAll)).Parse(strings.ToLower(value))
+		if err != nil {
+			return nil, util.NewInvalidParamsError("type: " + err.Error())
+		}
+	}
+
+	sort := strings.TrimSpace(params.Query["sort"])
+	if sort == "" {
+		sort = "-target.date"
+	}
+
+	page := sch.Int().Must(sch.Positive()).Optional(1).Parse(params.Query["page"])
+	size := sch.Int().Must(sch.Between(1, 100)).Optional(50).Parse(params.Query["size"])
+
+	res, err := p.bitbucket.ListRefs(ctx, namespace, repository, bitbucket.ListRefsOptions{
+		Type:  refType,
+		Query: strings.TrimSpace(params.Query["q"]),
+		Sort:  sort,
+		Page:  page,
+		Size:  size,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewJsonResourceResult(req.Params.URI, res)
+}
@@ -2,7 +2,8 @@ package templates
 
 import (
 	"context"
-	"encoding/json"
+	"strings"
+	"time"
 
 	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
 	"github.com/branow/mcp-bitbucket/internal/util"
@@ -19,16 +20,24 @@ type ListRepositoriesProvider struct {
 	uriParser *util.UriTemplateParser
 }
 
+// Repository roles accepted by the role URI parameter.
+var repositoryRoles = []string{
+	bitbucket.RepositoryRoleMember,
+	bitbucket.RepositoryRoleContributor,
+	bitbucket.RepositoryRoleAdmin,
+	bitbucket.RepositoryRoleOwner,
+}
+
 // NewRepositoriesProvider creates a new provider for listing repositories.
 // The provider supports the URI template:
-// mcp://bitbucket/{namespace}/repositories?page={page}&pageSize={pageSize}
+// mcp://bitbucket/{namespace}/repositories?name={name}&project={project}&language={language}&private={private}&since={since}&role={role}&q={q}&sort={sort}&page={page}&pageSize={pageSize}
 //
 // Parameters:
 //   - bitbucket: The Bitbucket service for making API requests
 //
 // Returns a configured ListRepositoriesProvider.
 func NewRepositoriesProvider(bitbucket *bitbucket.Service) *ListRepositoriesProvider {
-	template := "mcp://bitbucket/{namespace}/repositories{?page,pageSize}"
+	template := "mcp://bitbucket/{namespace}/repositories{?name,project,language,private,since,role,q,sort,page,pageSize}"
 	parser, err := util.NewUriTemplateParser(template)
 	if err != nil {
 		panic(err)
@@ -48,7 +57,7 @@ func (p *ListRepositoriesProvider) GetDefinition() *mcp.ResourceTemplate {
 		Name:        "repositories",
 		URITemplate: p.template,
 		Title:       "List Repositories",
-		Description: "Retrieves a list of repositories from the configured Bitbucket workspace, including metadata such as repository name, slug, and visibility.",
+		Description: "Searches the repositories of a workspace, including metadata such as repository name, slug, project, language, and visibility. Supports filtering by a part of the name (name=billing), comma-separated project keys (project=PAY,OPS), comma-separated languages (language=go,java), visibility (private=true or false), last update (since, a URL-encoded RFC 3339 timestamp or a date, e.g., since=2024-06-01), the authenticated user's role (role=member, contributor, admin, or owner), an additional BBQL expression (q), sorting (sort=-updated_on or name), and paging (page, pageSize up to 100).",
 		MIMEType:    string(web.MimeApplicationJson),
 	}
 }
@@ -59,8 +68,16 @@ func (p *ListRepositoriesProvider) GetDefinition() *mcp.ResourceTemplate {
 //
 // URI Parameters:
 //   - namespace: The workspace slug or username (required, must not be blank)
+//   - name: Part of the repository name (optional)
+//   - project: Comma-separated project keys (optional)
+//   - language: Comma-separated languages (optional, case-insensitive)
+//   - private: Visibility of the repositories (optional, must be a boolean)
+//   - since: Keep only repositories updated after this time (optional, RFC 3339 timestamp or date)
+//   - role: The authenticated user's role (optional, must be member, contributor, admin, or owner)
+//   - q: Additional BBQL filter expression (optional)
+//   - sort: Field to sort by, "-" prefix for descending order (optional)
 //   - page: The page number (optional, defaults to 1, must be positive)
-//   - pageSize: The number of items per page (optional, defaults to 50, must be positive)
+//   - pageSize: The number of items per page (optional, defaults to 50, must be between 1 and 100)
 //
 // Returns:
 //   - ReadResourceResult containing the list of repositories as JSON
@@ -78,26 +95,59 @@ func (p *ListRepositoriesProvider) Handler(ctx context.Context, req *mcp.ReadRes
 		return nil, util.NewInvalidParamsError(err.Error())
 	}
 
-	page := schema.Int().Must(schema.Positive()).Optional(1).Parse(params.Query["page"])
-	size := schema.Int().Must(schema.Positive()).Optional(50).Parse(params.Query["pageSize"])
+	projects, err := schema.List(",").Parse(strings.ToUpper(params.Query["project"]))
+	if err != nil {
+		return nil, util.NewInvalidParamsError("project: " + err.Error())
+	}
 
-	res, err := p.bitbucket.ListRepositories(ctx, namespace, page, size)
+	languages, err := schema.List(",").Parse(strings.ToLower(params.Query["language"]))
 	if err != nil {
-		return nil, err
+		return nil, util.NewInvalidParamsError("language: " + err.Error())
+	}
+
+	var private *bool
+	if value := strings.TrimSpace(params.Query["private"]); value != "" {
+		parsed, err := schema.Bool().Parse(value)
+		if err != nil {
+			return nil, util.NewInvalidParamsError("private: " + err.Error())
+		}
+		private = &parsed
 	}
 
-	bytes, err := json.Marshal(res)
+	var since *time.Time
+	if value := params.Query["since"]; value != "" {
+		parsed, err := schema.Time().Parse(value)
+		if err != nil {
+			return nil, util.NewInvalidParamsError("since: " + err.Error())
+		}
+		since = &parsed
+	}
+
+	role := strings.ToLower(strings.TrimSpace(params.Query["role"]))
+	if role != "" {
+		if err := schema.In(repositoryRoles...)(role); err != nil {
+			return nil, util.NewInvalidParamsError("role: " + err.Error())
+		}
+	}
+
+	page := schema.Int().Must(schema.Positive()).Optional(1).Parse(params.Query["page"])
+	size := schema.Int().Must(schema.Between(1, 100)).Optional(50).Parse(params.Query["pageSize"])
+
+	res, err := p.bitbucket.ListRepositories(ctx, namespace, bitbucket.ListRepositoriesOptions{
+		Name:         strings.TrimSpace(params.Query["name"]),
+		Projects:     projects,
+		Languages:    languages,
+		Private:      private,
+		UpdatedSince: since,
+		Role:         role,
+		Query:        strings.TrimSpace(params.Query["q"]),
+		Sort:         strings.TrimSpace(params.Query["sort"]),
+		Page:         page,
+		Size:         size,
+	})
 	if err != nil {
-		return nil, util.NewInternalError()
+		return nil, err
 	}
 
-	return &mcp.ReadResourceResult{
-		Contents: []*mcp.ResourceContents{
-			{
-				URI:      req.Params.URI,
-				MIMEType: string(web.MimeApplicationJson),
-				Text:     string(bytes),
-			},
-		},
-	}, nil
+	return NewJsonResourceResult(req.Params.URI, res)
 }
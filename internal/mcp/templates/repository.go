@@ -2,12 +2,11 @@ package templates
 
 import (
 	"context"
-	"encoding/json"
 
 	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/branow/mcp-bitbucket/internal/mcp/markdown"
 	"github.com/branow/mcp-bitbucket/internal/util"
 	sch "github.com/branow/mcp-bitbucket/internal/util/schema"
-	"github.com/branow/mcp-bitbucket/internal/util/web"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -21,14 +20,14 @@ type RepositoryProvider struct {
 
 // NewRepositoryProvider creates a new provider for retrieving a single repository.
 // The provider supports the URI template:
-// mcp://bitbucket/{namespace}/repositories/{repository}?src={src}&readme={readme}
+// mcp://bitbucket/{namespace}/repositories/{repository}?src={src}&readme={readme}&format={format}
 //
 // Parameters:
 //   - bitbucket: The Bitbucket service for making API requests
 //
 // Returns a configured RepositoryProvider.
 func NewRepositoryProvider(bitbucket *bitbucket.Service) *RepositoryProvider {
-	template := "mcp://bitbucket/{namespace}/repositories/{repository}{?src,readme}"
+	template := "mcp://bitbucket/{namespace}/repositories/{repository}{?src,readme,format}"
 	parser, err := util.NewUriTemplateParser(template)
 	if err != nil {
 		panic(err)
@@ -42,29 +41,29 @@ func NewRepositoryProvider(bitbucket *bitbucket.Service) *RepositoryProvider {
 }
 
 // GetDefinition returns the MCP resource template definition for retrieving a repository.
-// The template includes URI pattern, title, description, and MIME type.
+// The template includes URI pattern, title, and description; the MIME type of each content depends on the requested format.
 func (p *RepositoryProvider) GetDefinition() *mcp.ResourceTemplate {
 	return &mcp.ResourceTemplate{
 		Name:        "repository",
 		URITemplate: p.template,
 		Title:       "Repository",
-		Description: "Retrieves a repository from the configured Bitbucket workspace, including metadata such as repository name, slug, and visibility. Optionally includes root-level source listing (src=true) and README file content (readme=true).",
-		MIMEType:    string(web.MimeApplicationJson),
+		Description: "Retrieves a repository from the configured Bitbucket workspace, including metadata such as repository name, slug, and visibility. Optionally includes root-level source listing (src=true) and README file content (readme=true). The output format can be JSON (format=json, default), Markdown (format=markdown), or both (format=both).",
 	}
 }
 
 // Handler processes read resource requests for retrieving a single repository.
 // It parses and validates the URI parameters, calls the Bitbucket service,
-// and returns the repository details as JSON.
+// and returns the repository details in the requested format.
 //
 // URI Parameters:
 //   - namespace: The workspace slug or username (required, must not be blank)
 //   - repository: The repository name/slug (required, must not be blank)
 //   - src: Include root-level source listing (optional, defaults to false)
 //   - readme: Include README file content (optional, defaults to false)
+//   - format: Output format - json, markdown, or both (optional, defaults to json)
 //
 // Returns:
-//   - ReadResourceResult containing the repository details as JSON
+//   - ReadResourceResult containing the repository details as JSON and/or Markdown
 //   - InvalidParamsError if URI parsing or validation fails
 //   - ResourceNotFoundError if the repository doesn't exist
 //   - InternalError if internal logic fails
@@ -86,24 +85,15 @@ func (p *RepositoryProvider) Handler(ctx context.Context, req *mcp.ReadResourceR
 
 	src := sch.Bool().Optional(false).Parse(params.Query["src"])
 	readme := sch.Bool().Optional(false).Parse(params.Query["readme"])
-
-	res, err := p.bitbucket.GetRepository(ctx, namespace, repository, bitbucket.GetRepositoryOptions{IncludeSource: src, IncludeReadme: readme})
+	format, err := ParseFormat(params.Query["format"])
 	if err != nil {
 		return nil, err
 	}
 
-	bytes, err := json.Marshal(res)
+	res, err := p.bitbucket.GetRepository(ctx, namespace, repository, bitbucket.GetRepositoryOptions{IncludeSource: src, IncludeReadme: readme})
 	if err != nil {
-		return nil, util.NewInternalError()
+		return nil, err
 	}
 
-	return &mcp.ReadResourceResult{
-		Contents: []*mcp.ResourceContents{
-			{
-				URI:      req.Params.URI,
-				MIMEType: string(web.MimeApplicationJson),
-				Text:     string(bytes),
-			},
-		},
-	}, nil
+	return NewResourceResult(req.Params.URI, format, res, markdown.RenderRepositoryDetails)
 }
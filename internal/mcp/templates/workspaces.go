@@ -0,0 +1,82 @@
+package templates
+
+import (
+	"context"
+
+	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/branow/mcp-bitbucket/internal/util"
+	sch "github.com/branow/mcp-bitbucket/internal/util/schema"
+	"github.com/branow/mcp-bitbucket/internal/util/web"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ListWorkspacesProvider implements the ResourceTemplateProvider interface
+// for listing the workspaces of the authenticated user.
+type ListWorkspacesProvider struct {
+	bitbucket *bitbucket.Service
+	template  string
+	uriParser *util.UriTemplateParser
+}
+
+// NewWorkspacesProvider creates a new provider for listing the current user's workspaces.
+// The provider supports the URI template:
+// mcp://bitbucket/workspaces?page={page}&size={size}
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured ListWorkspacesProvider.
+func NewWorkspacesProvider(bitbucket *bitbucket.Service) *ListWorkspacesProvider {
+	template := "mcp://bitbucket/workspaces{?page,size}"
+	parser, err := util.NewUriTemplateParser(template)
+	if err != nil {
+		panic(err)
+	}
+
+	return &ListWorkspacesProvider{
+		bitbucket: bitbucket,
+		template:  template,
+		uriParser: parser,
+	}
+}
+
+// GetDefinition returns the MCP resource template definition for listing workspaces.
+// The template includes URI pattern, title, description, and MIME type.
+func (p *ListWorkspacesProvider) GetDefinition() *mcp.ResourceTemplate {
+	return &mcp.ResourceTemplate{
+		Name:        "workspaces",
+		URITemplate: p.template,
+		Title:       "List Workspaces",
+		Description: "Retrieves the workspaces the authenticated user is a member of, with the slug, name, UUID, and the user's permission (owner, collaborator, or member) of each. The slug is the namespace of all other resources, so start here to find out which workspaces are available. Paged with page and size (1-100, defaults to 50).",
+		MIMEType:    string(web.MimeApplicationJson),
+	}
+}
+
+// Handler processes read resource requests for listing workspaces.
+// It parses and validates the URI parameters, calls the Bitbucket service,
+// and returns the workspaces as JSON.
+//
+// URI Parameters:
+//   - page: The page number (optional, defaults to 1, must be positive)
+//   - size: The number of items per page (optional, defaults to 50, must be between 1 and 100)
+//
+// Returns:
+//   - ReadResourceResult containing the list of workspaces as JSON
+//   - InvalidParamsError if URI parsing or validation fails
+//   - InternalError if internal logic fails
+func (p *ListWorkspacesProvider) Handler(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	params, err := p.uriParser.Parse(req.Params.URI)
+	if err != nil {
+		return nil, util.NewInvalidParamsError(err.Error())
+	}
+
+	page := sch.Int().Must(sch.Positive()).Optional(1).Parse(params.Query["page"])
+	size := sch.Int().Must(sch.Between(1, 100)).Optional(50).Parse(params.Query["size"])
+
+	res, err := p.bitbucket.ListWorkspaces(ctx, page, size)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewJsonResourceResult(req.Params.URI, res)
+}
@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"context"
+	"strings"
+
+	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/branow/mcp-bitbucket/internal/util"
+	sch "github.com/branow/mcp-bitbucket/internal/util/schema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ReportBuildStatusInput describes a build status to report for a commit.
+type ReportBuildStatusInput struct {
+	RepositoryInput
+	Commit      string `json:"commit" jsonschema:"The commit hash the build ran for"`
+	Key         string `json:"key" jsonschema:"Identifies the build, e.g. lint or my-agent-review; reporting the same key again updates the status"`
+	State       string `json:"state" jsonschema:"The build state: SUCCESSFUL, FAILED, INPROGRESS, or STOPPED"`
+	URL         string `json:"url" jsonschema:"Link to the build results"`
+	Name        string `json:"name,omitempty" jsonschema:"Name of the build shown in Bitbucket; defaults to the key"`
+	Description string `json:"description,omitempty" jsonschema:"Short description of the build result"`
+	RefName     string `json:"refname,omitempty" jsonschema:"The branch or tag the build ran for"`
+}
+
+// Validate checks that the repository is valid, the commit, key, and URL are not blank,
+// and the state is a known build state.
+//
+// Returns an InvalidParamsError if validation fails.
+func (in ReportBuildStatusInput) Validate() error {
+	if err := in.RepositoryInput.Validate(); err != nil {
+		return err
+	}
+	if err := sch.NotBlank()(in.Commit); err != nil {
+		return util.NewInvalidParamsError("commit: " + err.Error())
+	}
+	if err := sch.NotBlank()(in.Key); err != nil {
+		return util.NewInvalidParamsError("key: " + err.Error())
+	}
+	if err := sch.In(bitbucket.BuildStateSuccessful, bitbucket.BuildStateFailed, bitbucket.BuildStateInProgress, bitbucket.BuildStateStopped)(strings.ToUpper(in.State)); err != nil {
+		return util.NewInvalidParamsError("state: " + err.Error())
+	}
+	if err := sch.NotBlank()(in.URL); err != nil {
+		return util.NewInvalidParamsError("url: " + err.Error())
+	}
+	return nil
+}
+
+// ReportBuildStatusTool implements the ToolProvider interface
+// for reporting the build status of a commit.
+type ReportBuildStatusTool struct {
+	bitbucket *bitbucket.Service
+}
+
+// NewReportBuildStatusTool creates a new tool for reporting build statuses.
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured ReportBuildStatusTool.
+func NewReportBuildStatusTool(bitbucket *bitbucket.Service) *ReportBuildStatusTool {
+	return &ReportBuildStatusTool{bitbucket: bitbucket}
+}
+
+// GetDefinition returns the MCP tool definition for reporting a build status.
+func (t *ReportBuildStatusTool) GetDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "report_build_status",
+		Title:       "Report Build Status",
+		Description: "Reports the result of a check or build for a commit as a Bitbucket build status, shown on the commit and on pull requests from it, where it can be required to pass before merging. Reporting again with the same key updates the status, e.g. from INPROGRESS to SUCCESSFUL or FAILED. Returns the reported status.",
+	}
+}
+
+// Register adds the tool with its typed handler to the given MCP server.
+func (t *ReportBuildStatusTool) Register(server *mcp.Server) {
+	mcp.AddTool(server, t.GetDefinition(), t.Handler)
+}
+
+// Handler processes tool calls reporting a build status.
+//
+// Returns:
+//   - CommitStatus that was reported
+//   - InvalidParamsError if input validation fails
+//   - ResourceNotFoundError if the repository or commit doesn't exist
+func (t *ReportBuildStatusTool) Handler(ctx context.Context, req *mcp.CallToolRequest, input ReportBuildStatusInput) (*mcp.CallToolResult, *bitbucket.CommitStatus, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	res, err := t.bitbucket.ReportBuildStatus(ctx, input.Namespace, input.Repository, input.Commit, bitbucket.ReportBuildStatusOptions{
+		Key:         input.Key,
+		State:       strings.ToUpper(input.State),
+		Name:        input.Name,
+		URL:         input.URL,
+		Description: input.Description,
+		RefName:     input.RefName,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, res, nil
+}
@@ -0,0 +1,237 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/branow/mcp-bitbucket/internal/util"
+	sch "github.com/branow/mcp-bitbucket/internal/util/schema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ReportInput describes a Code Insights report in the tool arguments.
+type ReportInput struct {
+	ID          string            `json:"id" jsonschema:"Identifies the report, e.g. my-linter; publishing the same ID again replaces the report"`
+	Title       string            `json:"title" jsonschema:"Title of the report shown in Bitbucket"`
+	Details     string            `json:"details,omitempty" jsonschema:"Summary of the report"`
+	Type        string            `json:"type,omitempty" jsonschema:"The report type: SECURITY, COVERAGE, TEST, or BUG (default)"`
+	Result      string            `json:"result,omitempty" jsonschema:"The report result: PASSED, FAILED, or PENDING"`
+	Reporter    string            `json:"reporter,omitempty" jsonschema:"Name of the tool that produced the report"`
+	Link        string            `json:"link,omitempty" jsonschema:"Link to the full results"`
+	Annotations []AnnotationInput `json:"annotations,omitempty" jsonschema:"Findings of the report, at most 1000"`
+}
+
+// AnnotationInput describes a finding of a Code Insights report in the tool arguments.
+type AnnotationInput struct {
+	ID       string `json:"id" jsonschema:"Identifies the annotation within the report"`
+	Summary  string `json:"summary" jsonschema:"Short description of the finding"`
+	Path     string `json:"path,omitempty" jsonschema:"The file path relative to the repository root"`
+	Line     int    `json:"line,omitempty" jsonschema:"The line of the file the finding refers to"`
+	Type     string `json:"type,omitempty" jsonschema:"The annotation type: VULNERABILITY, CODE_SMELL (default), or BUG"`
+	Severity string `json:"severity,omitempty" jsonschema:"The severity: CRITICAL, HIGH, MEDIUM, or LOW"`
+	Details  string `json:"details,omitempty" jsonschema:"Detailed description of the finding"`
+	Link     string `json:"link,omitempty" jsonschema:"Link to the documentation of the finding"`
+}
+
+// PublishCodeInsightsInput describes Code Insights reports to publish for a commit,
+// given either as a SARIF log or as a single report.
+type PublishCodeInsightsInput struct {
+	RepositoryInput
+	Commit string       `json:"commit" jsonschema:"The commit hash the analysis ran for"`
+	SARIF  string       `json:"sarif,omitempty" jsonschema:"A SARIF 2.1.0 log as JSON; every run becomes a report named after its tool"`
+	Report *ReportInput `json:"report,omitempty" jsonschema:"A report with annotations to publish instead of a SARIF log"`
+}
+
+// Validate checks that the repository is valid, the commit is not blank,
+// and exactly one of the SARIF log and the report is given with valid fields.
+//
+// Returns an InvalidParamsError if validation fails.
+func (in PublishCodeInsightsInput) Validate() error {
+	if err := in.RepositoryInput.Validate(); err != nil {
+		return err
+	}
+	if err := sch.NotBlank()(in.Commit); err != nil {
+		return util.NewInvalidParamsError("commit: " + err.Error())
+	}
+	if (strings.TrimSpace(in.SARIF) == "") == (in.Report == nil) {
+		return util.NewInvalidParamsError("sarif: exactly one of sarif and report must be given")
+	}
+	if in.Report != nil {
+		return in.Report.Validate()
+	}
+	return nil
+}
+
+// Validate checks that the ID and title are not blank, the type and result are known,
+// and the annotations are valid and have unique IDs.
+//
+// Returns an InvalidParamsError if validation fails.
+func (in ReportInput) Validate() error {
+	if err := sch.NotBlank()(in.ID); err != nil {
+		return util.NewInvalidParamsError("report.id: " + err.Error())
+	}
+	if err := sch.NotBlank()(in.Title); err != nil {
+		return util.NewInvalidParamsError("report.title: " + err.Error())
+	}
+	if in.Type != "" {
+		if err := sch.In(bitbucket.ReportTypeSecurity, bitbucket.ReportTypeCoverage, bitbucket.ReportTypeTest, bitbucket.ReportTypeBug)(strings.ToUpper(in.Type)); err != nil {
+			return util.NewInvalidParamsError("report.type: " + err.Error())
+		}
+	}
+	if in.Result != "" {
+		if err := sch.In(bitbucket.ReportResultPassed, bitbucket.ReportResultFailed, bitbucket.ReportResultPending)(strings.ToUpper(in.Result)); err != nil {
+			return util.NewInvalidParamsError("report.result: " + err.Error())
+		}
+	}
+	if len(in.Annotations) > 1000 {
+		return util.NewInvalidParamsError("report.annotations: must contain at most 1000 annotations")
+	}
+
+	ids := make(map[string]bool, len(in.Annotations))
+	for i, annotation := range in.Annotations {
+		field := fmt.Sprintf("report.annotations[%d]", i)
+		if err := sch.NotBlank()(annotation.ID); err != nil {
+			return util.NewInvalidParamsError(field + ".id: " + err.Error())
+		}
+		if ids[annotation.ID] {
+			return util.NewInvalidParamsError(field + ".id: duplicate annotation " + annotation.ID)
+		}
+		ids[annotation.ID] = true
+		if err := sch.NotBlank()(annotation.Summary); err != nil {
+			return util.NewInvalidParamsError(field + ".summary: " + err.Error())
+		}
+		if annotation.Type != "" {
+			if err := sch.In(bitbucket.AnnotationTypeVulnerability, bitbucket.AnnotationTypeCodeSmell, bitbucket.AnnotationTypeBug)(strings.ToUpper(annotation.Type)); err != nil {
+				return util.NewInvalidParamsError(field + ".type: " + err.Error())
+			}
+		}
+		if annotation.Severity != "" {
+			if err := sch.In(bitbucket.SeverityCritical, bitbucket.SeverityHigh, bitbucket.SeverityMedium, bitbucket.SeverityLow)(strings.ToUpper(annotation.Severity)); err != nil {
+				return util.NewInvalidParamsError(field + ".severity: " + err.Error())
+			}
+		}
+		if annotation.Line < 0 || (annotation.Line > 0 && annotation.Path == "") {
+			return util.NewInvalidParamsError(field + ".line: must be positive and requires a path")
+		}
+	}
+	return nil
+}
+
+// mapReport converts a report from the tool arguments to a service report,
+// applying the default report and annotation types.
+func mapReport(in *ReportInput) bitbucket.Report {
+	report := bitbucket.Report{
+		ID:       in.ID,
+		Title:    in.Title,
+		Details:  in.Details,
+		Type:     strings.ToUpper(in.Type),
+		Result:   strings.ToUpper(in.Result),
+		Reporter: in.Reporter,
+		Link:     in.Link,
+	}
+	if report.Type == "" {
+		report.Type = bitbucket.ReportTypeBug
+	}
+	for _, annotation := range in.Annotations {
+		mapped := bitbucket.Annotation{
+			ID:       annotation.ID,
+			Type:     strings.ToUpper(annotation.Type),
+			Path:     annotation.Path,
+			Line:     annotation.Line,
+			Summary:  annotation.Summary,
+			Details:  annotation.Details,
+			Severity: strings.ToUpper(annotation.Severity),
+			Link:     annotation.Link,
+		}
+		if mapped.Type == "" {
+			mapped.Type = bitbucket.AnnotationTypeCodeSmell
+		}
+		report.Annotations = append(report.Annotations, mapped)
+	}
+	return report
+}
+
+// PublishedReport summarizes a published Code Insights report.
+type PublishedReport struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Type        string `json:"type"`
+	Result      string `json:"result,omitempty"`
+	Annotations int    `json:"annotations"`
+}
+
+// PublishCodeInsightsResult lists the reports published for a commit.
+type PublishCodeInsightsResult struct {
+	Commit  string            `json:"commit"`
+	Reports []PublishedReport `json:"reports"`
+}
+
+// PublishCodeInsightsTool implements the ToolProvider interface
+// for publishing Code Insights reports and annotations for a commit.
+type PublishCodeInsightsTool struct {
+	bitbucket *bitbucket.Service
+}
+
+// NewPublishCodeInsightsTool creates a new tool for publishing Code Insights reports.
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured PublishCodeInsightsTool.
+func NewPublishCodeInsightsTool(bitbucket *bitbucket.Service) *PublishCodeInsightsTool {
+	return &PublishCodeInsightsTool{bitbucket: bitbucket}
+}
+
+// GetDefinition returns the MCP tool definition for publishing Code Insights reports.
+func (t *PublishCodeInsightsTool) GetDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "publish_code_insights",
+		Title:       "Publish Code Insights",
+		Description: "Publishes the results of a static analysis or another check run outside Bitbucket as Code Insights reports for a commit, shown on the commit and on pull requests from it with findings annotated on the changed lines. Accepts either a SARIF 2.1.0 log, publishing one report per tool, or a single report with annotations. Publishing a report with the same ID again replaces it. Returns the published reports with their numbers of annotations.",
+	}
+}
+
+// Register adds the tool with its typed handler to the given MCP server.
+func (t *PublishCodeInsightsTool) Register(server *mcp.Server) {
+	mcp.AddTool(server, t.GetDefinition(), t.Handler)
+}
+
+// Handler processes tool calls publishing Code Insights reports.
+//
+// Returns:
+//   - PublishCodeInsightsResult listing the published reports
+//   - InvalidParamsError if input validation fails or the SARIF log is malformed
+//   - ResourceNotFoundError if the repository or commit doesn't exist
+func (t *PublishCodeInsightsTool) Handler(ctx context.Context, req *mcp.CallToolRequest, input PublishCodeInsightsInput) (*mcp.CallToolResult, *PublishCodeInsightsResult, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	var reports []bitbucket.Report
+	if input.Report != nil {
+		reports = []bitbucket.Report{mapReport(input.Report)}
+	} else {
+		var err error
+		if reports, err = bitbucket.ParseSARIF([]byte(input.SARIF)); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	res := &PublishCodeInsightsResult{Commit: input.Commit, Reports: []PublishedReport{}}
+	for _, report := range reports {
+		published, err := t.bitbucket.PublishReport(ctx, input.Namespace, input.Repository, input.Commit, report)
+		if err != nil {
+			return nil, nil, err
+		}
+		res.Reports = append(res.Reports, PublishedReport{
+			ID:          published.ID,
+			Title:       published.Title,
+			Type:        published.Type,
+			Result:      published.Result,
+			Annotations: len(published.Annotations),
+		})
+	}
+	return nil, res, nil
+}
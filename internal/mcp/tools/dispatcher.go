@@ -0,0 +1,68 @@
+// Package tools provides MCP tool providers and dispatchers.
+//
+// This package defines the interface for tools and manages
+// registering them with the MCP server.
+package tools
+
+import (
+	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ToolProvider defines the interface for MCP tool providers.
+// Implementations provide the tool definition and register it with a typed handler,
+// so that input is unmarshaled and validated against the schema inferred from its type.
+type ToolProvider interface {
+	GetDefinition() *mcp.Tool
+	Register(*mcp.Server)
+}
+
+// ToolDispatcher manages multiple tool providers and registers them with an MCP server.
+type ToolDispatcher struct {
+	providers []ToolProvider
+}
+
+// NewToolDispatcher creates a new dispatcher with all available tool providers.
+// Currently includes the pull request creation, update, merge, review, and task tools,
+// the branch and tag management tools, the pipeline trigger, stop, restart, and wait tools,
+// the build status report and Code Insights publishing tools, the issue creation and comment tools, and the code search tool.
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service used by tool providers
+//
+// Returns a dispatcher ready to register tools with an MCP server.
+func NewToolDispatcher(bitbucket *bitbucket.Service) *ToolDispatcher {
+	return &ToolDispatcher{
+		providers: []ToolProvider{
+			NewCreatePullRequestTool(bitbucket),
+			NewUpdatePullRequestTool(bitbucket),
+			NewMergePullRequestTool(bitbucket),
+			NewApprovePullRequestTool(bitbucket),
+			NewUnapprovePullRequestTool(bitbucket),
+			NewRequestChangesTool(bitbucket),
+			NewCreatePullRequestTaskTool(bitbucket),
+			NewResolvePullRequestTaskTool(bitbucket),
+			NewDeletePullRequestTaskTool(bitbucket),
+			NewCreateBranchTool(bitbucket),
+			NewDeleteBranchTool(bitbucket),
+			NewCreateTagTool(bitbucket),
+			NewDeleteTagTool(bitbucket),
+			NewTriggerPipelineTool(bitbucket),
+			NewStopPipelineTool(bitbucket),
+			NewRestartPipelineTool(bitbucket),
+			NewWaitForPipelineTool(bitbucket),
+			NewReportBuildStatusTool(bitbucket),
+			NewPublishCodeInsightsTool(bitbucket),
+			NewCreateIssueTool(bitbucket),
+			NewAddIssueCommentTool(bitbucket),
+			NewSearchCodeTool(bitbucket),
+		},
+	}
+}
+
+// Dispatch registers all tool providers with the given MCP server.
+func (d *ToolDispatcher) Dispatch(server *mcp.Server) {
+	for _, provider := range d.providers {
+		provider.Register(server)
+	}
+}
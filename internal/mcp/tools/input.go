@@ -0,0 +1,63 @@
+package tools
+
+import (
+	"github.com/branow/mcp-bitbucket/internal/util"
+	sch "github.com/branow/mcp-bitbucket/internal/util/schema"
+)
+
+// RepositoryInput identifies a repository in the tool arguments.
+type RepositoryInput struct {
+	Namespace  string `json:"namespace" jsonschema:"The workspace slug or username"`
+	Repository string `json:"repository" jsonschema:"The repository name/slug"`
+}
+
+// Validate checks that the namespace and repository are not blank.
+//
+// Returns an InvalidParamsError if validation fails.
+func (in RepositoryInput) Validate() error {
+	if err := sch.NotBlank()(in.Namespace); err != nil {
+		return util.NewInvalidParamsError("namespace: " + err.Error())
+	}
+	if err := sch.NotBlank()(in.Repository); err != nil {
+		return util.NewInvalidParamsError("repository: " + err.Error())
+	}
+	return nil
+}
+
+// PullRequestInput identifies a pull request in the tool arguments.
+type PullRequestInput struct {
+	RepositoryInput
+	PullRequestID int `json:"pull_request_id" jsonschema:"The pull request ID"`
+}
+
+// Validate checks that the repository is valid and the pull request ID is positive.
+//
+// Returns an InvalidParamsError if validation fails.
+func (in PullRequestInput) Validate() error {
+	if err := in.RepositoryInput.Validate(); err != nil {
+		return err
+	}
+	if err := sch.Positive()(in.PullRequestID); err != nil {
+		return util.NewInvalidParamsError("pull_request_id: " + err.Error())
+	}
+	return nil
+}
+
+// IssueInput identifies an issue in the tool arguments.
+type IssueInput struct {
+	RepositoryInput
+	IssueID int `json:"issue_id" jsonschema:"The issue ID"`
+}
+
+// Validate checks that the repository is valid and the issue ID is positive.
+//
+// Returns an InvalidParamsError if validation fails.
+func (in IssueInput) Validate() error {
+	if err := in.RepositoryInput.Validate(); err != nil {
+		return err
+	}
+	if err := sch.Positive()(in.IssueID); err != nil {
+		return util.NewInvalidParamsError("issue_id: " + err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package tools
+
+import (
+	"context"
+	"strings"
+
+	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/branow/mcp-bitbucket/internal/util"
+	sch "github.com/branow/mcp-bitbucket/internal/util/schema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// CreateIssueInput describes an issue to create in a repository's issue tracker.
+type CreateIssueInput struct {
+	RepositoryInput
+	Title     string `json:"title" jsonschema:"The issue title"`
+	Content   string `json:"content,omitempty" jsonschema:"The issue description (Markdown)"`
+	Kind      string `json:"kind,omitempty" jsonschema:"The issue kind: bug (default), enhancement, proposal, or task"`
+	Priority  string `json:"priority,omitempty" jsonschema:"The issue priority: trivial, minor, major (default), critical, or blocker"`
+	Assignee  string `json:"assignee,omitempty" jsonschema:"The assignee, by nickname, account ID, or UUID"`
+	Component string `json:"component,omitempty" jsonschema:"The name of the component the issue belongs to"`
+	Milestone string `json:"milestone,omitempty" jsonschema:"The name of the milestone the issue is planned for"`
+	Version   string `json:"version,omitempty" jsonschema:"The name of the version the issue affects"`
+}
+
+// Validate checks that the repository is valid, the title is not blank,
+// and the kind and priority are known if given.
+//
+// Returns an InvalidParamsError if validation fails.
+func (in CreateIssueInput) Validate() error {
+	if err := in.RepositoryInput.Validate(); err != nil {
+		return err
+	}
+	if err := sch.NotBlank()(in.Title); err != nil {
+		return util.NewInvalidParamsError("title: " + err.Error())
+	}
+	if in.Kind != "" {
+		if err := sch.In(bitbucket.IssueKindBug, bitbucket.IssueKindEnhancement, bitbucket.IssueKindProposal, bitbucket.IssueKindTask)(strings.ToLower(in.Kind)); err != nil {
+			return util.NewInvalidParamsError("kind: " + err.Error())
+		}
+	}
+	if in.Priority != "" {
+		if err := sch.In(bitbucket.IssuePriorityTrivial, bitbucket.IssuePriorityMinor, bitbucket.IssuePriorityMajor, bitbucket.IssuePriorityCritical, bitbucket.IssuePriorityBlocker)(strings.ToLower(in.Priority)); err != nil {
+			return util.NewInvalidParamsError("priority: " + err.Error())
+		}
+	}
+	return nil
+}
+
+// CreateIssueTool implements the ToolProvider interface
+// for creating an issue in a repository's issue tracker.
+type CreateIssueTool struct {
+	bitbucket *bitbucket.Service
+}
+
+// NewCreateIssueTool creates a new tool for creating issues.
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured CreateIssueTool.
+func NewCreateIssueTool(bitbucket *bitbucket.Service) *CreateIssueTool {
+	return &CreateIssueTool{bitbucket: bitbucket}
+}
+
+// GetDefinition returns the MCP tool definition for creating an issue.
+func (t *CreateIssueTool) GetDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "create_issue",
+		Title:       "Create Issue",
+		Description: "Creates an issue in the issue tracker of a repository. The assignee is looked up among the workspace members by nickname, account ID, or UUID. Returns the created issue.",
+	}
+}
+
+// Register adds the tool with its typed handler to the given MCP server.
+func (t *CreateIssueTool) Register(server *mcp.Server) {
+	mcp.AddTool(server, t.GetDefinition(), t.Handler)
+}
+
+// Handler processes tool calls creating an issue.
+//
+// Returns:
+//   - Issue that was created
+//   - InvalidParamsError if input validation fails or the assignee cannot be found
+//   - ResourceNotFoundError if the repository or its issue tracker doesn't exist
+func (t *CreateIssueTool) Handler(ctx context.Context, req *mcp.CallToolRequest, input CreateIssueInput) (*mcp.CallToolResult, *bitbucket.Issue, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	res, err := t.bitbucket.CreateIssue(ctx, input.Namespace, input.Repository, bitbucket.CreateIssueOptions{
+		Title:     input.Title,
+		Content:   input.Content,
+		Kind:      strings.ToLower(input.Kind),
+		Priority:  strings.ToLower(input.Priority),
+		Assignee:  strings.TrimSpace(input.Assignee),
+		Component: input.Component,
+		Milestone: input.Milestone,
+		Version:   input.Version,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, res, nil
+}
+
+// AddIssueCommentInput describes a comment to add to an issue.
+type AddIssueCommentInput struct {
+	IssueInput
+	Content string `json:"content" jsonschema:"The comment text (Markdown)"`
+}
+
+// AddIssueCommentTool implements the ToolProvider interface
+// for adding a comment to an issue.
+type AddIssueCommentTool struct {
+	bitbucket *bitbucket.Service
+}
+
+// NewAddIssueCommentTool creates a new tool for commenting on issues.
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured AddIssueCommentTool.
+func NewAddIssueCommentTool(bitbucket *bitbucket.Service) *AddIssueCommentTool {
+	return &AddIssueCommentTool{bitbucket: bitbucket}
+}
+
+// GetDefinition returns the MCP tool definition for adding an issue comment.
+func (t *AddIssueCommentTool) GetDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "add_issue_comment",
+		Title:       "Add Issue Comment",
+		Description: "Adds a comment to an issue in the issue tracker of a repository. Returns the created comment.",
+	}
+}
+
+// Register adds the tool with its typed handler to the given MCP server.
+func (t *AddIssueCommentTool) Register(server *mcp.Server) {
+	mcp.AddTool(server, t.GetDefinition(), t.Handler)
+}
+
+// Handler processes tool calls adding an issue comment.
+//
+// Returns:
+//   - IssueComment that was created
+//   - InvalidParamsError if input validation fails
+//   - ResourceNotFoundError if the issue doesn't exist
+func (t *AddIssueCommentTool) Handler(ctx context.Context, req *mcp.CallToolRequest, input AddIssueCommentInput) (*mcp.CallToolResult, *bitbucket.IssueComment, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if err := sch.NotBlank()(input.Content); err != nil {
+		return nil, nil, util.NewInvalidParamsError("content: " + err.Error())
+	}
+
+	res, err := t.bitbucket.CreateIssueComment(ctx, input.Namespace, input.Repository, input.IssueID, input.Content)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, res, nil
+}
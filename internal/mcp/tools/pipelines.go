@@ -0,0 +1,349 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/branow/mcp-bitbucket/internal/util"
+	sch "github.com/branow/mcp-bitbucket/internal/util/schema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// PipelineInput identifies a pipeline in the tool arguments.
+type PipelineInput struct {
+	RepositoryInput
+	Pipeline string `json:"pipeline" jsonschema:"The pipeline build number (e.g. 42) or UUID"`
+}
+
+// Validate checks that the repository is valid and the pipeline is not blank.
+//
+// Returns an InvalidParamsError if validation fails.
+func (in PipelineInput) Validate() error {
+	if err := in.RepositoryInput.Validate(); err != nil {
+		return err
+	}
+	if err := sch.NotBlank()(in.Pipeline); err != nil {
+		return util.NewInvalidParamsError("pipeline: " + err.Error())
+	}
+	return nil
+}
+
+// PipelineVariableInput describes a variable passed to a pipeline run.
+type PipelineVariableInput struct {
+	Key     string `json:"key" jsonschema:"The variable name"`
+	Value   string `json:"value" jsonschema:"The variable value"`
+	Secured bool   `json:"secured,omitempty" jsonschema:"Whether the value is secret and hidden in logs and API responses"`
+}
+
+// validateVariables checks that every variable has a non-blank, unique key.
+//
+// Returns an InvalidParamsError if validation fails.
+func validateVariables(variables []PipelineVariableInput) error {
+	keys := make(map[string]bool, len(variables))
+	for i, variable := range variables {
+		if err := sch.NotBlank()(variable.Key); err != nil {
+			return util.NewInvalidParamsError(fmt.Sprintf("variables[%d].key: %s", i, err.Error()))
+		}
+		if keys[variable.Key] {
+			return util.NewInvalidParamsError(fmt.Sprintf("variables[%d].key: duplicate key %s", i, variable.Key))
+		}
+		keys[variable.Key] = true
+	}
+	return nil
+}
+
+// mapVariables converts the variables of the tool arguments to the service type.
+func mapVariables(variables []PipelineVariableInput) []bitbucket.PipelineVariable {
+	result := make([]bitbucket.PipelineVariable, len(variables))
+	for i, variable := range variables {
+		result[i] = bitbucket.PipelineVariable{Key: variable.Key, Value: variable.Value, Secured: variable.Secured}
+	}
+	return result
+}
+
+// TriggerPipelineInput describes the target of a new pipeline run.
+type TriggerPipelineInput struct {
+	RepositoryInput
+	Branch    string                  `json:"branch,omitempty" jsonschema:"The branch to run the pipeline on"`
+	Commit    string                  `json:"commit,omitempty" jsonschema:"The commit hash to run the pipeline on; pins the commit when combined with branch"`
+	Custom    string                  `json:"custom,omitempty" jsonschema:"The name of a custom pipeline defined under pipelines.custom in bitbucket-pipelines.yml"`
+	Variables []PipelineVariableInput `json:"variables,omitempty" jsonschema:"Variables passed to the pipeline"`
+}
+
+// Validate checks that the repository is valid, a branch or commit is given, and the variables are valid.
+//
+// Returns an InvalidParamsError if validation fails.
+func (in TriggerPipelineInput) Validate() error {
+	if err := in.RepositoryInput.Validate(); err != nil {
+		return err
+	}
+	if sch.NotBlank()(in.Branch) != nil && sch.NotBlank()(in.Commit) != nil {
+		return util.NewInvalidParamsError("branch: either branch or commit is required")
+	}
+	return validateVariables(in.Variables)
+}
+
+// RestartPipelineInput identifies a completed pipeline to run again.
+type RestartPipelineInput struct {
+	PipelineInput
+	Variables []PipelineVariableInput `json:"variables,omitempty" jsonschema:"Variables to add to or override in the original run; secured variables of the original run must be passed again"`
+}
+
+// Validate checks that the pipeline and the variables are valid.
+//
+// Returns an InvalidParamsError if validation fails.
+func (in RestartPipelineInput) Validate() error {
+	if err := in.PipelineInput.Validate(); err != nil {
+		return err
+	}
+	return validateVariables(in.Variables)
+}
+
+// WaitForPipelineInput identifies a pipeline to wait for and how long to wait.
+type WaitForPipelineInput struct {
+	PipelineInput
+	TimeoutSeconds int `json:"timeout_seconds,omitempty" jsonschema:"How long to wait for the pipeline to complete, in seconds; defaults to 600, at most 3600"`
+}
+
+// Validate checks that the pipeline is valid and the timeout is within bounds.
+//
+// Returns an InvalidParamsError if validation fails.
+func (in WaitForPipelineInput) Validate() error {
+	if err := in.PipelineInput.Validate(); err != nil {
+		return err
+	}
+	if in.TimeoutSeconds != 0 {
+		if err := sch.Between(1, 3600)(in.TimeoutSeconds); err != nil {
+			return util.NewInvalidParamsError("timeout_seconds: " + err.Error())
+		}
+	}
+	return nil
+}
+
+// PipelineRunResult reports a started or stopped pipeline run
+// with the URI of the pipeline resource to follow its progress.
+type PipelineRunResult struct {
+	UUID        string `json:"uuid"`
+	BuildNumber int    `json:"build_number,omitempty"`
+	State       string `json:"state,omitempty"`
+	URI         string `json:"uri"`
+}
+
+// newPipelineRunResult creates a PipelineRunResult pointing to the pipeline resource.
+func newPipelineRunResult(namespace string, repository string, pipeline *bitbucket.Pipeline) *PipelineRunResult {
+	return &PipelineRunResult{
+		UUID:        pipeline.UUID,
+		BuildNumber: pipeline.BuildNumber,
+		State:       pipeline.State,
+		URI:         pipelineURI(namespace, repository, pipeline.UUID),
+	}
+}
+
+// pipelineURI builds the URI of the pipeline resource, percent-encoding the braces of the UUID.
+func pipelineURI(namespace string, repository string, uuid string) string {
+	return fmt.Sprintf("mcp://bitbucket/%s/repositories/%s/pipelines/%s", url.PathEscape(namespace), url.PathEscape(repository), url.PathEscape(uuid))
+}
+
+// TriggerPipelineTool implements the ToolProvider interface
+// for starting a pipeline run.
+type TriggerPipelineTool struct {
+	bitbucket *bitbucket.Service
+}
+
+// NewTriggerPipelineTool creates a new tool for starting pipeline runs.
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured TriggerPipelineTool.
+func NewTriggerPipelineTool(bitbucket *bitbucket.Service) *TriggerPipelineTool {
+	return &TriggerPipelineTool{bitbucket: bitbucket}
+}
+
+// GetDefinition returns the MCP tool definition for starting a pipeline run.
+func (t *TriggerPipelineTool) GetDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "trigger_pipeline",
+		Title:       "Trigger Pipeline",
+		Description: "Starts a Bitbucket Pipelines run on a branch, a commit, or a commit of a branch. Runs the pipeline configured for the branch, or the custom pipeline named by custom, with optional variables. Returns the UUID, build number, and state of the run, and the URI of the pipeline resource to follow it.",
+	}
+}
+
+// Register adds the tool with its typed handler to the given MCP server.
+func (t *TriggerPipelineTool) Register(server *mcp.Server) {
+	mcp.AddTool(server, t.GetDefinition(), t.Handler)
+}
+
+// Handler processes tool calls starting a pipeline run.
+//
+// Returns:
+//   - PipelineRunResult of the started run
+//   - InvalidParamsError if input validation fails or Bitbucket rejects the target
+//   - ResourceNotFoundError if the repository, branch, or commit doesn't exist
+func (t *TriggerPipelineTool) Handler(ctx context.Context, req *mcp.CallToolRequest, input TriggerPipelineInput) (*mcp.CallToolResult, *PipelineRunResult, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	res, err := t.bitbucket.TriggerPipeline(ctx, input.Namespace, input.Repository, bitbucket.TriggerPipelineOptions{
+		Branch:    input.Branch,
+		Commit:    input.Commit,
+		Custom:    input.Custom,
+		Variables: mapVariables(input.Variables),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, newPipelineRunResult(input.Namespace, input.Repository, res), nil
+}
+
+// StopPipelineTool implements the ToolProvider interface
+// for stopping a running pipeline.
+type StopPipelineTool struct {
+	bitbucket *bitbucket.Service
+}
+
+// NewStopPipelineTool creates a new tool for stopping pipelines.
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured StopPipelineTool.
+func NewStopPipelineTool(bitbucket *bitbucket.Service) *StopPipelineTool {
+	return &StopPipelineTool{bitbucket: bitbucket}
+}
+
+// GetDefinition returns the MCP tool definition for stopping a pipeline.
+func (t *StopPipelineTool) GetDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "stop_pipeline",
+		Title:       "Stop Pipeline",
+		Description: "Stops a running Bitbucket Pipelines run given by build number or UUID. Stopping is asynchronous; follow the returned URI of the pipeline resource until its state is COMPLETED with result STOPPED.",
+	}
+}
+
+// Register adds the tool with its typed handler to the given MCP server.
+func (t *StopPipelineTool) Register(server *mcp.Server) {
+	mcp.AddTool(server, t.GetDefinition(), t.Handler)
+}
+
+// Handler processes tool calls stopping a pipeline.
+//
+// Returns:
+//   - PipelineRunResult of the stopped run
+//   - InvalidParamsError if input validation fails or the pipeline has already completed
+//   - ResourceNotFoundError if the pipeline doesn't exist
+func (t *StopPipelineTool) Handler(ctx context.Context, req *mcp.CallToolRequest, input PipelineInput) (*mcp.CallToolResult, *PipelineRunResult, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	uuid, err := t.bitbucket.StopPipeline(ctx, input.Namespace, input.Repository, input.Pipeline)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, &PipelineRunResult{UUID: uuid, URI: pipelineURI(input.Namespace, input.Repository, uuid)}, nil
+}
+
+// RestartPipelineTool implements the ToolProvider interface
+// for running a completed pipeline again.
+type RestartPipelineTool struct {
+	bitbucket *bitbucket.Service
+}
+
+// NewRestartPipelineTool creates a new tool for restarting pipelines.
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured RestartPipelineTool.
+func NewRestartPipelineTool(bitbucket *bitbucket.Service) *RestartPipelineTool {
+	return &RestartPipelineTool{bitbucket: bitbucket}
+}
+
+// GetDefinition returns the MCP tool definition for restarting a pipeline.
+func (t *RestartPipelineTool) GetDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "restart_pipeline",
+		Title:       "Restart Pipeline",
+		Description: "Starts a new run of a completed, typically failed, Bitbucket Pipelines run given by build number or UUID, on the same commit with the same pipeline and variables. Every step of the pipeline runs again, not only the failed steps of the original run. Values of secured variables cannot be read back and must be passed again in variables. Returns the UUID, build number, and state of the new run, and the URI of the pipeline resource to follow it.",
+	}
+}
+
+// Register adds the tool with its typed handler to the given MCP server.
+func (t *RestartPipelineTool) Register(server *mcp.Server) {
+	mcp.AddTool(server, t.GetDefinition(), t.Handler)
+}
+
+// Handler processes tool calls restarting a pipeline.
+//
+// Returns:
+//   - PipelineRunResult of the new run
+//   - InvalidParamsError if input validation fails or the pipeline is still running
+//   - ResourceNotFoundError if the pipeline doesn't exist
+func (t *RestartPipelineTool) Handler(ctx context.Context, req *mcp.CallToolRequest, input RestartPipelineInput) (*mcp.CallToolResult, *PipelineRunResult, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	res, err := t.bitbucket.RestartPipeline(ctx, input.Namespace, input.Repository, input.Pipeline, mapVariables(input.Variables))
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, newPipelineRunResult(input.Namespace, input.Repository, res), nil
+}
+
+// WaitForPipelineTool implements the ToolProvider interface
+// for waiting until a pipeline completes.
+type WaitForPipelineTool struct {
+	bitbucket *bitbucket.Service
+}
+
+// NewWaitForPipelineTool creates a new tool for waiting for pipelines.
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured WaitForPipelineTool.
+func NewWaitForPipelineTool(bitbucket *bitbucket.Service) *WaitForPipelineTool {
+	return &WaitForPipelineTool{bitbucket: bitbucket}
+}
+
+// GetDefinition returns the MCP tool definition for waiting for a pipeline.
+func (t *WaitForPipelineTool) GetDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "wait_for_pipeline",
+		Title:       "Wait for Pipeline",
+		Description: "Waits for a Bitbucket Pipelines run given by build number or UUID to complete, polling its state with backoff for up to timeout_seconds (defaults to 600). Sends progress notifications with the state of every step if the request has a progress token. Returns a summary with completed (false if the wait timed out), passed (true only if the pipeline succeeded), the pipeline, and its steps, including the last 16 KB of the log of every failed step.",
+	}
+}
+
+// Register adds the tool with its typed handler to the given MCP server.
+func (t *WaitForPipelineTool) Register(server *mcp.Server) {
+	mcp.AddTool(server, t.GetDefinition(), t.Handler)
+}
+
+// Handler processes tool calls waiting for a pipeline.
+// Waiting stops early when the request is canceled.
+//
+// Returns:
+//   - PipelineSummary of the completed or still running pipeline
+//   - InvalidParamsError if input validation fails
+//   - ResourceNotFoundError if the pipeline doesn't exist
+func (t *WaitForPipelineTool) Handler(ctx context.Context, req *mcp.CallToolRequest, input WaitForPipelineInput) (*mcp.CallToolResult, *bitbucket.PipelineSummary, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	res, err := t.bitbucket.WaitForPipeline(ctx, input.Namespace, input.Repository, input.Pipeline, bitbucket.WaitForPipelineOptions{
+		Timeout:  time.Duration(input.TimeoutSeconds) * time.Second,
+		Progress: progressNotifier(ctx, req),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, res, nil
+}
@@ -0,0 +1,36 @@
+package tools
+
+import (
+	"context"
+	"log/slog"
+
+	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/branow/mcp-bitbucket/internal/util"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// progressNotifier creates a ProgressFunc that sends progress notifications
+// to the session calling the tool.
+//
+// Returns nil if the caller did not request progress notifications with a progress token.
+func progressNotifier(ctx context.Context, req *mcp.CallToolRequest) bitbucket.ProgressFunc {
+	if req == nil || req.Session == nil || req.Params == nil {
+		return nil
+	}
+	token := req.Params.GetProgressToken()
+	if token == nil {
+		return nil
+	}
+
+	return func(progress float64, total float64, message string) {
+		err := req.Session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+			ProgressToken: token,
+			Progress:      progress,
+			Total:         total,
+			Message:       message,
+		})
+		if err != nil {
+			slog.Warn("Failed to send progress notification", util.NewLogArgsExtractor().AddError(err).Extract()...)
+		}
+	}
+}
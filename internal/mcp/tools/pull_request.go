@@ -0,0 +1,284 @@
+package tools
+
+import (
+	"context"
+
+	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/branow/mcp-bitbucket/internal/util"
+	sch "github.com/branow/mcp-bitbucket/internal/util/schema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// CreatePullRequestInput describes a pull request to create.
+type CreatePullRequestInput struct {
+	RepositoryInput
+	Title                string   `json:"title" jsonschema:"The pull request title"`
+	Description          string   `json:"description,omitempty" jsonschema:"The pull request description (Markdown)"`
+	Source               string   `json:"source" jsonschema:"The source branch name"`
+	Destination          string   `json:"destination,omitempty" jsonschema:"The destination branch name; defaults to the main branch"`
+	CloseSourceBranch    *bool    `json:"close_source_branch,omitempty" jsonschema:"Whether to close the source branch after merging"`
+	Draft                *bool    `json:"draft,omitempty" jsonschema:"Whether to create the pull request as a draft"`
+	Reviewers            []string `json:"reviewers,omitempty" jsonschema:"Additional reviewers, by nickname, account ID, or UUID"`
+	SkipDefaultReviewers bool     `json:"skip_default_reviewers,omitempty" jsonschema:"Do not add the repository's default reviewers"`
+}
+
+// Validate checks that the repository is valid and the title, source branch, and reviewers are not blank.
+//
+// Returns an InvalidParamsError if validation fails.
+func (in CreatePullRequestInput) Validate() error {
+	if err := in.RepositoryInput.Validate(); err != nil {
+		return err
+	}
+	if err := sch.NotBlank()(in.Title); err != nil {
+		return util.NewInvalidParamsError("title: " + err.Error())
+	}
+	if err := sch.NotBlank()(in.Source); err != nil {
+		return util.NewInvalidParamsError("source: " + err.Error())
+	}
+	for _, reviewer := range in.Reviewers {
+		if err := sch.NotBlank()(reviewer); err != nil {
+			return util.NewInvalidParamsError("reviewers: " + err.Error())
+		}
+	}
+	return nil
+}
+
+// CreatePullRequestTool implements the ToolProvider interface
+// for creating a pull request with the repository's default reviewers.
+type CreatePullRequestTool struct {
+	bitbucket *bitbucket.Service
+}
+
+// NewCreatePullRequestTool creates a new tool for creating pull requests.
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured CreatePullRequestTool.
+func NewCreatePullRequestTool(bitbucket *bitbucket.Service) *CreatePullRequestTool {
+	return &CreatePullRequestTool{bitbucket: bitbucket}
+}
+
+// GetDefinition returns the MCP tool definition for creating a pull request.
+func (t *CreatePullRequestTool) GetDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "create_pull_request",
+		Title:       "Create Pull Request",
+		Description: "Creates a pull request from a source branch. The repository's effective default reviewers (including the ones inherited from the project) are added automatically unless skip_default_reviewers is true; additional reviewers can be given by nickname, account ID, or UUID. Returns the created pull request.",
+	}
+}
+
+// Register adds the tool with its typed handler to the given MCP server.
+func (t *CreatePullRequestTool) Register(server *mcp.Server) {
+	mcp.AddTool(server, t.GetDefinition(), t.Handler)
+}
+
+// Handler processes tool calls creating a pull request.
+//
+// Returns:
+//   - PullRequest that was created
+//   - InvalidParamsError if input validation fails or a reviewer cannot be found
+//   - ResourceNotFoundError if the repository doesn't exist
+func (t *CreatePullRequestTool) Handler(ctx context.Context, req *mcp.CallToolRequest, input CreatePullRequestInput) (*mcp.CallToolResult, *bitbucket.PullRequest, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	res, err := t.bitbucket.CreatePullRequest(ctx, input.Namespace, input.Repository, bitbucket.CreatePullRequestOptions{
+		Title:                input.Title,
+		Description:          input.Description,
+		Source:               input.Source,
+		Destination:          input.Destination,
+		CloseSourceBranch:    input.CloseSourceBranch,
+		Draft:                input.Draft,
+		Reviewers:            input.Reviewers,
+		SkipDefaultReviewers: input.SkipDefaultReviewers,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, res, nil
+}
+
+// UpdatePullRequestInput describes the changes to apply to a pull request.
+// Omitted fields are left unchanged.
+type UpdatePullRequestInput struct {
+	PullRequestInput
+	Title             *string  `json:"title,omitempty" jsonschema:"The new title"`
+	Description       *string  `json:"description,omitempty" jsonschema:"The new description (Markdown)"`
+	Destination       *string  `json:"destination,omitempty" jsonschema:"The new destination branch name"`
+	CloseSourceBranch *bool    `json:"close_source_branch,omitempty" jsonschema:"Whether to close the source branch after merging"`
+	Draft             *bool    `json:"draft,omitempty" jsonschema:"Whether the pull request is a draft"`
+	AddReviewers      []string `json:"add_reviewers,omitempty" jsonschema:"Reviewers to add, by nickname, account ID, or UUID"`
+	RemoveReviewers   []string `json:"remove_reviewers,omitempty" jsonschema:"Reviewers to remove, by nickname, account ID, or UUID"`
+}
+
+// Validate checks that the pull request is valid, that the provided fields are not blank,
+// and that at least one change is requested.
+//
+// Returns an InvalidParamsError if validation fails.
+func (in UpdatePullRequestInput) Validate() error {
+	if err := in.PullRequestInput.Validate(); err != nil {
+		return err
+	}
+	if in.Title != nil {
+		if err := sch.NotBlank()(*in.Title); err != nil {
+			return util.NewInvalidParamsError("title: " + err.Error())
+		}
+	}
+	if in.Destination != nil {
+		if err := sch.NotBlank()(*in.Destination); err != nil {
+			return util.NewInvalidParamsError("destination: " + err.Error())
+		}
+	}
+	for _, reviewer := range in.AddReviewers {
+		if err := sch.NotBlank()(reviewer); err != nil {
+			return util.NewInvalidParamsError("add_reviewers: " + err.Error())
+		}
+	}
+	for _, reviewer := range in.RemoveReviewers {
+		if err := sch.NotBlank()(reviewer); err != nil {
+			return util.NewInvalidParamsError("remove_reviewers: " + err.Error())
+		}
+	}
+	if in.Title == nil && in.Description == nil && in.Destination == nil && in.CloseSourceBranch == nil &&
+		in.Draft == nil && len(in.AddReviewers) == 0 && len(in.RemoveReviewers) == 0 {
+		return util.NewInvalidParamsError("no changes to apply")
+	}
+	return nil
+}
+
+// UpdatePullRequestTool implements the ToolProvider interface
+// for updating a pull request and managing its reviewers.
+type UpdatePullRequestTool struct {
+	bitbucket *bitbucket.Service
+}
+
+// NewUpdatePullRequestTool creates a new tool for updating pull requests.
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured UpdatePullRequestTool.
+func NewUpdatePullRequestTool(bitbucket *bitbucket.Service) *UpdatePullRequestTool {
+	return &UpdatePullRequestTool{bitbucket: bitbucket}
+}
+
+// GetDefinition returns the MCP tool definition for updating a pull request.
+func (t *UpdatePullRequestTool) GetDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "update_pull_request",
+		Title:       "Update Pull Request",
+		Description: "Updates the title, description, destination branch, draft flag, or close_source_branch setting of a pull request, and adds or removes reviewers by nickname, account ID, or UUID. Omitted fields are left unchanged. Returns the updated pull request.",
+	}
+}
+
+// Register adds the tool with its typed handler to the given MCP server.
+func (t *UpdatePullRequestTool) Register(server *mcp.Server) {
+	mcp.AddTool(server, t.GetDefinition(), t.Handler)
+}
+
+// Handler processes tool calls updating a pull request.
+//
+// Returns:
+//   - PullRequest with the applied changes
+//   - InvalidParamsError if input validation fails or a reviewer cannot be found
+//   - ResourceNotFoundError if the pull request doesn't exist
+func (t *UpdatePullRequestTool) Handler(ctx context.Context, req *mcp.CallToolRequest, input UpdatePullRequestInput) (*mcp.CallToolResult, *bitbucket.PullRequest, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	res, err := t.bitbucket.UpdatePullRequest(ctx, input.Namespace, input.Repository, input.PullRequestID, bitbucket.UpdatePullRequestOptions{
+		Title:             input.Title,
+		Description:       input.Description,
+		Destination:       input.Destination,
+		CloseSourceBranch: input.CloseSourceBranch,
+		Draft:             input.Draft,
+		AddReviewers:      input.AddReviewers,
+		RemoveReviewers:   input.RemoveReviewers,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, res, nil
+}
+
+// mergeStrategies lists the merge strategies supported by Bitbucket.
+var mergeStrategies = []string{"merge_commit", "squash", "fast_forward", "squash_fast_forward", "rebase_fast_forward", "rebase_merge"}
+
+// MergePullRequestInput describes how to merge a pull request.
+type MergePullRequestInput struct {
+	PullRequestInput
+	Strategy          string `json:"strategy,omitempty" jsonschema:"The merge strategy: merge_commit, squash, fast_forward, squash_fast_forward, rebase_fast_forward, or rebase_merge; defaults to the destination branch's default strategy"`
+	Message           string `json:"message,omitempty" jsonschema:"The merge commit message; defaults to the message generated by Bitbucket"`
+	CloseSourceBranch *bool  `json:"close_source_branch,omitempty" jsonschema:"Whether to close the source branch after merging; defaults to the pull request setting"`
+}
+
+// Validate checks that the pull request is valid and the strategy is supported.
+//
+// Returns an InvalidParamsError if validation fails.
+func (in MergePullRequestInput) Validate() error {
+	if err := in.PullRequestInput.Validate(); err != nil {
+		return err
+	}
+	if in.Strategy != "" {
+		if err := sch.In(mergeStrategies...)(in.Strategy); err != nil {
+			return util.NewInvalidParamsError("strategy: " + err.Error())
+		}
+	}
+	return nil
+}
+
+// MergePullRequestTool implements the ToolProvider interface
+// for merging a pull request after checking that it can be merged.
+type MergePullRequestTool struct {
+	bitbucket *bitbucket.Service
+}
+
+// NewMergePullRequestTool creates a new tool for merging pull requests.
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured MergePullRequestTool.
+func NewMergePullRequestTool(bitbucket *bitbucket.Service) *MergePullRequestTool {
+	return &MergePullRequestTool{bitbucket: bitbucket}
+}
+
+// GetDefinition returns the MCP tool definition for merging a pull request.
+func (t *MergePullRequestTool) GetDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "merge_pull_request",
+		Title:       "Merge Pull Request",
+		Description: "Merges a pull request with the given strategy and commit message. Before merging, checks that the pull request is open, not a draft, and free of conflicts, that the strategy is allowed on the destination branch, and that the enforced merge checks pass (required approvals, passing builds, completed tasks, no change requests). If the pull request cannot be merged, it is left unchanged and the result has merged=false with the reasons and the evaluated checks; otherwise it has merged=true with the merged pull request. Long-running merges that Bitbucket completes asynchronously are awaited, with progress notifications sent if the request has a progress token.",
+	}
+}
+
+// Register adds the tool with its typed handler to the given MCP server.
+func (t *MergePullRequestTool) Register(server *mcp.Server) {
+	mcp.AddTool(server, t.GetDefinition(), t.Handler)
+}
+
+// Handler processes tool calls merging a pull request.
+//
+// Returns:
+//   - MergeResult with the merged pull request or the reasons it cannot be merged
+//   - InvalidParamsError if input validation fails
+//   - ResourceNotFoundError if the pull request doesn't exist
+func (t *MergePullRequestTool) Handler(ctx context.Context, req *mcp.CallToolRequest, input MergePullRequestInput) (*mcp.CallToolResult, *bitbucket.MergeResult, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	res, err := t.bitbucket.MergePullRequest(ctx, input.Namespace, input.Repository, input.PullRequestID, bitbucket.MergePullRequestOptions{
+		Strategy:          input.Strategy,
+		Message:           input.Message,
+		CloseSourceBranch: input.CloseSourceBranch,
+		Progress:          progressNotifier(ctx, req),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, res, nil
+}
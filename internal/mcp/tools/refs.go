@@ -0,0 +1,270 @@
+package tools
+
+import (
+	"context"
+
+	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/branow/mcp-bitbucket/internal/util"
+	sch "github.com/branow/mcp-bitbucket/internal/util/schema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RefInput identifies a branch or tag in the tool arguments.
+type RefInput struct {
+	RepositoryInput
+	Name string `json:"name" jsonschema:"The branch or tag name, without the refs/heads or refs/tags prefix"`
+}
+
+// Validate checks that the repository is valid and the name is not blank.
+//
+// Returns an InvalidParamsError if validation fails.
+func (in RefInput) Validate() error {
+	if err := in.RepositoryInput.Validate(); err != nil {
+		return err
+	}
+	if err := sch.NotBlank()(in.Name); err != nil {
+		return util.NewInvalidParamsError("name: " + err.Error())
+	}
+	return nil
+}
+
+// CreateRefInput describes a branch or tag to create.
+type CreateRefInput struct {
+	RefInput
+	Target string `json:"target" jsonschema:"The commit hash to point to"`
+}
+
+// Validate checks that the ref is valid and the target is not blank.
+//
+// Returns an InvalidParamsError if validation fails.
+func (in CreateRefInput) Validate() error {
+	if err := in.RefInput.Validate(); err != nil {
+		return err
+	}
+	if err := sch.NotBlank()(in.Target); err != nil {
+		return util.NewInvalidParamsError("target: " + err.Error())
+	}
+	return nil
+}
+
+// CreateTagInput describes a tag to create.
+type CreateTagInput struct {
+	CreateRefInput
+	Message string `json:"message,omitempty" jsonschema:"The tag message; creates an annotated tag"`
+}
+
+// DeleteRefInput identifies a branch or tag to delete and confirms the deletion.
+type DeleteRefInput struct {
+	RefInput
+	Confirm bool `json:"confirm" jsonschema:"Must be true to confirm the deletion, which cannot be undone"`
+}
+
+// Validate checks that the ref is valid and the deletion is confirmed.
+//
+// Returns an InvalidParamsError if validation fails.
+func (in DeleteRefInput) Validate() error {
+	if err := in.RefInput.Validate(); err != nil {
+		return err
+	}
+	if !in.Confirm {
+		return util.NewInvalidParamsError("confirm: must be true to delete " + in.Name)
+	}
+	return nil
+}
+
+// DeleteRefResult reports the deleted branch or tag.
+type DeleteRefResult struct {
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Deleted bool   `json:"deleted"`
+}
+
+// CreateBranchTool implements the ToolProvider interface
+// for creating a branch.
+type CreateBranchTool struct {
+	bitbucket *bitbucket.Service
+}
+
+// NewCreateBranchTool creates a new tool for creating branches.
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured CreateBranchTool.
+func NewCreateBranchTool(bitbucket *bitbucket.Service) *CreateBranchTool {
+	return &CreateBranchTool{bitbucket: bitbucket}
+}
+
+// GetDefinition returns the MCP tool definition for creating a branch.
+func (t *CreateBranchTool) GetDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "create_branch",
+		Title:       "Create Branch",
+		Description: "Creates a branch pointing to a commit. Returns the created branch with its target commit.",
+	}
+}
+
+// Register adds the tool with its typed handler to the given MCP server.
+func (t *CreateBranchTool) Register(server *mcp.Server) {
+	mcp.AddTool(server, t.GetDefinition(), t.Handler)
+}
+
+// Handler processes tool calls creating a branch.
+//
+// Returns:
+//   - Ref that was created
+//   - InvalidParamsError if input validation fails or the branch already exists
+//   - ResourceNotFoundError if the repository doesn't exist
+func (t *CreateBranchTool) Handler(ctx context.Context, req *mcp.CallToolRequest, input CreateRefInput) (*mcp.CallToolResult, *bitbucket.Ref, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	res, err := t.bitbucket.CreateBranch(ctx, input.Namespace, input.Repository, input.Name, input.Target)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, res, nil
+}
+
+// DeleteBranchTool implements the ToolProvider interface
+// for deleting a branch.
+type DeleteBranchTool struct {
+	bitbucket *bitbucket.Service
+}
+
+// NewDeleteBranchTool creates a new tool for deleting branches.
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured DeleteBranchTool.
+func NewDeleteBranchTool(bitbucket *bitbucket.Service) *DeleteBranchTool {
+	return &DeleteBranchTool{bitbucket: bitbucket}
+}
+
+// GetDefinition returns the MCP tool definition for deleting a branch.
+func (t *DeleteBranchTool) GetDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "delete_branch",
+		Title:       "Delete Branch",
+		Description: "Deletes a branch. The deletion cannot be undone and must be confirmed with confirm=true. The main branch cannot be deleted.",
+	}
+}
+
+// Register adds the tool with its typed handler to the given MCP server.
+func (t *DeleteBranchTool) Register(server *mcp.Server) {
+	mcp.AddTool(server, t.GetDefinition(), t.Handler)
+}
+
+// Handler processes tool calls deleting a branch.
+//
+// Returns:
+//   - DeleteRefResult confirming the deletion
+//   - InvalidParamsError if input validation fails, including a missing confirmation
+//   - ResourceNotFoundError if the branch doesn't exist
+func (t *DeleteBranchTool) Handler(ctx context.Context, req *mcp.CallToolRequest, input DeleteRefInput) (*mcp.CallToolResult, *DeleteRefResult, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	if err := t.bitbucket.DeleteBranch(ctx, input.Namespace, input.Repository, input.Name); err != nil {
+		return nil, nil, err
+	}
+	return nil, &DeleteRefResult{Type: bitbucket.RefTypeBranch, Name: input.Name, Deleted: true}, nil
+}
+
+// CreateTagTool implements the ToolProvider interface
+// for creating a lightweight or annotated tag.
+type CreateTagTool struct {
+	bitbucket *bitbucket.Service
+}
+
+// NewCreateTagTool creates a new tool for creating tags.
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured CreateTagTool.
+func NewCreateTagTool(bitbucket *bitbucket.Service) *CreateTagTool {
+	return &CreateTagTool{bitbucket: bitbucket}
+}
+
+// GetDefinition returns the MCP tool definition for creating a tag.
+func (t *CreateTagTool) GetDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "create_tag",
+		Title:       "Create Tag",
+		Description: "Creates a tag pointing to a commit. When a message is provided, an annotated tag is created. Returns the created tag with its target commit.",
+	}
+}
+
+// Register adds the tool with its typed handler to the given MCP server.
+func (t *CreateTagTool) Register(server *mcp.Server) {
+	mcp.AddTool(server, t.GetDefinition(), t.Handler)
+}
+
+// Handler processes tool calls creating a tag.
+//
+// Returns:
+//   - Ref that was created
+//   - InvalidParamsError if input validation fails or the tag already exists
+//   - ResourceNotFoundError if the repository doesn't exist
+func (t *CreateTagTool) Handler(ctx context.Context, req *mcp.CallToolRequest, input CreateTagInput) (*mcp.CallToolResult, *bitbucket.Ref, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	res, err := t.bitbucket.CreateTag(ctx, input.Namespace, input.Repository, input.Name, input.Target, input.Message)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, res, nil
+}
+
+// DeleteTagTool implements the ToolProvider interface
+// for deleting a tag.
+type DeleteTagTool struct {
+	bitbucket *bitbucket.Service
+}
+
+// NewDeleteTagTool creates a new tool for deleting tags.
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured DeleteTagTool.
+func NewDeleteTagTool(bitbucket *bitbucket.Service) *DeleteTagTool {
+	return &DeleteTagTool{bitbucket: bitbucket}
+}
+
+// GetDefinition returns the MCP tool definition for deleting a tag.
+func (t *DeleteTagTool) GetDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "delete_tag",
+		Title:       "Delete Tag",
+		Description: "Deletes a tag. The deletion cannot be undone and must be confirmed with confirm=true.",
+	}
+}
+
+// Register adds the tool with its typed handler to the given MCP server.
+func (t *DeleteTagTool) Register(server *mcp.Server) {
+	mcp.AddTool(server, t.GetDefinition(), t.Handler)
+}
+
+// Handler processes tool calls deleting a tag.
+//
+// Returns:
+//   - DeleteRefResult confirming the deletion
+//   - InvalidParamsError if input validation fails, including a missing confirmation
+//   - ResourceNotFoundError if the tag doesn't exist
+func (t *DeleteTagTool) Handler(ctx context.Context, req *mcp.CallToolRequest, input DeleteRefInput) (*mcp.CallToolResult, *DeleteRefResult, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	if err := t.bitbucket.DeleteTag(ctx, input.Namespace, input.Repository, input.Name); err != nil {
+		return nil, nil, err
+	}
+	return nil, &DeleteRefResult{Type: bitbucket.RefTypeTag, Name: input.Name, Deleted: true}, nil
+}
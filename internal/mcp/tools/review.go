@@ -0,0 +1,158 @@
+package tools
+
+import (
+	"context"
+
+	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ApprovePullRequestTool implements the ToolProvider interface
+// for approving a pull request as the authenticated user.
+type ApprovePullRequestTool struct {
+	bitbucket *bitbucket.Service
+}
+
+// NewApprovePullRequestTool creates a new tool for approving pull requests.
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured ApprovePullRequestTool.
+func NewApprovePullRequestTool(bitbucket *bitbucket.Service) *ApprovePullRequestTool {
+	return &ApprovePullRequestTool{bitbucket: bitbucket}
+}
+
+// GetDefinition returns the MCP tool definition for approving a pull request.
+func (t *ApprovePullRequestTool) GetDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "approve_pull_request",
+		Title:       "Approve Pull Request",
+		Description: "Approves a pull request as the authenticated user. Returns the pull request state and the updated list of participants with their approval status.",
+	}
+}
+
+// Register adds the tool with its typed handler to the given MCP server.
+func (t *ApprovePullRequestTool) Register(server *mcp.Server) {
+	mcp.AddTool(server, t.GetDefinition(), t.Handler)
+}
+
+// Handler processes tool calls approving a pull request.
+//
+// Returns:
+//   - PullRequestReview with the updated participants
+//   - InvalidParamsError if input validation fails
+//   - ResourceNotFoundError if the pull request doesn't exist
+func (t *ApprovePullRequestTool) Handler(ctx context.Context, req *mcp.CallToolRequest, input PullRequestInput) (*mcp.CallToolResult, *bitbucket.PullRequestReview, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	res, err := t.bitbucket.ApprovePullRequest(ctx, input.Namespace, input.Repository, input.PullRequestID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, res, nil
+}
+
+// UnapprovePullRequestTool implements the ToolProvider interface
+// for withdrawing the authenticated user's approval of a pull request.
+type UnapprovePullRequestTool struct {
+	bitbucket *bitbucket.Service
+}
+
+// NewUnapprovePullRequestTool creates a new tool for withdrawing pull request approvals.
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured UnapprovePullRequestTool.
+func NewUnapprovePullRequestTool(bitbucket *bitbucket.Service) *UnapprovePullRequestTool {
+	return &UnapprovePullRequestTool{bitbucket: bitbucket}
+}
+
+// GetDefinition returns the MCP tool definition for withdrawing a pull request approval.
+func (t *UnapprovePullRequestTool) GetDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "unapprove_pull_request",
+		Title:       "Unapprove Pull Request",
+		Description: "Withdraws the authenticated user's approval of a pull request. Returns the pull request state and the updated list of participants with their approval status.",
+	}
+}
+
+// Register adds the tool with its typed handler to the given MCP server.
+func (t *UnapprovePullRequestTool) Register(server *mcp.Server) {
+	mcp.AddTool(server, t.GetDefinition(), t.Handler)
+}
+
+// Handler processes tool calls withdrawing a pull request approval.
+//
+// Returns:
+//   - PullRequestReview with the updated participants
+//   - InvalidParamsError if input validation fails
+//   - ResourceNotFoundError if the pull request doesn't exist
+func (t *UnapprovePullRequestTool) Handler(ctx context.Context, req *mcp.CallToolRequest, input PullRequestInput) (*mcp.CallToolResult, *bitbucket.PullRequestReview, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	res, err := t.bitbucket.UnapprovePullRequest(ctx, input.Namespace, input.Repository, input.PullRequestID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, res, nil
+}
+
+// RequestChangesInput identifies a pull request and whether to withdraw a change request.
+type RequestChangesInput struct {
+	PullRequestInput
+	Remove bool `json:"remove,omitempty" jsonschema:"Withdraw a previous change request instead of requesting changes"`
+}
+
+// RequestChangesTool implements the ToolProvider interface
+// for requesting changes on a pull request as the authenticated user.
+type RequestChangesTool struct {
+	bitbucket *bitbucket.Service
+}
+
+// NewRequestChangesTool creates a new tool for requesting pull request changes.
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured RequestChangesTool.
+func NewRequestChangesTool(bitbucket *bitbucket.Service) *RequestChangesTool {
+	return &RequestChangesTool{bitbucket: bitbucket}
+}
+
+// GetDefinition returns the MCP tool definition for requesting pull request changes.
+func (t *RequestChangesTool) GetDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "request_changes",
+		Title:       "Request Pull Request Changes",
+		Description: "Requests changes on a pull request as the authenticated user, or withdraws a previous change request when remove is true. Returns the pull request state and the updated list of participants with their review status.",
+	}
+}
+
+// Register adds the tool with its typed handler to the given MCP server.
+func (t *RequestChangesTool) Register(server *mcp.Server) {
+	mcp.AddTool(server, t.GetDefinition(), t.Handler)
+}
+
+// Handler processes tool calls requesting or withdrawing pull request changes.
+//
+// Returns:
+//   - PullRequestReview with the updated participants
+//   - InvalidParamsError if input validation fails
+//   - ResourceNotFoundError if the pull request doesn't exist
+func (t *RequestChangesTool) Handler(ctx context.Context, req *mcp.CallToolRequest, input RequestChangesInput) (*mcp.CallToolResult, *bitbucket.PullRequestReview, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	res, err := t.bitbucket.RequestPullRequestChanges(ctx, input.Namespace, input.Repository, input.PullRequestID, input.Remove)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, res, nil
+}
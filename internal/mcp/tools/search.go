@@ -0,0 +1,103 @@
+package tools
+
+import (
+	"context"
+
+	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/branow/mcp-bitbucket/internal/util"
+	sch "github.com/branow/mcp-bitbucket/internal/util/schema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// SearchCodeInput describes a code search across the repositories of a workspace.
+type SearchCodeInput struct {
+	Namespace  string `json:"namespace" jsonschema:"The workspace slug"`
+	Query      string `json:"query" jsonschema:"The search query in the Bitbucket search syntax, e.g. NewClient or \"exact phrase\" NOT test"`
+	Repository string `json:"repository,omitempty" jsonschema:"Only search the repository with this slug"`
+	Language   string `json:"language,omitempty" jsonschema:"Only search files in this language, e.g. go"`
+	Extension  string `json:"extension,omitempty" jsonschema:"Only search files with this extension, e.g. yaml"`
+	Path       string `json:"path,omitempty" jsonschema:"Only search files under this path, e.g. src/main"`
+	Page       int    `json:"page,omitempty" jsonschema:"The page number (default 1)"`
+	Size       int    `json:"size,omitempty" jsonschema:"The number of files per page, at most 50 (default 10)"`
+}
+
+// Validate checks that the namespace and query are not blank,
+// and the page and size are in range if given.
+//
+// Returns an InvalidParamsError if validation fails.
+func (in SearchCodeInput) Validate() error {
+	if err := sch.NotBlank()(in.Namespace); err != nil {
+		return util.NewInvalidParamsError("namespace: " + err.Error())
+	}
+	if err := sch.NotBlank()(in.Query); err != nil {
+		return util.NewInvalidParamsError("query: " + err.Error())
+	}
+	if in.Page != 0 {
+		if err := sch.Positive()(in.Page); err != nil {
+			return util.NewInvalidParamsError("page: " + err.Error())
+		}
+	}
+	if in.Size != 0 {
+		if err := sch.Between(1, 50)(in.Size); err != nil {
+			return util.NewInvalidParamsError("size: " + err.Error())
+		}
+	}
+	return nil
+}
+
+// SearchCodeTool implements the ToolProvider interface
+// for searching the code of all repositories in a workspace.
+type SearchCodeTool struct {
+	bitbucket *bitbucket.Service
+}
+
+// NewSearchCodeTool creates a new tool for searching code.
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured SearchCodeTool.
+func NewSearchCodeTool(bitbucket *bitbucket.Service) *SearchCodeTool {
+	return &SearchCodeTool{bitbucket: bitbucket}
+}
+
+// GetDefinition returns the MCP tool definition for searching code.
+func (t *SearchCodeTool) GetDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "search_code",
+		Title:       "Search Code",
+		Description: "Searches the code of all repositories in a workspace, on the default branch of every repository. The query accepts the Bitbucket search syntax and can be narrowed to a repository, a language, a file extension, and a path. Returns the matching files with their repository, path, and commit, and the matching lines with their highlighted parts and the lines around them. Code search must be enabled for the workspace.",
+	}
+}
+
+// Register adds the tool with its typed handler to the given MCP server.
+func (t *SearchCodeTool) Register(server *mcp.Server) {
+	mcp.AddTool(server, t.GetDefinition(), t.Handler)
+}
+
+// Handler processes tool calls searching code.
+//
+// Returns:
+//   - CodeSearchResults with a page of the matching files
+//   - InvalidParamsError if input validation fails or Bitbucket rejects the query
+//   - ResourceNotFoundError if the workspace doesn't exist
+func (t *SearchCodeTool) Handler(ctx context.Context, req *mcp.CallToolRequest, input SearchCodeInput) (*mcp.CallToolResult, *bitbucket.CodeSearchResults, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	options := bitbucket.SearchCodeOptions{
+		Query:      input.Query,
+		Repository: input.Repository,
+		Language:   input.Language,
+		Extension:  input.Extension,
+		Path:       input.Path,
+		Page:       input.Page,
+		Size:       input.Size,
+	}
+	res, err := t.bitbucket.SearchCode(ctx, input.Namespace, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, res, nil
+}
@@ -0,0 +1,199 @@
+package tools
+
+import (
+	"context"
+
+	bitbucket "github.com/branow/mcp-bitbucket/internal/bitbucket/service"
+	"github.com/branow/mcp-bitbucket/internal/util"
+	sch "github.com/branow/mcp-bitbucket/internal/util/schema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// CreateTaskInput describes a task to create on a pull request.
+type CreateTaskInput struct {
+	PullRequestInput
+	Content   string `json:"content" jsonschema:"The task description (Markdown)"`
+	CommentID *int   `json:"comment_id,omitempty" jsonschema:"The ID of the comment to attach the task to"`
+}
+
+// CreatePullRequestTaskTool implements the ToolProvider interface
+// for creating a pull request task, optionally from a comment.
+type CreatePullRequestTaskTool struct {
+	bitbucket *bitbucket.Service
+}
+
+// NewCreatePullRequestTaskTool creates a new tool for creating pull request tasks.
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured CreatePullRequestTaskTool.
+func NewCreatePullRequestTaskTool(bitbucket *bitbucket.Service) *CreatePullRequestTaskTool {
+	return &CreatePullRequestTaskTool{bitbucket: bitbucket}
+}
+
+// GetDefinition returns the MCP tool definition for creating a pull request task.
+func (t *CreatePullRequestTaskTool) GetDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "create_pull_request_task",
+		Title:       "Create Pull Request Task",
+		Description: "Creates a task on a pull request. When comment_id is provided, the task is attached to that comment, turning review feedback into a checklist item. Returns the created task.",
+	}
+}
+
+// Register adds the tool with its typed handler to the given MCP server.
+func (t *CreatePullRequestTaskTool) Register(server *mcp.Server) {
+	mcp.AddTool(server, t.GetDefinition(), t.Handler)
+}
+
+// Handler processes tool calls creating a pull request task.
+//
+// Returns:
+//   - PullRequestTask that was created
+//   - InvalidParamsError if input validation fails
+//   - ResourceNotFoundError if the pull request or comment doesn't exist
+func (t *CreatePullRequestTaskTool) Handler(ctx context.Context, req *mcp.CallToolRequest, input CreateTaskInput) (*mcp.CallToolResult, *bitbucket.PullRequestTask, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if err := sch.NotBlank()(input.Content); err != nil {
+		return nil, nil, util.NewInvalidParamsError("content: " + err.Error())
+	}
+	if input.CommentID != nil {
+		if err := sch.Positive()(*input.CommentID); err != nil {
+			return nil, nil, util.NewInvalidParamsError("comment_id: " + err.Error())
+		}
+	}
+
+	res, err := t.bitbucket.CreatePullRequestTask(ctx, input.Namespace, input.Repository, input.PullRequestID, input.Content, input.CommentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, res, nil
+}
+
+// TaskInput identifies a task on a pull request.
+type TaskInput struct {
+	PullRequestInput
+	TaskID int `json:"task_id" jsonschema:"The task ID"`
+}
+
+// Validate checks that the pull request is valid and the task ID is positive.
+//
+// Returns an InvalidParamsError if validation fails.
+func (in TaskInput) Validate() error {
+	if err := in.PullRequestInput.Validate(); err != nil {
+		return err
+	}
+	if err := sch.Positive()(in.TaskID); err != nil {
+		return util.NewInvalidParamsError("task_id: " + err.Error())
+	}
+	return nil
+}
+
+// ResolveTaskInput identifies a task and whether to resolve or reopen it.
+type ResolveTaskInput struct {
+	TaskInput
+	Reopen bool `json:"reopen,omitempty" jsonschema:"Reopen a resolved task instead of resolving it"`
+}
+
+// ResolvePullRequestTaskTool implements the ToolProvider interface
+// for resolving or reopening a pull request task.
+type ResolvePullRequestTaskTool struct {
+	bitbucket *bitbucket.Service
+}
+
+// NewResolvePullRequestTaskTool creates a new tool for resolving pull request tasks.
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured ResolvePullRequestTaskTool.
+func NewResolvePullRequestTaskTool(bitbucket *bitbucket.Service) *ResolvePullRequestTaskTool {
+	return &ResolvePullRequestTaskTool{bitbucket: bitbucket}
+}
+
+// GetDefinition returns the MCP tool definition for resolving a pull request task.
+func (t *ResolvePullRequestTaskTool) GetDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "resolve_pull_request_task",
+		Title:       "Resolve Pull Request Task",
+		Description: "Marks a pull request task as resolved, or reopens it when reopen is true. Returns the updated task.",
+	}
+}
+
+// Register adds the tool with its typed handler to the given MCP server.
+func (t *ResolvePullRequestTaskTool) Register(server *mcp.Server) {
+	mcp.AddTool(server, t.GetDefinition(), t.Handler)
+}
+
+// Handler processes tool calls resolving or reopening a pull request task.
+//
+// Returns:
+//   - PullRequestTask with the updated state
+//   - InvalidParamsError if input validation fails
+//   - ResourceNotFoundError if the task doesn't exist
+func (t *ResolvePullRequestTaskTool) Handler(ctx context.Context, req *mcp.CallToolRequest, input ResolveTaskInput) (*mcp.CallToolResult, *bitbucket.PullRequestTask, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	res, err := t.bitbucket.ResolvePullRequestTask(ctx, input.Namespace, input.Repository, input.PullRequestID, input.TaskID, !input.Reopen)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, res, nil
+}
+
+// DeleteTaskResult reports the deleted task.
+type DeleteTaskResult struct {
+	TaskID  int  `json:"task_id"`
+	Deleted bool `json:"deleted"`
+}
+
+// DeletePullRequestTaskTool implements the ToolProvider interface
+// for deleting a pull request task.
+type DeletePullRequestTaskTool struct {
+	bitbucket *bitbucket.Service
+}
+
+// NewDeletePullRequestTaskTool creates a new tool for deleting pull request tasks.
+//
+// Parameters:
+//   - bitbucket: The Bitbucket service for making API requests
+//
+// Returns a configured DeletePullRequestTaskTool.
+func NewDeletePullRequestTaskTool(bitbucket *bitbucket.Service) *DeletePullRequestTaskTool {
+	return &DeletePullRequestTaskTool{bitbucket: bitbucket}
+}
+
+// GetDefinition returns the MCP tool definition for deleting a pull request task.
+func (t *DeletePullRequestTaskTool) GetDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "delete_pull_request_task",
+		Title:       "Delete Pull Request Task",
+		Description: "Deletes a task from a pull request.",
+	}
+}
+
+// Register adds the tool with its typed handler to the given MCP server.
+func (t *DeletePullRequestTaskTool) Register(server *mcp.Server) {
+	mcp.AddTool(server, t.GetDefinition(), t.Handler)
+}
+
+// Handler processes tool calls deleting a pull request task.
+//
+// Returns:
+//   - DeleteTaskResult confirming the deletion
+//   - InvalidParamsError if input validation fails
+//   - ResourceNotFoundError if the task doesn't exist
+func (t *DeletePullRequestTaskTool) Handler(ctx context.Context, req *mcp.CallToolRequest, input TaskInput) (*mcp.CallToolResult, *DeleteTaskResult, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	if err := t.bitbucket.DeletePullRequestTask(ctx, input.Namespace, input.Repository, input.PullRequestID, input.TaskID); err != nil {
+		return nil, nil, err
+	}
+	return nil, &DeleteTaskResult{TaskID: input.TaskID, Deleted: true}, nil
+}
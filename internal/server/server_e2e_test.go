@@ -3,6 +3,7 @@ package server_test
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
@@ -11,6 +12,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -34,6 +36,9 @@ type E2ETestSuite_BasicAuth struct {
 	server     *server.McpServer
 	bitbucket  *httptest.Server
 	cfg        config.Global
+
+	busyRepositoryListings *atomic.Int32
+	busyReviewSearches     *atomic.Int32
 }
 
 func TestE2E_BasicAuth(t *testing.T) {
@@ -50,6 +55,7 @@ func (s *E2ETestSuite_BasicAuth) SetupBitbucketServer() {
 	mux := http.NewServeMux()
 	newBitbucketRepositoriesHandler(s.T(), mux)
 	newBitbucketRepositoriesNotFoundHandler(s.T(), mux)
+	s.busyRepositoryListings, s.busyReviewSearches = newBitbucketBusyWorkspaceHandlers(s.T(), mux)
 	newBitbucketRepositoryHandler(s.T(), mux)
 	newBitbucketRepositoryWithoutReadmeHandler(s.T(), mux)
 	newBitbucketRepositoryNotFoundHandler(s.T(), mux)
@@ -57,6 +63,7 @@ func (s *E2ETestSuite_BasicAuth) SetupBitbucketServer() {
 	newBitbucketRepositorySourceWithoutReadmeHandler(s.T(), mux)
 	newBitbucketRepositorySourceNotFoundHandler(s.T(), mux)
 	newBitbucketFileSourceReadmeHandler(s.T(), mux)
+	newBitbucketPullRequestsHandler(s.T(), mux)
 	newBitbucketPullRequestHandler(s.T(), mux)
 	newBitbucketPullRequestNotFoundHandler(s.T(), mux)
 	newBitbucketPullRequestCommitsHandler(s.T(), mux)
@@ -65,6 +72,53 @@ func (s *E2ETestSuite_BasicAuth) SetupBitbucketServer() {
 	newBitbucketPullRequestDiffNotFoundHandler(s.T(), mux)
 	newBitbucketPullRequestCommentsHandler(s.T(), mux)
 	newBitbucketPullRequestCommentsNotFoundHandler(s.T(), mux)
+	newBitbucketUserHandler(s.T(), mux)
+	newBitbucketUserEmailsHandler(s.T(), mux)
+	newBitbucketUserPullRequestsHandler(s.T(), mux)
+	newBitbucketPullRequestApproveHandler(s.T(), mux)
+	newBitbucketPullRequestRequestChangesHandler(s.T(), mux)
+	newBitbucketPullRequestActivityHandler(s.T(), mux)
+	newBitbucketPullRequestActivityInvalidDateHandler(s.T(), mux)
+	newBitbucketPullRequestTasksHandler(s.T(), mux)
+	newBitbucketPullRequestTaskHandler(s.T(), mux)
+	newBitbucketWorkspaceMembersHandler(s.T(), mux)
+	newBitbucketEffectiveDefaultReviewersHandler(s.T(), mux)
+	newBitbucketBranchHandler(s.T(), mux)
+	newBitbucketBranchRestrictionsHandler(s.T(), mux)
+	newBitbucketEffectiveBranchingModelHandler(s.T(), mux)
+	newBitbucketPullRequestDiffStatHandler(s.T(), mux)
+	newBitbucketPullRequestStatusesHandler(s.T(), mux)
+	newBitbucketMergeablePullRequestHandler(s.T(), mux)
+	newBitbucketPullRequestMergeHandler(s.T(), mux)
+	newBitbucketPullRequestMergeTaskStatusHandler(s.T(), mux)
+	newBitbucketCommitsHandler(s.T(), mux)
+	newBitbucketCommitHandler(s.T(), mux)
+	newBitbucketCommitDiffHandler(s.T(), mux)
+	newBitbucketCommitDiffStatHandler(s.T(), mux)
+	newBitbucketCompareDiffHandler(s.T(), mux)
+	newBitbucketCompareDiffStatHandler(s.T(), mux)
+	newBitbucketRefsHandler(s.T(), mux)
+	newBitbucketBranchesHandler(s.T(), mux)
+	newBitbucketTagsHandler(s.T(), mux)
+	newBitbucketDeleteRefHandler(s.T(), mux, "branches", "feature/login")
+	newBitbucketDeleteRefHandler(s.T(), mux, "tags", "v1.2.0")
+	newBitbucketPipelinesHandler(s.T(), mux)
+	newBitbucketPipelineHandler(s.T(), mux)
+	newBitbucketPipelineStepsHandler(s.T(), mux)
+	newBitbucketPipelineStepLogHandler(s.T(), mux)
+	newBitbucketStopPipelineHandler(s.T(), mux)
+	newBitbucketCommitStatusesHandler(s.T(), mux)
+	newBitbucketCreateBuildStatusHandler(s.T(), mux)
+	newBitbucketReportsHandler(s.T(), mux)
+	newBitbucketReportHandler(s.T(), mux)
+	newBitbucketReportAnnotationsHandler(s.T(), mux)
+	newBitbucketIssuesHandler(s.T(), mux)
+	newBitbucketIssueHandler(s.T(), mux)
+	newBitbucketIssueCommentsHandler(s.T(), mux)
+	newBitbucketWorkspacesHandler(s.T(), mux)
+	newBitbucketProjectsHandler(s.T(), mux)
+	newBitbucketProjectHandler(s.T(), mux)
+	newBitbucketCodeSearchHandler(s.T(), mux)
 	auth := newBasicAuthMiddleware("test@example.com", "test_token")
 	s.bitbucket = httptest.NewServer(auth(mux))
 }
@@ -162,6 +216,42 @@ func (s *E2ETestSuite_BasicAuth) TestRepositoriesResource_NotFound() {
 	testResourceError(s.T(), s.mcpClient, uri, code, err)
 }
 
+func (s *E2ETestSuite_BasicAuth) TestRepositoriesResource_Search() {
+	uri := "mcp://bitbucket/test-workspace/repositories?name=repo&project=test&language=Go,Python&private=true&since=2023-06-01&role=contributor&sort=-updated_on&page=2&pageSize=20"
+	responses := []string{"repositories.json"}
+	testResource(s.T(), s.mcpClient, uri, responses)
+}
+
+func (s *E2ETestSuite_BasicAuth) TestRepositoriesResource_InvalidFilter() {
+	tests := []struct {
+		name  string
+		uri   string
+		error string
+	}{
+		{
+			name:  "unknown role",
+			uri:   "mcp://bitbucket/test-workspace/repositories?role=guest",
+			error: "role: ",
+		},
+		{
+			name:  "invalid visibility",
+			uri:   "mcp://bitbucket/test-workspace/repositories?private=maybe",
+			error: "private: ",
+		},
+		{
+			name:  "invalid since",
+			uri:   "mcp://bitbucket/test-workspace/repositories?since=last-month",
+			error: "since: ",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testResourceError(s.T(), s.mcpClient, tt.uri, util.CodeInvalidParamsErr, tt.error)
+		})
+	}
+}
+
 func (s *E2ETestSuite_BasicAuth) TestRepositoryResource() {
 	tests := []struct {
 		name      string
@@ -193,6 +283,16 @@ func (s *E2ETestSuite_BasicAuth) TestRepositoryResource() {
 			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository?readme=true&src=true",
 			responses: []string{"/repository/with-src-and-readme.json"},
 		},
+		{
+			name:      "markdown",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository?readme=true&src=true&format=markdown",
+			responses: []string{"/repository/with-src-and-readme.md"},
+		},
+		{
+			name:      "json and markdown",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository?readme=true&src=true&format=both",
+			responses: []string{"/repository/with-src-and-readme.json", "/repository/with-src-and-readme.md"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -202,6 +302,11 @@ func (s *E2ETestSuite_BasicAuth) TestRepositoryResource() {
 	}
 }
 
+func (s *E2ETestSuite_BasicAuth) TestRepositoryResource_InvalidFormat() {
+	uri := "mcp://bitbucket/test-workspace/repositories/test-repository?format=invalid"
+	testResourceError(s.T(), s.mcpClient, uri, util.CodeInvalidParamsErr, "format: ")
+}
+
 func (s *E2ETestSuite_BasicAuth) TestRepositoryResource_NotFound() {
 	uri := "mcp://bitbucket/test-workspace/repositories/invalid-repository?src=true&readme=true"
 	code := util.CodeResourceNotFoundErr
@@ -209,6 +314,25 @@ func (s *E2ETestSuite_BasicAuth) TestRepositoryResource_NotFound() {
 	testResourceError(s.T(), s.mcpClient, uri, code, err)
 }
 
+func (s *E2ETestSuite_BasicAuth) TestDefaultReviewersResource() {
+	uri := "mcp://bitbucket/test-workspace/repositories/test-repository/default-reviewers"
+	responses := []string{"default-reviewers.json"}
+	testResource(s.T(), s.mcpClient, uri, responses)
+}
+
+func (s *E2ETestSuite_BasicAuth) TestPullRequestsResource() {
+	uri := "mcp://bitbucket/test-workspace/repositories/test-repository/pullrequests?state=open,merged&author=testuser&q=title~%22feature%22&sort=-updated_on&page=1&size=10"
+	responses := []string{"pullrequests.json"}
+	testResource(s.T(), s.mcpClient, uri, responses)
+}
+
+func (s *E2ETestSuite_BasicAuth) TestPullRequestsResource_InvalidState() {
+	uri := "mcp://bitbucket/test-workspace/repositories/test-repository/pullrequests?state=closed"
+	code := util.CodeInvalidParamsErr
+	err := "state: "
+	testResourceError(s.T(), s.mcpClient, uri, code, err)
+}
+
 func (s *E2ETestSuite_BasicAuth) TestPullRequestResource() {
 	tests := []struct {
 		name      string
@@ -235,11 +359,46 @@ func (s *E2ETestSuite_BasicAuth) TestPullRequestResource() {
 			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/pullrequests/1?comments=true",
 			responses: []string{"/pullrequest/with-comments.json"},
 		},
+		{
+			name:      "with unresolved comments",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/pullrequests/1?comments=true&unresolved=true",
+			responses: []string{"/pullrequest/with-unresolved-comments.json"},
+		},
+		{
+			name:      "with file comments",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/pullrequests/1?comments=true&file=src%2Fmain%2Fjava%2Fcom%2Fexample%2FApp.java",
+			responses: []string{"/pullrequest/with-file-comments.json"},
+		},
+		{
+			name:      "with tasks",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/pullrequests/1?tasks=true",
+			responses: []string{"/pullrequest/with-tasks.json"},
+		},
+		{
+			name:      "with tasks markdown",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/pullrequests/1?tasks=true&format=markdown",
+			responses: []string{"/pullrequest/with-tasks.md"},
+		},
 		{
 			name:      "with all",
 			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/pullrequests/1?commits=true&diff=true&comments=true",
 			responses: []string{"/pullrequest/with-all.json"},
 		},
+		{
+			name:      "markdown",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/pullrequests/1?commits=true&diff=true&comments=true&format=markdown",
+			responses: []string{"/pullrequest/with-all.md"},
+		},
+		{
+			name:      "json and markdown",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/pullrequests/1?format=both",
+			responses: []string{"/pullrequest/base.json", "/pullrequest/base.md"},
+		},
+		{
+			name:      "from inaccessible fork",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/pullrequests/4",
+			responses: []string{"/pullrequest/fork.json"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -256,470 +415,3026 @@ func (s *E2ETestSuite_BasicAuth) TestPullRequestResource_NotFound() {
 	testResourceError(s.T(), s.mcpClient, uri, code, err)
 }
 
-// E2ETestSuite_OAuth is the test suite for end-to-end tests with OAuth authentication
-type E2ETestSuite_OAuth struct {
-	suite.Suite
-	baseURL    string
-	mcpClient  *mcp.ClientSession
-	httpClient *http.Client
-	server     *server.McpServer
-	bitbucket  *httptest.Server
-	cfg        config.Global
-}
-
-func TestE2E_OAuth(t *testing.T) {
-	suite.Run(t, new(E2ETestSuite_OAuth))
+func (s *E2ETestSuite_BasicAuth) TestPullRequestResource_StatusesUnavailable() {
+	uri := "mcp://bitbucket/test-workspace/repositories/test-repository/pullrequests/5"
+	code := util.CodeResourceUnavailableErr
+	err := "Bitbucket service unavailable"
+	testResourceError(s.T(), s.mcpClient, uri, code, err)
 }
 
-func (s *E2ETestSuite_OAuth) SetupSuite() {
-	s.SetupBitbucketServer()
-	s.SetupMcpServer()
-	s.SetupMcpClient()
-}
+func (s *E2ETestSuite_BasicAuth) TestPullRequestActivityResource() {
+	tests := []struct {
+		name      string
+		uri       string
+		responses []string
+	}{
+		{
+			name:      "all",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/pullrequests/1/activity",
+			responses: []string{"/pullrequest/activity.json"},
+		},
+		{
+			name:      "since",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/pullrequests/1/activity?since=2023-01-16T10%3A00%3A00Z",
+			responses: []string{"/pullrequest/activity-since.json"},
+		},
+		{
+			name:      "types",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/pullrequests/1/activity?type=approval,changes_requested",
+			responses: []string{"/pullrequest/activity-reviews.json"},
+		},
+		{
+			name:      "since with invalid date",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/pullrequests/3/activity?since=2023-01-16T08%3A00%3A00Z",
+			responses: []string{"/pullrequest/activity-since-invalid-date.json"},
+		},
+	}
 
-func (s *E2ETestSuite_OAuth) SetupBitbucketServer() {
-	mux := http.NewServeMux()
-	newBitbucketRepositoriesHandler(s.T(), mux)
-	auth := newOpaqueTokenMiddleware("random-valid-token")
-	s.bitbucket = httptest.NewServer(auth(mux))
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testResource(s.T(), s.mcpClient, tt.uri, tt.responses)
+		})
+	}
 }
 
-func (s *E2ETestSuite_OAuth) SetupMcpServer() {
-	// Clear config cache to ensure environment variables are re-read for this test
-	config.ClearCache()
-
-	listener, err := net.Listen("tcp", "127.0.0.1:0")
-	s.Require().NoError(err, "failed to find available port")
-
-	port := listener.Addr().(*net.TCPAddr).Port
-	listener.Close()
-
-	s.T().Setenv("SERVER_PORT", strconv.Itoa(port))
-	s.T().Setenv("BITBUCKET_URL", s.bitbucket.URL)
-	s.T().Setenv("BITBUCKET_TIMEOUT", "5")
-	s.T().Setenv("BITBUCKET_AUTH", "oauth")
-	s.T().Setenv("SERVER_URL", fmt.Sprintf("http://127.0.0.1:%d", port))
-	s.T().Setenv("OAUTH_ISSUER", "https://bitbucket.org")
-	s.T().Setenv("OAUTH_SCOPES", "repository;pullrequest")
-
-	cfg := config.NewGlobal()
-	s.server = server.NewMcpServer(cfg)
-	s.baseURL = fmt.Sprintf("http://127.0.0.1:%d", port)
-	s.httpClient = &http.Client{Timeout: 5 * time.Second}
-
-	go func() {
-		if err := s.server.Run(); err != nil && err != http.ErrServerClosed {
-			s.T().Logf("server error: %v", err)
-		}
-	}()
+func (s *E2ETestSuite_BasicAuth) TestPullRequestActivityResource_Invalid() {
+	tests := []struct {
+		name string
+		uri  string
+		err  string
+	}{
+		{
+			name: "invalid since",
+			uri:  "mcp://bitbucket/test-workspace/repositories/test-repository/pullrequests/1/activity?since=yesterday",
+			err:  "since: ",
+		},
+		{
+			name: "invalid type",
+			uri:  "mcp://bitbucket/test-workspace/repositories/test-repository/pullrequests/1/activity?type=merge",
+			err:  "type: ",
+		},
+	}
 
-	s.Require().NoError(s.server.WaitUntilReady(5*time.Second), "server failed to start")
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testResourceError(s.T(), s.mcpClient, tt.uri, util.CodeInvalidParamsErr, tt.err)
+		})
+	}
 }
 
-func (s *E2ETestSuite_OAuth) SetupMcpClient() {
-	client := mcp.NewClient(&mcp.Implementation{
-		Name:    "Test Client",
-		Version: "1.0.0",
-	}, nil)
-	transport := &mcp.StreamableClientTransport{
-		Endpoint: fmt.Sprintf("%s/%s", s.baseURL, "mcp"),
-		HTTPClient: &http.Client{
-			Transport: &oauthTransport{
-				base:  http.DefaultTransport,
-				token: "random-valid-token",
-			},
+func (s *E2ETestSuite_BasicAuth) TestCommitsResource() {
+	tests := []struct {
+		name      string
+		uri       string
+		responses []string
+	}{
+		{
+			name:      "all branches",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/commits",
+			responses: []string{"/commits.json"},
+		},
+		{
+			name:      "filtered",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/commits?include=feature&exclude=main&path=src%2Finput.go&page=2",
+			responses: []string{"/commits.json"},
 		},
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
 
-	session, err := client.Connect(ctx, transport, nil)
-	s.Require().NoError(err, "failed to connect to mcp server")
-	s.mcpClient = session
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testResource(s.T(), s.mcpClient, tt.uri, tt.responses)
+		})
+	}
 }
 
-func (s *E2ETestSuite_OAuth) TearDownSuite() {
-	if s.mcpClient != nil {
-		s.mcpClient.Close()
+func (s *E2ETestSuite_BasicAuth) TestCommitResource() {
+	tests := []struct {
+		name      string
+		uri       string
+		responses []string
+	}{
+		{
+			name:      "base",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/commits/def456abc123",
+			responses: []string{"/commit/base.json"},
+		},
+		{
+			name:      "with diff and diffstat",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/commits/def456abc123?diff=true&diffstat=true",
+			responses: []string{"/commit/with-diff.json"},
+		},
 	}
 
-	if s.server != nil {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-		s.Require().NoError(s.server.Shutdown(ctx), "failed to shutdown server")
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testResource(s.T(), s.mcpClient, tt.uri, tt.responses)
+		})
 	}
+}
 
-	if s.bitbucket != nil {
-		s.bitbucket.Close()
-	}
+func (s *E2ETestSuite_BasicAuth) TestCommitResource_NotFound() {
+	uri := "mcp://bitbucket/test-workspace/repositories/test-repository/commits/0000000"
+	testResourceError(s.T(), s.mcpClient, uri, util.CodeResourceNotFoundErr, "Commit not found")
 }
 
-func (s *E2ETestSuite_OAuth) TestHealthEndpoint() {
-	url := s.baseURL + "/health"
-	req, err := http.NewRequest("GET", url, nil)
-	s.Require().NoError(err, "failed to create GET /health request")
+func (s *E2ETestSuite_BasicAuth) TestCompareResource() {
+	tests := []struct {
+		name      string
+		uri       string
+		responses []string
+	}{
+		{
+			name:      "diff only",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/compare/release-1.2..main",
+			responses: []string{"/compare/base.json"},
+		},
+		{
+			name:      "with path and diffstat",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/compare/release-1.2..main?path=src%2Fapp&diffstat=true",
+			responses: []string{"/compare/with-diffstat.json"},
+		},
+		{
+			name:      "markdown",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/compare/release-1.2..main?diffstat=true&format=markdown",
+			responses: []string{"/compare/markdown.md"},
+		},
+	}
 
-	resp, err := s.httpClient.Do(req)
-	s.Require().NoError(err, "failed to make GET /health request")
-	s.Assert().Equal(http.StatusOK, resp.StatusCode)
-	s.Assert().Equal("application/json", resp.Header.Get("Content-Type"))
-	s.Assert().Equal(`{"status":"ok"}`, strings.TrimSpace(readResponseBody(s.T(), resp)))
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testResource(s.T(), s.mcpClient, tt.uri, tt.responses)
+		})
+	}
 }
 
-func (s *E2ETestSuite_OAuth) TestMcpInitialize() {
-	s.Assert().Equal("Bitbucket MCP", s.mcpClient.InitializeResult().ServerInfo.Title)
-	s.Assert().Equal("1.0.0", s.mcpClient.InitializeResult().ServerInfo.Version)
+func (s *E2ETestSuite_BasicAuth) TestCompareResource_NotFound() {
+	uri := "mcp://bitbucket/test-workspace/repositories/test-repository/compare/release-9.9..main"
+	testResourceError(s.T(), s.mcpClient, uri, util.CodeResourceNotFoundErr, "Commit not found")
 }
 
-func (s *E2ETestSuite_OAuth) TestOAuthMetadataEndpoint() {
-	url := s.baseURL + "/.well-known/oauth-protected-resource"
-	req, err := http.NewRequest("GET", url, nil)
-	s.Require().NoError(err, "failed to create GET metadata request")
-
-	resp, err := s.httpClient.Do(req)
-	s.Require().NoError(err, "failed to make GET metadata request")
-	s.Assert().Equal(http.StatusOK, resp.StatusCode)
-	s.Assert().Equal("application/json", resp.Header.Get("Content-Type"))
-
-	body := readResponseBody(s.T(), resp)
-
-	expected := fmt.Sprintf(`{
-		"resource": "%s/mcp",
-		"authorization_servers":["https://bitbucket.org/site/oauth2/access_token"],
-		"scopes_supported":["repository","pullrequest"]
-	}`, s.baseURL)
+func (s *E2ETestSuite_BasicAuth) TestRefsResource() {
+	tests := []struct {
+		name      string
+		uri       string
+		responses []string
+	}{
+		{
+			name:      "all",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/refs",
+			responses: []string{"/refs/all.json"},
+		},
+		{
+			name:      "branches",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/refs?type=branch&q=name%20~%20%22release%22&sort=name",
+			responses: []string{"/refs/branches.json"},
+		},
+		{
+			name:      "tags",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/refs?type=tag",
+			responses: []string{"/refs/tags.json"},
+		},
+	}
 
-	s.Assert().JSONEq(expected, body)
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testResource(s.T(), s.mcpClient, tt.uri, tt.responses)
+		})
+	}
 }
 
-func (s *E2ETestSuite_OAuth) TestRepositoriesResource() {
-	uri := "mcp://bitbucket/test-workspace/repositories?page=1&pageSize=50"
-	responses := []string{"repositories.json"}
-	testResource(s.T(), s.mcpClient, uri, responses)
+func (s *E2ETestSuite_BasicAuth) TestRefsResource_InvalidType() {
+	uri := "mcp://bitbucket/test-workspace/repositories/test-repository/refs?type=commit"
+	testResourceError(s.T(), s.mcpClient, uri, util.CodeInvalidParamsErr, "type: ")
 }
 
-func testResource(t *testing.T, client *mcp.ClientSession, uri string, responses []string) {
-	t.Helper()
+func (s *E2ETestSuite_BasicAuth) TestRefTools() {
+	tests := []struct {
+		name      string
+		tool      string
+		arguments map[string]any
+		response  string
+	}{
+		{
+			name:      "create branch",
+			tool:      "create_branch",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "name": "feature/login", "target": "def456abc123456789012345678901234567890ab"},
+			response:  "/refs/branch-created.json",
+		},
+		{
+			name:      "delete branch",
+			tool:      "delete_branch",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "name": "feature/login", "confirm": true},
+			response:  "/refs/branch-deleted.json",
+		},
+		{
+			name:      "create tag",
+			tool:      "create_tag",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "name": "v1.2.0", "target": "def456abc123456789012345678901234567890ab", "message": "Release 1.2.0"},
+			response:  "/refs/tag-created.json",
+		},
+		{
+			name:      "delete tag",
+			tool:      "delete_tag",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "name": "v1.2.0", "confirm": true},
+			response:  "/refs/tag-deleted.json",
+		},
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testTool(s.T(), s.mcpClient, tt.tool, tt.arguments, tt.response)
+		})
+	}
+}
 
-	result, err := client.ReadResource(ctx, &mcp.ReadResourceParams{URI: uri})
-	require.NoError(t, err, "failed to read resource")
-	require.NotNil(t, result)
-	require.Len(t, result.Contents, len(responses))
+func (s *E2ETestSuite_BasicAuth) TestRefTools_Invalid() {
+	tests := []struct {
+		name      string
+		tool      string
+		arguments map[string]any
+		error     string
+	}{
+		{
+			name:      "delete branch without confirmation",
+			tool:      "delete_branch",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "name": "feature/login"},
+			error:     "confirm",
+		},
+		{
+			name:      "delete tag without confirmation",
+			tool:      "delete_tag",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "name": "v1.2.0", "confirm": false},
+			error:     "confirm: must be true to delete v1.2.0",
+		},
+		{
+			name:      "create branch without target",
+			tool:      "create_branch",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "name": "feature/login", "target": " "},
+			error:     "target: ",
+		},
+	}
 
-	for i, resp := range responses {
-		assert.Equal(t, uri, result.Contents[i].URI)
-		assert.Equal(t, "application/json", result.Contents[i].MIMEType)
-		expectedData := readMcpServerTestData(t, resp)
-		assert.JSONEq(t, string(expectedData), result.Contents[i].Text)
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testToolError(s.T(), s.mcpClient, tt.tool, tt.arguments, util.CodeInvalidParamsErr, tt.error)
+		})
 	}
 }
 
-func testResourceError(t *testing.T, client *mcp.ClientSession, uri string, code int64, error string) {
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+func (s *E2ETestSuite_BasicAuth) TestDeleteBranchTool_NotFound() {
+	arguments := map[string]any{"namespace": "test-workspace", "repository": "test-repository", "name": "missing", "confirm": true}
+	testToolError(s.T(), s.mcpClient, "delete_branch", arguments, util.CodeResourceNotFoundErr, "")
+}
+
+func (s *E2ETestSuite_BasicAuth) TestPipelinesResource() {
+	tests := []struct {
+		name      string
+		uri       string
+		responses []string
+	}{
+		{
+			name:      "all branches",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/pipelines",
+			responses: []string{"/pipelines/list.json"},
+		},
+		{
+			name:      "failed on main",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/pipelines?branch=main&status=failed&size=10",
+			responses: []string{"/pipelines/list.json"},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testResource(s.T(), s.mcpClient, tt.uri, tt.responses)
+		})
+	}
+}
+
+func (s *E2ETestSuite_BasicAuth) TestPipelineResource() {
+	tests := []struct {
+		name      string
+		uri       string
+		responses []string
+	}{
+		{
+			name:      "by build number",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/pipelines/42",
+			responses: []string{"/pipelines/pipeline.json"},
+		},
+		{
+			name:      "by uuid",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/pipelines/9f1c2e3a-4b5d-4e6f-8a9b-0c1d2e3f4a5b",
+			responses: []string{"/pipelines/pipeline.json"},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testResource(s.T(), s.mcpClient, tt.uri, tt.responses)
+		})
+	}
+}
+
+func (s *E2ETestSuite_BasicAuth) TestPipelineResource_NotFound() {
+	uri := "mcp://bitbucket/test-workspace/repositories/test-repository/pipelines/7"
+	testResourceError(s.T(), s.mcpClient, uri, util.CodeResourceNotFoundErr, "Pipeline not found")
+}
+
+func (s *E2ETestSuite_BasicAuth) TestPipelineStepLogResource() {
+	tests := []struct {
+		name      string
+		uri       string
+		responses []string
+	}{
+		{
+			name:      "tail",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/pipelines/42/steps/%7Bb2c3d4e5-f6a7-4b8c-9d0e-1f2a3b4c5d6e%7D/log?length=64",
+			responses: []string{"/pipelines/step-log-tail.json"},
+		},
+		{
+			name:      "range",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/pipelines/%7B9f1c2e3a-4b5d-4e6f-8a9b-0c1d2e3f4a5b%7D/steps/b2c3d4e5-f6a7-4b8c-9d0e-1f2a3b4c5d6e/log?offset=15&length=16",
+			responses: []string{"/pipelines/step-log-range.json"},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testResource(s.T(), s.mcpClient, tt.uri, tt.responses)
+		})
+	}
+}
+
+func (s *E2ETestSuite_BasicAuth) TestPipelineStepLogResource_InvalidOffset() {
+	uri := "mcp://bitbucket/test-workspace/repositories/test-repository/pipelines/42/steps/b2c3d4e5-f6a7-4b8c-9d0e-1f2a3b4c5d6e/log?offset=-1"
+	testResourceError(s.T(), s.mcpClient, uri, util.CodeInvalidParamsErr, "offset")
+}
+
+func (s *E2ETestSuite_BasicAuth) TestPipelineTools() {
+	tests := []struct {
+		name      string
+		tool      string
+		arguments map[string]any
+		response  string
+	}{
+		{
+			name:      "trigger on branch",
+			tool:      "trigger_pipeline",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "branch": "main"},
+			response:  "/pipelines/triggered.json",
+		},
+		{
+			name: "trigger custom pipeline on commit",
+			tool: "trigger_pipeline",
+			arguments: map[string]any{
+				"namespace":  "test-workspace",
+				"repository": "test-repository",
+				"commit":     "def456abc123456789012345678901234567890ab",
+				"custom":     "deploy-staging",
+				"variables":  []map[string]any{{"key": "ENV", "value": "staging"}},
+			},
+			response: "/pipelines/triggered.json",
+		},
+		{
+			name:      "stop",
+			tool:      "stop_pipeline",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "pipeline": "43"},
+			response:  "/pipelines/stopped.json",
+		},
+		{
+			name: "restart",
+			tool: "restart_pipeline",
+			arguments: map[string]any{
+				"namespace":  "test-workspace",
+				"repository": "test-repository",
+				"pipeline":   "46",
+				"variables":  []map[string]any{{"key": "DEPLOY_TOKEN", "value": "secret", "secured": true}},
+			},
+			response: "/pipelines/triggered.json",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testTool(s.T(), s.mcpClient, tt.tool, tt.arguments, tt.response)
+		})
+	}
+}
+
+func (s *E2ETestSuite_BasicAuth) TestPipelineTools_Invalid() {
+	tests := []struct {
+		name      string
+		tool      string
+		arguments map[string]any
+		error     string
+	}{
+		{
+			name:      "trigger without branch or commit",
+			tool:      "trigger_pipeline",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "custom": "deploy-staging"},
+			error:     "branch: either branch or commit is required",
+		},
+		{
+			name: "trigger with duplicate variables",
+			tool: "trigger_pipeline",
+			arguments: map[string]any{
+				"namespace":  "test-workspace",
+				"repository": "test-repository",
+				"branch":     "main",
+				"variables":  []map[string]any{{"key": "ENV", "value": "a"}, {"key": "ENV", "value": "b"}},
+			},
+			error: "variables[1].key: duplicate key ENV",
+		},
+		{
+			name:      "stop completed pipeline",
+			tool:      "stop_pipeline",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "pipeline": "42"},
+			error:     "already completed",
+		},
+		{
+			name:      "restart running pipeline",
+			tool:      "restart_pipeline",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "pipeline": "43"},
+			error:     "pipeline: #43 is still in_progress",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testToolError(s.T(), s.mcpClient, tt.tool, tt.arguments, util.CodeInvalidParamsErr, tt.error)
+		})
+	}
+}
+
+func (s *E2ETestSuite_BasicAuth) TestWaitForPipelineTool() {
+	progress := make(chan *mcp.ProgressNotificationParams, 10)
+	client := mcp.NewClient(&mcp.Implementation{Name: "Progress Client", Version: "1.0.0"}, &mcp.ClientOptions{
+		ProgressNotificationHandler: func(ctx context.Context, req *mcp.ProgressNotificationClientRequest) {
+			progress <- req.Params
+		},
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	result, err := client.ReadResource(ctx, &mcp.ReadResourceParams{URI: uri})
-	require.Error(t, err)
-	assert.Nil(t, result)
+	session, err := client.Connect(ctx, &mcp.StreamableClientTransport{Endpoint: fmt.Sprintf("%s/%s", s.baseURL, "mcp")}, nil)
+	s.Require().NoError(err, "failed to connect to mcp server")
+	defer session.Close()
 
-	var jsonrpcErr *jsonrpc.Error
-	require.ErrorAs(t, err, &jsonrpcErr, "error should be a JSON-RPC error")
-	assert.Equal(t, code, jsonrpcErr.Code, "unexpected error code")
-	assert.Contains(t, jsonrpcErr.Message, error, "unexpected error message")
+	params := &mcp.CallToolParams{
+		Meta:      mcp.Meta{"progressToken": "pipeline-progress"},
+		Name:      "wait_for_pipeline",
+		Arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "pipeline": "45"},
+	}
+
+	result, err := session.CallTool(ctx, params)
+	s.Require().NoError(err, "failed to call tool")
+	s.Require().False(result.IsError, "unexpected tool error")
+	content, ok := result.Content[0].(*mcp.TextContent)
+	s.Require().True(ok, "expected text content")
+	s.JSONEq(string(readMcpServerTestData(s.T(), "/pipelines/waited.json")), content.Text)
+
+	select {
+	case notification := <-progress:
+		s.Equal("pipeline-progress", notification.ProgressToken)
+		s.Equal("Pipeline #45 is RUNNING: Build SUCCESSFUL, Test FAILED", notification.Message)
+	case <-ctx.Done():
+		s.Fail("expected a progress notification")
+	}
 }
 
-type Middleware func(http.Handler) http.Handler
+func (s *E2ETestSuite_BasicAuth) TestWaitForPipelineTool_Completed() {
+	arguments := map[string]any{"namespace": "test-workspace", "repository": "test-repository", "pipeline": "42"}
+	testTool(s.T(), s.mcpClient, "wait_for_pipeline", arguments, "/pipelines/completed.json")
+}
 
-func newBasicAuthMiddleware(username, password string) Middleware {
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			auth := r.Header.Get("Authorization")
-			if auth == "" {
-				w.Header().Set("WWW-Authenticate", `Basic realm="Restricted"`)
-				http.Error(w, "Unauthorized", http.StatusUnauthorized)
-				return
-			}
+func (s *E2ETestSuite_BasicAuth) TestWaitForPipelineTool_Timeout() {
+	arguments := map[string]any{"namespace": "test-workspace", "repository": "test-repository", "pipeline": "43", "timeout_seconds": 1}
+	testTool(s.T(), s.mcpClient, "wait_for_pipeline", arguments, "/pipelines/timed-out.json")
+}
 
-			const prefix = "Basic "
-			if !strings.HasPrefix(auth, prefix) {
-				http.Error(w, "Unauthorized", http.StatusUnauthorized)
-				return
-			}
+func (s *E2ETestSuite_BasicAuth) TestWaitForPipelineTool_Invalid() {
+	arguments := map[string]any{"namespace": "test-workspace", "repository": "test-repository", "pipeline": "42", "timeout_seconds": 7200}
+	testToolError(s.T(), s.mcpClient, "wait_for_pipeline", arguments, util.CodeInvalidParamsErr, "timeout_seconds: ")
+}
 
-			payload, err := base64.StdEncoding.DecodeString(auth[len(prefix):])
-			if err != nil {
-				http.Error(w, "Unauthorized", http.StatusUnauthorized)
-				return
-			}
+func (s *E2ETestSuite_BasicAuth) TestReportBuildStatusTool() {
+	arguments := map[string]any{
+		"namespace":   "test-workspace",
+		"repository":  "test-repository",
+		"commit":      "def456ghi789",
+		"key":         "agent-review",
+		"state":       "successful",
+		"name":        "Agent review",
+		"url":         "https://agents.example.com/reviews/15",
+		"description": "No issues found",
+		"refname":     "feature-branch",
+	}
+	testTool(s.T(), s.mcpClient, "report_build_status", arguments, "/commit/build-status.json")
+}
 
-			parts := strings.SplitN(string(payload), ":", 2)
-			if len(parts) != 2 || parts[0] != username || parts[1] != password {
-				http.Error(w, "Unauthorized", http.StatusUnauthorized)
-				return
-			}
+func (s *E2ETestSuite_BasicAuth) TestReportBuildStatusTool_Invalid() {
+	tests := []struct {
+		name      string
+		arguments map[string]any
+		code      int64
+		error     string
+	}{
+		{
+			name:      "unknown state",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "commit": "def456ghi789", "key": "lint", "state": "passed", "url": "https://ci.example.com/lint/8"},
+			code:      util.CodeInvalidParamsErr,
+			error:     "state: ",
+		},
+		{
+			name:      "unknown commit",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "commit": "0000000", "key": "lint", "state": "FAILED", "url": "https://ci.example.com/lint/8"},
+			code:      util.CodeResourceNotFoundErr,
+			error:     "Commit not found",
+		},
+	}
 
-			next.ServeHTTP(w, r)
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testToolError(s.T(), s.mcpClient, "report_build_status", tt.arguments, tt.code, tt.error)
 		})
 	}
 }
 
-func newOpaqueTokenMiddleware(token string) Middleware {
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			auth := r.Header.Get("Authorization")
-			const prefix = "Bearer "
+func (s *E2ETestSuite_BasicAuth) TestPublishCodeInsightsTool() {
+	tests := []struct {
+		name      string
+		arguments map[string]any
+		response  string
+	}{
+		{
+			name: "sarif",
+			arguments: map[string]any{
+				"namespace":  "test-workspace",
+				"repository": "test-repository",
+				"commit":     "def456ghi789",
+				"sarif":      string(readTestData(s.T(), filepath.Join("sarif", "results.sarif"))),
+			},
+			response: "/commit/code-insights-sarif.json",
+		},
+		{
+			name: "report",
+			arguments: map[string]any{
+				"namespace":  "test-workspace",
+				"repository": "test-repository",
+				"commit":     "def456ghi789",
+				"report": map[string]any{
+					"id":      "agent-review",
+					"title":   "Agent review",
+					"details": "One issue found.",
+					"result":  "failed",
+					"annotations": []any{
+						map[string]any{"id": "missing-check", "summary": "The response status is not checked.", "path": "src/api.js", "line": 42, "type": "bug", "severity": "high"},
+					},
+				},
+			},
+			response: "/commit/code-insights-report.json",
+		},
+	}
 
-			if !strings.HasPrefix(auth, prefix) || auth[len(prefix):] != token {
-				http.Error(w, "Unauthorized", http.StatusUnauthorized)
-				return
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testTool(s.T(), s.mcpClient, "publish_code_insights", tt.arguments, tt.response)
+		})
+	}
+}
+
+func (s *E2ETestSuite_BasicAuth) TestPublishCodeInsightsTool_Invalid() {
+	report := map[string]any{"id": "agent-review", "title": "Agent review"}
+	tests := []struct {
+		name      string
+		arguments map[string]any
+		code      int64
+		error     string
+	}{
+		{
+			name:      "neither sarif nor report",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "commit": "def456ghi789"},
+			code:      util.CodeInvalidParamsErr,
+			error:     "sarif: exactly one",
+		},
+		{
+			name:      "both sarif and report",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "commit": "def456ghi789", "sarif": `{"runs": []}`, "report": report},
+			code:      util.CodeInvalidParamsErr,
+			error:     "sarif: exactly one",
+		},
+		{
+			name:      "malformed sarif",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "commit": "def456ghi789", "sarif": `{"runs": [`},
+			code:      util.CodeInvalidParamsErr,
+			error:     "sarif: ",
+		},
+		{
+			name: "duplicate annotation",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "commit": "def456ghi789", "report": map[string]any{
+				"id":    "agent-review",
+				"title": "Agent review",
+				"annotations": []any{
+					map[string]any{"id": "check", "summary": "First"},
+					map[string]any{"id": "check", "summary": "Second"},
+				},
+			}},
+			code:  util.CodeInvalidParamsErr,
+			error: "report.annotations[1].id: duplicate annotation check",
+		},
+		{
+			name:      "unknown commit",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "commit": "0000000", "report": report},
+			code:      util.CodeResourceNotFoundErr,
+			error:     "Commit not found",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testToolError(s.T(), s.mcpClient, "publish_code_insights", tt.arguments, tt.code, tt.error)
+		})
+	}
+}
+
+func (s *E2ETestSuite_BasicAuth) TestPullRequestReportsResource() {
+	tests := []struct {
+		name      string
+		uri       string
+		responses []string
+	}{
+		{
+			name:      "reports",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/pullrequests/1/reports",
+			responses: []string{"/pullrequest/reports.json"},
+		},
+		{
+			name:      "with annotations",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/pullrequests/1/reports?annotations=true",
+			responses: []string{"/pullrequest/reports-annotations.json"},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testResource(s.T(), s.mcpClient, tt.uri, tt.responses)
+		})
+	}
+}
+
+func (s *E2ETestSuite_BasicAuth) TestPullRequestReportsResource_NotFound() {
+	uri := "mcp://bitbucket/test-workspace/repositories/test-repository/pullrequests/999/reports"
+	testResourceError(s.T(), s.mcpClient, uri, util.CodeResourceNotFoundErr, "Resource not found")
+}
+
+func (s *E2ETestSuite_BasicAuth) TestIssuesResource() {
+	tests := []struct {
+		name      string
+		uri       string
+		responses []string
+	}{
+		{
+			name:      "all",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/issues",
+			responses: []string{"/issues/issues.json"},
+		},
+		{
+			name:      "filtered",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/issues?state=OPEN,on%20hold&kind=bug&assignee=devuser&sort=-priority&size=10",
+			responses: []string{"/issues/issues.json"},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testResource(s.T(), s.mcpClient, tt.uri, tt.responses)
+		})
+	}
+}
+
+func (s *E2ETestSuite_BasicAuth) TestIssuesResource_InvalidFilter() {
+	tests := []struct {
+		name string
+		uri  string
+		err  string
+	}{
+		{
+			name: "state",
+			uri:  "mcp://bitbucket/test-workspace/repositories/test-repository/issues?state=done",
+			err:  "state: ",
+		},
+		{
+			name: "kind",
+			uri:  "mcp://bitbucket/test-workspace/repositories/test-repository/issues?kind=feature",
+			err:  "kind: ",
+		},
+		{
+			name: "priority",
+			uri:  "mcp://bitbucket/test-workspace/repositories/test-repository/issues?priority=urgent",
+			err:  "priority: ",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testResourceError(s.T(), s.mcpClient, tt.uri, util.CodeInvalidParamsErr, tt.err)
+		})
+	}
+}
+
+func (s *E2ETestSuite_BasicAuth) TestIssueResource() {
+	tests := []struct {
+		name      string
+		uri       string
+		responses []string
+	}{
+		{
+			name:      "issue",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/issues/1",
+			responses: []string{"/issues/issue.json"},
+		},
+		{
+			name:      "with comments",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/issues/1?comments=true&format=both",
+			responses: []string{"/issues/issue-comments.json", "/issues/issue-comments.md"},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testResource(s.T(), s.mcpClient, tt.uri, tt.responses)
+		})
+	}
+}
+
+func (s *E2ETestSuite_BasicAuth) TestIssueResource_NotFound() {
+	uri := "mcp://bitbucket/test-workspace/repositories/test-repository/issues/999?comments=true"
+	testResourceError(s.T(), s.mcpClient, uri, util.CodeResourceNotFoundErr, "Issue not found")
+}
+
+func (s *E2ETestSuite_BasicAuth) TestCreateIssueTool() {
+	arguments := map[string]any{
+		"namespace":  "test-workspace",
+		"repository": "test-repository",
+		"title":      "Crash on export",
+		"content":    "Exporting a report crashes the app.",
+		"kind":       "Bug",
+		"priority":   "CRITICAL",
+		"assignee":   "newreviewer",
+		"component":  "export",
+	}
+	testTool(s.T(), s.mcpClient, "create_issue", arguments, "/issues/created.json")
+}
+
+func (s *E2ETestSuite_BasicAuth) TestCreateIssueTool_Invalid() {
+	tests := []struct {
+		name      string
+		arguments map[string]any
+		code      int64
+		error     string
+	}{
+		{
+			name:      "blank title",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "title": " "},
+			code:      util.CodeInvalidParamsErr,
+			error:     "title: ",
+		},
+		{
+			name:      "unknown kind",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "title": "Crash", "kind": "feature"},
+			code:      util.CodeInvalidParamsErr,
+			error:     "kind: ",
+		},
+		{
+			name:      "unknown assignee",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "title": "Crash", "assignee": "ghost"},
+			code:      util.CodeInvalidParamsErr,
+			error:     "assignee not found in workspace: ghost",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testToolError(s.T(), s.mcpClient, "create_issue", tt.arguments, tt.code, tt.error)
+		})
+	}
+}
+
+func (s *E2ETestSuite_BasicAuth) TestAddIssueCommentTool() {
+	arguments := map[string]any{
+		"namespace":  "test-workspace",
+		"repository": "test-repository",
+		"issue_id":   1,
+		"content":    "Fixed in the next release.",
+	}
+	testTool(s.T(), s.mcpClient, "add_issue_comment", arguments, "/issues/comment-created.json")
+}
+
+func (s *E2ETestSuite_BasicAuth) TestAddIssueCommentTool_Invalid() {
+	tests := []struct {
+		name      string
+		arguments map[string]any
+		code      int64
+		error     string
+	}{
+		{
+			name:      "blank content",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "issue_id": 1, "content": ""},
+			code:      util.CodeInvalidParamsErr,
+			error:     "content: ",
+		},
+		{
+			name:      "unknown issue",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "issue_id": 999, "content": "Ping"},
+			code:      util.CodeResourceNotFoundErr,
+			error:     "Issue not found",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testToolError(s.T(), s.mcpClient, "add_issue_comment", tt.arguments, tt.code, tt.error)
+		})
+	}
+}
+
+func (s *E2ETestSuite_BasicAuth) TestWorkspacesResource() {
+	uri := "mcp://bitbucket/workspaces"
+	responses := []string{"workspace/workspaces.json"}
+	testResource(s.T(), s.mcpClient, uri, responses)
+}
+
+func (s *E2ETestSuite_BasicAuth) TestProjectsResource() {
+	uri := "mcp://bitbucket/test-workspace/projects?page=1&size=50"
+	responses := []string{"workspace/projects.json"}
+	testResource(s.T(), s.mcpClient, uri, responses)
+}
+
+func (s *E2ETestSuite_BasicAuth) TestProjectResource() {
+	uri := "mcp://bitbucket/test-workspace/projects/TEST"
+	responses := []string{"workspace/project.json"}
+	testResource(s.T(), s.mcpClient, uri, responses)
+}
+
+func (s *E2ETestSuite_BasicAuth) TestProjectResource_NotFound() {
+	uri := "mcp://bitbucket/test-workspace/projects/MISSING"
+	code := util.CodeResourceNotFoundErr
+	err := "Project MISSING not found in workspace test-workspace"
+	testResourceError(s.T(), s.mcpClient, uri, code, err)
+}
+
+func (s *E2ETestSuite_BasicAuth) TestMembersResource() {
+	uri := "mcp://bitbucket/test-workspace/members?size=100"
+	responses := []string{"workspace/members.json"}
+	testResource(s.T(), s.mcpClient, uri, responses)
+}
+
+func (s *E2ETestSuite_BasicAuth) TestCurrentUserResource() {
+	uri := "mcp://bitbucket/me"
+	responses := []string{"me/user.json"}
+	testResource(s.T(), s.mcpClient, uri, responses)
+	testResource(s.T(), s.mcpClient, uri, responses)
+}
+
+func (s *E2ETestSuite_BasicAuth) TestCodeSearchResource() {
+	tests := []struct {
+		name      string
+		uri       string
+		responses []string
+	}{
+		{
+			name:      "query",
+			uri:       "mcp://bitbucket/test-workspace/search/code?q=NewClient",
+			responses: []string{"/search/code-search.json"},
+		},
+		{
+			name:      "with filters",
+			uri:       "mcp://bitbucket/test-workspace/search/code?q=NewClient&repository=test-repository&language=go&extension=.go&path=internal%2Fclient&page=2&size=5&format=both",
+			responses: []string{"/search/code-search-filtered.json", "/search/code-search-filtered.md"},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testResource(s.T(), s.mcpClient, tt.uri, tt.responses)
+		})
+	}
+}
+
+func (s *E2ETestSuite_BasicAuth) TestCodeSearchResource_Invalid() {
+	tests := []struct {
+		name  string
+		uri   string
+		error string
+	}{
+		{
+			name:  "missing query",
+			uri:   "mcp://bitbucket/test-workspace/search/code",
+			error: "q: ",
+		},
+		{
+			name:  "invalid query",
+			uri:   "mcp://bitbucket/test-workspace/search/code?q=NewClient%28",
+			error: "Invalid search query",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testResourceError(s.T(), s.mcpClient, tt.uri, util.CodeInvalidParamsErr, tt.error)
+		})
+	}
+}
+
+func (s *E2ETestSuite_BasicAuth) TestSearchCodeTool() {
+	arguments := map[string]any{
+		"namespace":  "test-workspace",
+		"query":      "NewClient",
+		"repository": "test-repository",
+		"language":   "go",
+		"extension":  "go",
+		"path":       "internal/client",
+		"page":       2,
+		"size":       5,
+	}
+	testTool(s.T(), s.mcpClient, "search_code", arguments, "/search/tool-results.json")
+}
+
+func (s *E2ETestSuite_BasicAuth) TestSearchCodeTool_DefaultPaging() {
+	arguments := map[string]any{"namespace": "test-workspace", "query": "NewClient"}
+	testTool(s.T(), s.mcpClient, "search_code", arguments, "/search/tool-results-default.json")
+}
+
+func (s *E2ETestSuite_BasicAuth) TestSearchCodeTool_Invalid() {
+	tests := []struct {
+		name      string
+		arguments map[string]any
+		code      int64
+		error     string
+	}{
+		{
+			name:      "blank query",
+			arguments: map[string]any{"namespace": "test-workspace", "query": " "},
+			code:      util.CodeInvalidParamsErr,
+			error:     "query: ",
+		},
+		{
+			name:      "size out of range",
+			arguments: map[string]any{"namespace": "test-workspace", "query": "NewClient", "size": 100},
+			code:      util.CodeInvalidParamsErr,
+			error:     "size: ",
+		},
+		{
+			name:      "path with quote",
+			arguments: map[string]any{"namespace": "test-workspace", "query": "NewClient", "path": `docs" OR "x`},
+			code:      util.CodeInvalidParamsErr,
+			error:     "path: must not contain quotes",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testToolError(s.T(), s.mcpClient, "search_code", tt.arguments, tt.code, tt.error)
+		})
+	}
+}
+
+func (s *E2ETestSuite_BasicAuth) TestMyPullRequestsResource() {
+	tests := []struct {
+		name      string
+		uri       string
+		responses []string
+	}{
+		{
+			name:      "all",
+			uri:       "mcp://bitbucket/me/pullrequests",
+			responses: []string{"/me/pullrequests.json"},
+		},
+		{
+			name:      "reviewer",
+			uri:       "mcp://bitbucket/me/pullrequests?role=reviewer&state=open&page=1&size=10",
+			responses: []string{"/me/pullrequests-reviewing.json"},
+		},
+		{
+			name:      "reviewer in workspace",
+			uri:       "mcp://bitbucket/me/pullrequests?role=reviewer&state=open&workspace=test-workspace&page=1&size=10",
+			responses: []string{"/me/pullrequests-reviewing.json"},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testResource(s.T(), s.mcpClient, tt.uri, tt.responses)
+		})
+	}
+}
+
+func (s *E2ETestSuite_BasicAuth) TestMyPullRequestsResource_BoundedReviewSearch() {
+	uri := "mcp://bitbucket/me/pullrequests?role=reviewer&workspace=busy-workspace"
+	responses := []string{"/me/pullrequests-reviewing-empty.json"}
+	testResource(s.T(), s.mcpClient, uri, responses)
+	s.Equal(int32(1), s.busyRepositoryListings.Load(), "only the first page of repositories must be listed")
+	s.Equal(int32(50), s.busyReviewSearches.Load(), "only the first page of pull requests of 50 repositories must be listed")
+}
+
+func (s *E2ETestSuite_BasicAuth) TestMyPullRequestsResource_InvalidRole() {
+	uri := "mcp://bitbucket/me/pullrequests?role=owner"
+	code := util.CodeInvalidParamsErr
+	err := "role: "
+	testResourceError(s.T(), s.mcpClient, uri, code, err)
+}
+
+func (s *E2ETestSuite_BasicAuth) TestMergeCheckResource() {
+	tests := []struct {
+		name      string
+		uri       string
+		responses []string
+	}{
+		{
+			name:      "blocked",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/pullrequests/1/merge-check",
+			responses: []string{"/pullrequest/merge-check-blocked.json"},
+		},
+		{
+			name:      "mergeable",
+			uri:       "mcp://bitbucket/test-workspace/repositories/test-repository/pullrequests/2/merge-check",
+			responses: []string{"/pullrequest/merge-check.json"},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testResource(s.T(), s.mcpClient, tt.uri, tt.responses)
+		})
+	}
+}
+
+func (s *E2ETestSuite_BasicAuth) TestMergeCheckResource_NotFound() {
+	uri := "mcp://bitbucket/test-workspace/repositories/test-repository/pullrequests/999/merge-check"
+	testResourceError(s.T(), s.mcpClient, uri, util.CodeResourceNotFoundErr, "Resource not found")
+}
+
+func (s *E2ETestSuite_BasicAuth) TestMergePullRequestTool() {
+	tests := []struct {
+		name      string
+		arguments map[string]any
+		response  string
+	}{
+		{
+			name:      "blocked by checks",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "pull_request_id": 1, "strategy": "rebase_merge"},
+			response:  "/pullrequest/merge-blocked.json",
+		},
+		{
+			name:      "rejected by bitbucket",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "pull_request_id": 2, "strategy": "fast_forward"},
+			response:  "/pullrequest/merge-rejected.json",
+		},
+		{
+			name: "merged",
+			arguments: map[string]any{
+				"namespace":           "test-workspace",
+				"repository":          "test-repository",
+				"pull_request_id":     2,
+				"strategy":            "squash",
+				"message":             "Fix login redirect (#2)",
+				"close_source_branch": false,
+			},
+			response: "/pullrequest/merged.json",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testTool(s.T(), s.mcpClient, "merge_pull_request", tt.arguments, tt.response)
+		})
+	}
+}
+
+func (s *E2ETestSuite_BasicAuth) TestMergePullRequestTool_Async() {
+	progress := make(chan *mcp.ProgressNotificationParams, 10)
+	client := mcp.NewClient(&mcp.Implementation{Name: "Progress Client", Version: "1.0.0"}, &mcp.ClientOptions{
+		ProgressNotificationHandler: func(ctx context.Context, req *mcp.ProgressNotificationClientRequest) {
+			progress <- req.Params
+		},
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	session, err := client.Connect(ctx, &mcp.StreamableClientTransport{Endpoint: fmt.Sprintf("%s/%s", s.baseURL, "mcp")}, nil)
+	s.Require().NoError(err, "failed to connect to mcp server")
+	defer session.Close()
+
+	params := &mcp.CallToolParams{
+		Meta:      mcp.Meta{"progressToken": "merge-progress"},
+		Name:      "merge_pull_request",
+		Arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "pull_request_id": 2, "strategy": "merge_commit"},
+	}
+
+	result, err := session.CallTool(ctx, params)
+	s.Require().NoError(err, "failed to call tool")
+	s.Require().False(result.IsError, "unexpected tool error")
+	content, ok := result.Content[0].(*mcp.TextContent)
+	s.Require().True(ok, "expected text content")
+	s.JSONEq(string(readMcpServerTestData(s.T(), "/pullrequest/merged.json")), content.Text)
+
+	select {
+	case notification := <-progress:
+		s.Equal("merge-progress", notification.ProgressToken)
+		s.Contains(notification.Message, "task merge-task-1")
+	case <-ctx.Done():
+		s.Fail("expected a progress notification")
+	}
+}
+
+func (s *E2ETestSuite_BasicAuth) TestMergePullRequestTool_UnknownResult() {
+	arguments := map[string]any{"namespace": "test-workspace", "repository": "test-repository", "pull_request_id": 2, "strategy": "merge_commit", "message": "Unknown result"}
+	testToolError(s.T(), s.mcpClient, "merge_pull_request", arguments, util.CodeResourceUnavailableErr, "result is unknown (task merge-task-2)")
+}
+
+func (s *E2ETestSuite_BasicAuth) TestMergePullRequestTool_Invalid() {
+	arguments := map[string]any{"namespace": "test-workspace", "repository": "test-repository", "pull_request_id": 2, "strategy": "octopus"}
+	testToolError(s.T(), s.mcpClient, "merge_pull_request", arguments, util.CodeInvalidParamsErr, "strategy: ")
+}
+
+func (s *E2ETestSuite_BasicAuth) TestPullRequestReviewTools() {
+	tests := []struct {
+		name      string
+		tool      string
+		arguments map[string]any
+		response  string
+	}{
+		{
+			name:      "approve",
+			tool:      "approve_pull_request",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "pull_request_id": 1},
+			response:  "/pullrequest/review.json",
+		},
+		{
+			name:      "unapprove",
+			tool:      "unapprove_pull_request",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "pull_request_id": 1},
+			response:  "/pullrequest/review.json",
+		},
+		{
+			name:      "request changes",
+			tool:      "request_changes",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "pull_request_id": 1},
+			response:  "/pullrequest/review.json",
+		},
+		{
+			name:      "remove change request",
+			tool:      "request_changes",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "pull_request_id": 1, "remove": true},
+			response:  "/pullrequest/review.json",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testTool(s.T(), s.mcpClient, tt.tool, tt.arguments, tt.response)
+		})
+	}
+}
+
+func (s *E2ETestSuite_BasicAuth) TestPullRequestTaskTools() {
+	tests := []struct {
+		name      string
+		tool      string
+		arguments map[string]any
+		response  string
+	}{
+		{
+			name:      "create from comment",
+			tool:      "create_pull_request_task",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "pull_request_id": 1, "content": "Rename the helper method", "comment_id": 987654321},
+			response:  "/pullrequest/task.json",
+		},
+		{
+			name:      "resolve",
+			tool:      "resolve_pull_request_task",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "pull_request_id": 1, "task_id": 2},
+			response:  "/pullrequest/task-resolved.json",
+		},
+		{
+			name:      "reopen",
+			tool:      "resolve_pull_request_task",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "pull_request_id": 1, "task_id": 2, "reopen": true},
+			response:  "/pullrequest/task.json",
+		},
+		{
+			name:      "delete",
+			tool:      "delete_pull_request_task",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "pull_request_id": 1, "task_id": 2},
+			response:  "/pullrequest/task-deleted.json",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testTool(s.T(), s.mcpClient, tt.tool, tt.arguments, tt.response)
+		})
+	}
+}
+
+func (s *E2ETestSuite_BasicAuth) TestPullRequestTaskTools_Invalid() {
+	arguments := map[string]any{"namespace": "test-workspace", "repository": "test-repository", "pull_request_id": 1, "content": " "}
+	testToolError(s.T(), s.mcpClient, "create_pull_request_task", arguments, util.CodeInvalidParamsErr, "content: ")
+}
+
+func (s *E2ETestSuite_BasicAuth) TestCreatePullRequestTool() {
+	tests := []struct {
+		name      string
+		arguments map[string]any
+	}{
+		{
+			name: "with default reviewers",
+			arguments: map[string]any{
+				"namespace":  "test-workspace",
+				"repository": "test-repository",
+				"title":      "Add new feature",
+				"source":     "feature-branch",
+				"reviewers":  []string{"newreviewer", "reviewerone"},
+			},
+		},
+		{
+			name: "without default reviewers",
+			arguments: map[string]any{
+				"namespace":              "test-workspace",
+				"repository":             "test-repository",
+				"title":                  "Add new feature",
+				"source":                 "feature-branch",
+				"draft":                  true,
+				"skip_default_reviewers": true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testTool(s.T(), s.mcpClient, "create_pull_request", tt.arguments, "/pullrequest/created.json")
+		})
+	}
+}
+
+func (s *E2ETestSuite_BasicAuth) TestCreatePullRequestTool_Invalid() {
+	arguments := map[string]any{"namespace": "test-workspace", "repository": "test-repository", "title": "Add new feature", "source": " "}
+	testToolError(s.T(), s.mcpClient, "create_pull_request", arguments, util.CodeInvalidParamsErr, "source: ")
+}
+
+func (s *E2ETestSuite_BasicAuth) TestUpdatePullRequestTool() {
+	arguments := map[string]any{
+		"namespace":        "test-workspace",
+		"repository":       "test-repository",
+		"pull_request_id":  1,
+		"title":            "Add new feature with reviewers",
+		"draft":            true,
+		"add_reviewers":    []string{"NewReviewer", "reviewertwo"},
+		"remove_reviewers": []string{"reviewer-one-account-id"},
+	}
+	testTool(s.T(), s.mcpClient, "update_pull_request", arguments, "/pullrequest/updated.json")
+}
+
+func (s *E2ETestSuite_BasicAuth) TestUpdatePullRequestTool_Invalid() {
+	tests := []struct {
+		name      string
+		arguments map[string]any
+		code      int64
+		err       string
+	}{
+		{
+			name:      "no changes",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "pull_request_id": 1},
+			code:      util.CodeInvalidParamsErr,
+			err:       "no changes to apply",
+		},
+		{
+			name:      "blank title",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "pull_request_id": 1, "title": " "},
+			code:      util.CodeInvalidParamsErr,
+			err:       "title: ",
+		},
+		{
+			name:      "unknown reviewer",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "pull_request_id": 1, "add_reviewers": []string{"ghost"}},
+			code:      util.CodeInvalidParamsErr,
+			err:       "reviewer not found in workspace: ghost",
+		},
+		{
+			name:      "not found",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "pull_request_id": 999, "draft": false},
+			code:      util.CodeResourceNotFoundErr,
+			err:       "Resource not found at",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testToolError(s.T(), s.mcpClient, "update_pull_request", tt.arguments, tt.code, tt.err)
+		})
+	}
+}
+
+func (s *E2ETestSuite_BasicAuth) TestPullRequestReviewTools_Invalid() {
+	tests := []struct {
+		name      string
+		arguments map[string]any
+		code      int64
+		err       string
+	}{
+		{
+			name:      "blank namespace",
+			arguments: map[string]any{"namespace": " ", "repository": "test-repository", "pull_request_id": 1},
+			code:      util.CodeInvalidParamsErr,
+			err:       "namespace: ",
+		},
+		{
+			name:      "invalid pull request id",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "pull_request_id": 0},
+			code:      util.CodeInvalidParamsErr,
+			err:       "pull_request_id: ",
+		},
+		{
+			name:      "not found",
+			arguments: map[string]any{"namespace": "test-workspace", "repository": "test-repository", "pull_request_id": 999},
+			code:      util.CodeResourceNotFoundErr,
+			err:       "Resource not found at",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			testToolError(s.T(), s.mcpClient, "approve_pull_request", tt.arguments, tt.code, tt.err)
+		})
+	}
+}
+
+// E2ETestSuite_OAuth is the test suite for end-to-end tests with OAuth authentication
+type E2ETestSuite_OAuth struct {
+	suite.Suite
+	baseURL    string
+	mcpClient  *mcp.ClientSession
+	httpClient *http.Client
+	server     *server.McpServer
+	bitbucket  *httptest.Server
+	cfg        config.Global
+}
+
+func TestE2E_OAuth(t *testing.T) {
+	suite.Run(t, new(E2ETestSuite_OAuth))
+}
+
+func (s *E2ETestSuite_OAuth) SetupSuite() {
+	s.SetupBitbucketServer()
+	s.SetupMcpServer()
+	s.SetupMcpClient()
+}
+
+func (s *E2ETestSuite_OAuth) SetupBitbucketServer() {
+	mux := http.NewServeMux()
+	newBitbucketRepositoriesHandler(s.T(), mux)
+	newBitbucketUserHandler(s.T(), mux)
+	newBitbucketUserEmailsForbiddenHandler(s.T(), mux)
+	auth := newOpaqueTokenMiddleware("random-valid-token")
+	s.bitbucket = httptest.NewServer(auth(mux))
+}
+
+func (s *E2ETestSuite_OAuth) SetupMcpServer() {
+	// Clear config cache to ensure environment variables are re-read for this test
+	config.ClearCache()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err, "failed to find available port")
+
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	s.T().Setenv("SERVER_PORT", strconv.Itoa(port))
+	s.T().Setenv("BITBUCKET_URL", s.bitbucket.URL)
+	s.T().Setenv("BITBUCKET_TIMEOUT", "5")
+	s.T().Setenv("BITBUCKET_AUTH", "oauth")
+	s.T().Setenv("SERVER_URL", fmt.Sprintf("http://127.0.0.1:%d", port))
+	s.T().Setenv("OAUTH_ISSUER", "https://bitbucket.org")
+	s.T().Setenv("OAUTH_SCOPES", "repository;pullrequest")
+
+	cfg := config.NewGlobal()
+	s.server = server.NewMcpServer(cfg)
+	s.baseURL = fmt.Sprintf("http://127.0.0.1:%d", port)
+	s.httpClient = &http.Client{Timeout: 5 * time.Second}
+
+	go func() {
+		if err := s.server.Run(); err != nil && err != http.ErrServerClosed {
+			s.T().Logf("server error: %v", err)
+		}
+	}()
+
+	s.Require().NoError(s.server.WaitUntilReady(5*time.Second), "server failed to start")
+}
+
+func (s *E2ETestSuite_OAuth) SetupMcpClient() {
+	client := mcp.NewClient(&mcp.Implementation{
+		Name:    "Test Client",
+		Version: "1.0.0",
+	}, nil)
+	transport := &mcp.StreamableClientTransport{
+		Endpoint: fmt.Sprintf("%s/%s", s.baseURL, "mcp"),
+		HTTPClient: &http.Client{
+			Transport: &oauthTransport{
+				base:  http.DefaultTransport,
+				token: "random-valid-token",
+			},
+		},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	session, err := client.Connect(ctx, transport, nil)
+	s.Require().NoError(err, "failed to connect to mcp server")
+	s.mcpClient = session
+}
+
+func (s *E2ETestSuite_OAuth) TearDownSuite() {
+	if s.mcpClient != nil {
+		s.mcpClient.Close()
+	}
+
+	if s.server != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		s.Require().NoError(s.server.Shutdown(ctx), "failed to shutdown server")
+	}
+
+	if s.bitbucket != nil {
+		s.bitbucket.Close()
+	}
+}
+
+func (s *E2ETestSuite_OAuth) TestHealthEndpoint() {
+	url := s.baseURL + "/health"
+	req, err := http.NewRequest("GET", url, nil)
+	s.Require().NoError(err, "failed to create GET /health request")
+
+	resp, err := s.httpClient.Do(req)
+	s.Require().NoError(err, "failed to make GET /health request")
+	s.Assert().Equal(http.StatusOK, resp.StatusCode)
+	s.Assert().Equal("application/json", resp.Header.Get("Content-Type"))
+	s.Assert().Equal(`{"status":"ok"}`, strings.TrimSpace(readResponseBody(s.T(), resp)))
+}
+
+func (s *E2ETestSuite_OAuth) TestMcpInitialize() {
+	s.Assert().Equal("Bitbucket MCP", s.mcpClient.InitializeResult().ServerInfo.Title)
+	s.Assert().Equal("1.0.0", s.mcpClient.InitializeResult().ServerInfo.Version)
+}
+
+func (s *E2ETestSuite_OAuth) TestOAuthMetadataEndpoint() {
+	url := s.baseURL + "/.well-known/oauth-protected-resource"
+	req, err := http.NewRequest("GET", url, nil)
+	s.Require().NoError(err, "failed to create GET metadata request")
+
+	resp, err := s.httpClient.Do(req)
+	s.Require().NoError(err, "failed to make GET metadata request")
+	s.Assert().Equal(http.StatusOK, resp.StatusCode)
+	s.Assert().Equal("application/json", resp.Header.Get("Content-Type"))
+
+	body := readResponseBody(s.T(), resp)
+
+	expected := fmt.Sprintf(`{
+		"resource": "%s/mcp",
+		"authorization_servers":["https://bitbucket.org/site/oauth2/access_token"],
+		"scopes_supported":["repository","pullrequest"]
+	}`, s.baseURL)
+
+	s.Assert().JSONEq(expected, body)
+}
+
+func (s *E2ETestSuite_OAuth) TestRepositoriesResource() {
+	uri := "mcp://bitbucket/test-workspace/repositories?page=1&pageSize=50"
+	responses := []string{"repositories.json"}
+	testResource(s.T(), s.mcpClient, uri, responses)
+}
+
+func (s *E2ETestSuite_OAuth) TestCurrentUserResource() {
+	uri := "mcp://bitbucket/me"
+	responses := []string{"me/user-without-emails.json"}
+	testResource(s.T(), s.mcpClient, uri, responses)
+	testResource(s.T(), s.mcpClient, uri, responses)
+}
+
+func testResource(t *testing.T, client *mcp.ClientSession, uri string, responses []string) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := client.ReadResource(ctx, &mcp.ReadResourceParams{URI: uri})
+	require.NoError(t, err, "failed to read resource")
+	require.NotNil(t, result)
+	require.Len(t, result.Contents, len(responses))
+
+	for i, resp := range responses {
+		assert.Equal(t, uri, result.Contents[i].URI)
+		expectedData := readMcpServerTestData(t, resp)
+		if filepath.Ext(resp) == ".md" {
+			assert.Equal(t, "text/markdown", result.Contents[i].MIMEType)
+			assert.Equal(t, string(expectedData), result.Contents[i].Text)
+		} else {
+			assert.Equal(t, "application/json", result.Contents[i].MIMEType)
+			assert.JSONEq(t, string(expectedData), result.Contents[i].Text)
+		}
+	}
+}
+
+func testResourceError(t *testing.T, client *mcp.ClientSession, uri string, code int64, error string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := client.ReadResource(ctx, &mcp.ReadResourceParams{URI: uri})
+	require.Error(t, err)
+	assert.Nil(t, result)
+
+	var jsonrpcErr *jsonrpc.Error
+	require.ErrorAs(t, err, &jsonrpcErr, "error should be a JSON-RPC error")
+	assert.Equal(t, code, jsonrpcErr.Code, "unexpected error code")
+	assert.Contains(t, jsonrpcErr.Message, error, "unexpected error message")
+}
+
+func testTool(t *testing.T, client *mcp.ClientSession, name string, arguments map[string]any, response string) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := client.CallTool(ctx, &mcp.CallToolParams{Name: name, Arguments: arguments})
+	require.NoError(t, err, "failed to call tool")
+	require.NotNil(t, result)
+	require.False(t, result.IsError, "unexpected tool error")
+	require.Len(t, result.Content, 1)
+
+	content, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected text content")
+	assert.JSONEq(t, string(readMcpServerTestData(t, response)), content.Text)
+}
+
+func testToolError(t *testing.T, client *mcp.ClientSession, name string, arguments map[string]any, code int64, error string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := client.CallTool(ctx, &mcp.CallToolParams{Name: name, Arguments: arguments})
+	require.Error(t, err)
+	assert.Nil(t, result)
+
+	var jsonrpcErr *jsonrpc.Error
+	require.ErrorAs(t, err, &jsonrpcErr, "error should be a JSON-RPC error")
+	assert.Equal(t, code, jsonrpcErr.Code, "unexpected error code")
+	assert.Contains(t, jsonrpcErr.Message, error, "unexpected error message")
+}
+
+type Middleware func(http.Handler) http.Handler
+
+func newBasicAuthMiddleware(username, password string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			auth := r.Header.Get("Authorization")
+			if auth == "" {
+				w.Header().Set("WWW-Authenticate", `Basic realm="Restricted"`)
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			const prefix = "Basic "
+			if !strings.HasPrefix(auth, prefix) {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			payload, err := base64.StdEncoding.DecodeString(auth[len(prefix):])
+			if err != nil {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			parts := strings.SplitN(string(payload), ":", 2)
+			if len(parts) != 2 || parts[0] != username || parts[1] != password {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func newOpaqueTokenMiddleware(token string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			auth := r.Header.Get("Authorization")
+			const prefix = "Bearer "
+
+			if !strings.HasPrefix(auth, prefix) || auth[len(prefix):] != token {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func newBitbucketRepositoriesHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		query := r.URL.Query()
+		if query.Get("role") == "member" && query.Get("q") == "" {
+			assert.Equal(t, "-updated_on", query.Get("sort"))
+			assert.Equal(t, "50", query.Get("pagelen"))
+			assert.Equal(t, "1", query.Get("page"))
+			w.WriteHeader(http.StatusOK)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(readBitbucketTestData(t, "repositories-member.json"))
+			return
+		}
+		if q := query.Get("q"); strings.HasPrefix(q, "project.key = ") {
+			assert.Regexp(t, `^project\.key = "[A-Z]+"$`, q)
+			assert.Equal(t, "name", query.Get("sort"))
+		} else if q != "" {
+			assert.Equal(t, `name ~ "repo" AND project.key = "TEST" AND (language = "go" OR language = "python") AND is_private = true AND updated_on > 2023-06-01T00:00:00Z`, q)
+			assert.Equal(t, "-updated_on", query.Get("sort"))
+			assert.Equal(t, "contributor", query.Get("role"))
+			assert.Equal(t, "20", query.Get("pagelen"))
+			assert.Equal(t, "2", query.Get("page"))
+		} else {
+			assert.Equal(t, "50", query.Get("pagelen"))
+			assert.Equal(t, "1", query.Get("page"))
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(readBitbucketTestData(t, "repositories.json"))
+	})
+}
+
+func newBitbucketBusyWorkspaceHandlers(t *testing.T, mux *http.ServeMux) (listings *atomic.Int32, searches *atomic.Int32) {
+	listings, searches = &atomic.Int32{}, &atomic.Int32{}
+	mux.HandleFunc("/repositories/busy-workspace", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		listings.Add(1)
+		query := r.URL.Query()
+		assert.Equal(t, "member", query.Get("role"))
+		assert.Equal(t, "-updated_on", query.Get("sort"))
+		pagelen, err := strconv.Atoi(query.Get("pagelen"))
+		require.NoError(t, err)
+		repositories := make([]map[string]any, pagelen)
+		for i := range repositories {
+			repositories[i] = map[string]any{"slug": fmt.Sprintf("repository-%d", i+1)}
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"pagelen": pagelen,
+			"page":    1,
+			"size":    1000,
+			"next":    "https://api.bitbucket.org/2.0/repositories/busy-workspace?page=2",
+			"values":  repositories,
+		})
+	})
+	mux.HandleFunc("/repositories/busy-workspace/{repository}/pullrequests", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		searches.Add(1)
+		assert.Equal(t, "1", r.URL.Query().Get("page"))
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"pagelen": 50,
+			"page":    1,
+			"next":    "https://api.bitbucket.org/2.0/repositories/busy-workspace/" + r.PathValue("repository") + "/pullrequests?page=2",
+			"values":  []any{},
+		})
+	})
+	return listings, searches
+}
+
+func newBitbucketRepositoriesNotFoundHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/invalid-workspace", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(readBitbucketTestData(t, "not-found.json"))
+	})
+}
+
+func newBitbucketRepositoryHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(readBitbucketTestData(t, "repository.json"))
+	})
+}
+
+func newBitbucketRepositoryWithoutReadmeHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository-without-readme", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(readBitbucketTestData(t, "repository.json"))
+	})
+}
+
+func newBitbucketRepositoryNotFoundHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/invalid-repository", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(readBitbucketTestData(t, "not-found.json"))
+	})
+}
+
+func newBitbucketRepositorySourceHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/src", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(readBitbucketTestData(t, "repository-source.json"))
+	})
+}
+
+func newBitbucketRepositorySourceWithoutReadmeHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository-without-readme/src", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(readBitbucketTestData(t, "repository-source-without-readme.json"))
+	})
+}
+
+func newBitbucketRepositorySourceNotFoundHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/invalid-repository/src", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(readBitbucketTestData(t, "not-found.json"))
+	})
+}
+
+func newBitbucketFileSourceReadmeHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/src/abc123def456/README.md", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write(readBitbucketTestData(t, "file-source-readme.md"))
+	})
+}
+
+func readBitbucketTestData(t *testing.T, filename string) []byte {
+	t.Helper()
+	return readTestData(t, filepath.Join("bitbucket", filename))
+}
+
+func readMcpServerTestData(t *testing.T, filename string) []byte {
+	t.Helper()
+	return readTestData(t, filepath.Join("mcpserver", filename))
+}
+
+func readTestData(t *testing.T, filename string) []byte {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", filename))
+	require.NoError(t, err, fmt.Sprintf("failed to read test data file %s", filename))
+	return data
+}
+
+func readResponseBody(t *testing.T, resp *http.Response) string {
+	t.Helper()
+
+	var body string
+	err := web.ReadResponseText(resp, &body)
+	require.NoError(t, err, "failed to read response body")
+	return body
+}
+
+type oauthTransport struct {
+	base  http.RoundTripper
+	token string
+}
+
+func (t *oauthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req2 := req.Clone(req.Context())
+	req2.Header.Set("Authorization", "Bearer "+t.token)
+	return t.base.RoundTrip(req2)
+}
+
+func newBitbucketPullRequestsHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/pullrequests", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			query := r.URL.Query()
+			assert.Equal(t, "+values.reviewers,+values.participants", query.Get("fields"))
+			if q := query.Get("q"); q == `reviewers.uuid = "{test-user-uuid}"` {
+				assert.Equal(t, "-updated_on", query.Get("sort"))
+				assert.Equal(t, "50", query.Get("pagelen"))
+				w.WriteHeader(http.StatusOK)
+				w.Header().Set("Content-Type", "application/json")
+				w.Write(readBitbucketTestData(t, "user-pull-requests-reviewing.json"))
+				return
+			}
+			assert.Equal(t, "OPEN,MERGED", query.Get("state"))
+			assert.Equal(t, `(author.nickname = "testuser" OR author.account_id = "testuser") AND (title~"feature")`, query.Get("q"))
+			assert.Equal(t, "-updated_on", query.Get("sort"))
+			assert.Equal(t, "10", query.Get("pagelen"))
+			assert.Equal(t, "1", query.Get("page"))
+			w.WriteHeader(http.StatusOK)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(readBitbucketTestData(t, "pull-requests.json"))
+		case http.MethodPost:
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, map[string]any{"branch": map[string]any{"name": "feature-branch"}}, body["source"])
+			if body["draft"] == true {
+				assert.NotContains(t, body, "reviewers")
+			} else {
+				assert.Equal(t, []any{
+					map[string]any{"uuid": "{reviewer-one-uuid}"},
+					map[string]any{"uuid": "{reviewer-two-uuid}"},
+					map[string]any{"uuid": "{new-reviewer-uuid}"},
+				}, body["reviewers"])
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(readBitbucketTestData(t, "pull-request.json"))
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func newBitbucketEffectiveDefaultReviewersHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/effective-default-reviewers", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(readBitbucketTestData(t, "effective-default-reviewers.json"))
+	})
+}
+
+func newBitbucketUserHandler(t *testing.T, mux *http.ServeMux) {
+	var calls atomic.Int32
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		assert.Equal(t, int32(1), calls.Add(1), "the current user must be cached")
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(readBitbucketTestData(t, "user.json"))
+	})
+}
+
+func newBitbucketUserPullRequestsHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/pullrequests/test-account-id", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		query := r.URL.Query()
+		assert.Equal(t, "-updated_on", query.Get("sort"))
+		assert.Contains(t, query.Get("fields"), "+values.participants")
+		if query.Get("q") != `author.uuid = "{test-user-uuid}"` {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(readBitbucketTestData(t, "user-pull-requests-authored.json"))
+	})
+}
+
+func newBitbucketPullRequestApproveHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/pullrequests/1/approve", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusOK)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(readBitbucketTestData(t, "pull-request-participant.json"))
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func newBitbucketPullRequestRequestChangesHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/pullrequests/1/request-changes", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusOK)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(readBitbucketTestData(t, "pull-request-participant.json"))
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func newBitbucketPullRequestActivityHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/pullrequests/1/activity", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		file := "pull-request-activity.json"
+		if r.URL.Query().Get("ctx") == "page-2-cursor" {
+			file = "pull-request-activity-2.json"
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(readBitbucketTestData(t, file))
+	})
+}
+
+func newBitbucketPullRequestActivityInvalidDateHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/pullrequests/3/activity", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		file := "pull-request-activity-invalid-date.json"
+		if r.URL.Query().Get("ctx") == "page-2-cursor" {
+			file = "pull-request-activity-2.json"
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(readBitbucketTestData(t, file))
+	})
+}
+
+func newBitbucketPullRequestTasksHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/pullrequests/1/tasks", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.WriteHeader(http.StatusOK)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(readBitbucketTestData(t, "pull-request-tasks.json"))
+		case http.MethodPost:
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, map[string]any{"raw": "Rename the helper method"}, body["content"])
+			assert.Equal(t, map[string]any{"id": float64(987654321)}, body["comment"])
+			w.WriteHeader(http.StatusCreated)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(readBitbucketTestData(t, "pull-request-task.json"))
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func newBitbucketPullRequestTaskHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/pullrequests/1/tasks/2", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			file := "pull-request-task.json"
+			if body["state"] == "RESOLVED" {
+				file = "pull-request-task-resolved.json"
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(readBitbucketTestData(t, file))
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func newBitbucketPullRequestHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/pullrequests/1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.WriteHeader(http.StatusOK)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(readBitbucketTestData(t, "pull-request.json"))
+		case http.MethodPut:
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "Add new feature with reviewers", body["title"])
+			assert.Equal(t, true, body["draft"])
+			assert.NotContains(t, body, "description")
+			assert.Equal(t, []any{
+				map[string]any{"uuid": "{reviewer-two-uuid}"},
+				map[string]any{"uuid": "{new-reviewer-uuid}"},
+			}, body["reviewers"])
+			w.WriteHeader(http.StatusOK)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(readBitbucketTestData(t, "pull-request-updated.json"))
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/repositories/test-workspace/test-repository/pullrequests/4", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(readBitbucketTestData(t, "pull-request-fork.json"))
+	})
+	mux.HandleFunc("/repositories/test-workspace/test-repository/pullrequests/5", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(readBitbucketTestData(t, "pull-request.json"))
+	})
+}
+
+func newBitbucketWorkspaceMembersHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/workspaces/test-workspace/members", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(readBitbucketTestData(t, "workspace-members.json"))
+	})
+}
+
+func newBitbucketPullRequestNotFoundHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/pullrequests/999", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write(readBitbucketTestData(t, "pull-request-not-found.txt"))
+	})
+}
+
+func newBitbucketPullRequestCommitsHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/pullrequests/1/commits", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(readBitbucketTestData(t, "pull-request-commits.json"))
+	})
+}
+
+func newBitbucketPullRequestCommitsNotFoundHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/pullrequests/999/commits", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write(readBitbucketTestData(t, "pull-request-not-found.txt"))
+	})
+}
+
+func newBitbucketPullRequestDiffHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/pullrequests/1/diff", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write(readBitbucketTestData(t, "pull-request-diff.txt"))
+	})
+}
+
+func newBitbucketPullRequestDiffNotFoundHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/pullrequests/999/diff", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write(readBitbucketTestData(t, "pull-request-not-found.txt"))
+	})
+}
+
+func newBitbucketPullRequestCommentsHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/pullrequests/1/comments", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(readBitbucketTestData(t, "pull-request-comments.json"))
+	})
+}
+
+func newBitbucketPullRequestCommentsNotFoundHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/pullrequests/999/comments", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write(readBitbucketTestData(t, "pull-request-not-found.txt"))
+	})
+}
+
+func newBitbucketBranchHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/refs/branches/main", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(readBitbucketTestData(t, "branch-main.json"))
+	})
+}
+
+func newBitbucketBranchRestrictionsHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/branch-restrictions", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(readBitbucketTestData(t, "branch-restrictions.json"))
+	})
+}
+
+func newBitbucketEffectiveBranchingModelHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/effective-branching-model", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(readBitbucketTestData(t, "effective-branching-model.json"))
+	})
+}
+
+func newBitbucketPullRequestDiffStatHandler(t *testing.T, mux *http.ServeMux) {
+	files := map[string]string{
+		"1": "pull-request-diffstat-conflict.json",
+		"2": "pull-request-diffstat.json",
+	}
+	mux.HandleFunc("/repositories/test-workspace/test-repository/pullrequests/{id}/diffstat", func(w http.ResponseWriter, r *http.Request) {
+		file, ok := files[r.PathValue("id")]
+		if r.Method != http.MethodGet || !ok {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(readBitbucketTestData(t, file))
+	})
+}
+
+func newBitbucketPullRequestStatusesHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/pullrequests/{id}/statuses", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if r.PathValue("id") == "4" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			w.Write(readBitbucketTestData(t, "pull-request-statuses-forbidden.json"))
+			return
+		}
+		if r.PathValue("id") == "5" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		file := "pull-request-statuses.json"
+		if r.PathValue("id") == "2" {
+			file = "pull-request-statuses-stopped.json"
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(readBitbucketTestData(t, file))
+	})
+}
+
+func newBitbucketMergeablePullRequestHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/pullrequests/2", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(readBitbucketTestData(t, "pull-request-mergeable.json"))
+	})
+}
+
+func newBitbucketPullRequestMergeHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/pullrequests/2/merge", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.Header().Set("Content-Type", "application/json")
+		if body["merge_strategy"] == "fast_forward" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(readBitbucketTestData(t, "pull-request-merge-rejected.json"))
+			return
+		}
+		if body["merge_strategy"] == "merge_commit" {
+			task := "merge-task-1"
+			if body["message"] == "Unknown result" {
+				task = "merge-task-2"
 			}
+			w.Header().Set("Location", "http://"+r.Host+r.URL.Path+"/task-status/"+task)
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		assert.Equal(t, "squash", body["merge_strategy"])
+		assert.Equal(t, "Fix login redirect (#2)", body["message"])
+		assert.Equal(t, false, body["close_source_branch"])
+		w.WriteHeader(http.StatusOK)
+		w.Write(readBitbucketTestData(t, "pull-request-merged.json"))
+	})
+}
 
-			next.ServeHTTP(w, r)
-		})
-	}
+func newBitbucketPullRequestMergeTaskStatusHandler(t *testing.T, mux *http.ServeMux) {
+	var polls atomic.Int32
+	mux.HandleFunc("/repositories/test-workspace/test-repository/pullrequests/2/merge/task-status/merge-task-1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if polls.Add(1)%2 == 1 {
+			w.Write([]byte(`{"task_status": "PENDING"}`))
+			return
+		}
+		w.Write(readBitbucketTestData(t, "pull-request-merge-task-status.json"))
+	})
+	mux.HandleFunc("/repositories/test-workspace/test-repository/pullrequests/2/merge/task-status/merge-task-2", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"type": "error", "error": {"message": "Access denied"}}`))
+	})
 }
 
-func newBitbucketRepositoriesHandler(t *testing.T, mux *http.ServeMux) {
-	mux.HandleFunc("/repositories/test-workspace", func(w http.ResponseWriter, r *http.Request) {
+func newBitbucketCommitsHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/commits", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			w.WriteHeader(http.StatusMethodNotAllowed)
 			return
 		}
+		query := r.URL.Query()
+		assert.Equal(t, "50", query.Get("pagelen"))
+		if query.Has("include") {
+			assert.Equal(t, "feature", query.Get("include"))
+			assert.Equal(t, "main", query.Get("exclude"))
+			assert.Equal(t, "src/input.go", query.Get("path"))
+			assert.Equal(t, "2", query.Get("page"))
+		}
 		w.WriteHeader(http.StatusOK)
 		w.Header().Set("Content-Type", "application/json")
-		w.Write(readBitbucketTestData(t, "repositories.json"))
+		w.Write(readBitbucketTestData(t, "commits.json"))
 	})
 }
 
-func newBitbucketRepositoriesNotFoundHandler(t *testing.T, mux *http.ServeMux) {
-	mux.HandleFunc("/repositories/invalid-workspace", func(w http.ResponseWriter, r *http.Request) {
+func newBitbucketCommitHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/commit/{hash}", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			w.WriteHeader(http.StatusMethodNotAllowed)
 			return
 		}
-		w.WriteHeader(http.StatusNotFound)
 		w.Header().Set("Content-Type", "application/json")
-		w.Write(readBitbucketTestData(t, "not-found.json"))
+		if r.PathValue("hash") != "def456abc123" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write(readBitbucketTestData(t, "commit-not-found.json"))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write(readBitbucketTestData(t, "commit.json"))
 	})
 }
 
-func newBitbucketRepositoryHandler(t *testing.T, mux *http.ServeMux) {
-	mux.HandleFunc("/repositories/test-workspace/test-repository", func(w http.ResponseWriter, r *http.Request) {
+func newBitbucketCommitDiffHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/diff/def456abc123", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			w.WriteHeader(http.StatusMethodNotAllowed)
 			return
 		}
 		w.WriteHeader(http.StatusOK)
-		w.Header().Set("Content-Type", "application/json")
-		w.Write(readBitbucketTestData(t, "repository.json"))
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write(readBitbucketTestData(t, "commit-diff.txt"))
 	})
 }
 
-func newBitbucketRepositoryWithoutReadmeHandler(t *testing.T, mux *http.ServeMux) {
-	mux.HandleFunc("/repositories/test-workspace/test-repository-without-readme", func(w http.ResponseWriter, r *http.Request) {
+func newBitbucketCommitDiffStatHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/diffstat/def456abc123", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			w.WriteHeader(http.StatusMethodNotAllowed)
 			return
 		}
 		w.WriteHeader(http.StatusOK)
 		w.Header().Set("Content-Type", "application/json")
-		w.Write(readBitbucketTestData(t, "repository.json"))
+		w.Write(readBitbucketTestData(t, "commit-diffstat.json"))
 	})
 }
 
-func newBitbucketRepositoryNotFoundHandler(t *testing.T, mux *http.ServeMux) {
-	mux.HandleFunc("/repositories/test-workspace/invalid-repository", func(w http.ResponseWriter, r *http.Request) {
+func newBitbucketCompareDiffHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/diff/{spec}", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			w.WriteHeader(http.StatusMethodNotAllowed)
 			return
 		}
-		w.WriteHeader(http.StatusNotFound)
-		w.Header().Set("Content-Type", "application/json")
-		w.Write(readBitbucketTestData(t, "not-found.json"))
+		if r.PathValue("spec") != "main..release-1.2" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write(readBitbucketTestData(t, "commit-not-found.json"))
+			return
+		}
+		if r.URL.Query().Has("path") {
+			assert.Equal(t, "src/app", r.URL.Query().Get("path"))
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write(readBitbucketTestData(t, "compare-diff.txt"))
 	})
 }
 
-func newBitbucketRepositorySourceHandler(t *testing.T, mux *http.ServeMux) {
-	mux.HandleFunc("/repositories/test-workspace/test-repository/src", func(w http.ResponseWriter, r *http.Request) {
+func newBitbucketCompareDiffStatHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/diffstat/main..release-1.2", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			w.WriteHeader(http.StatusMethodNotAllowed)
 			return
 		}
+		if r.URL.Query().Has("path") {
+			assert.Equal(t, "src/app", r.URL.Query().Get("path"))
+		}
 		w.WriteHeader(http.StatusOK)
 		w.Header().Set("Content-Type", "application/json")
-		w.Write(readBitbucketTestData(t, "repository-source.json"))
+		w.Write(readBitbucketTestData(t, "compare-diffstat.json"))
 	})
 }
 
-func newBitbucketRepositorySourceWithoutReadmeHandler(t *testing.T, mux *http.ServeMux) {
-	mux.HandleFunc("/repositories/test-workspace/test-repository-without-readme/src", func(w http.ResponseWriter, r *http.Request) {
+func newBitbucketRefsHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/refs", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			w.WriteHeader(http.StatusMethodNotAllowed)
 			return
 		}
+		assert.Equal(t, "-target.date", r.URL.Query().Get("sort"))
 		w.WriteHeader(http.StatusOK)
 		w.Header().Set("Content-Type", "application/json")
-		w.Write(readBitbucketTestData(t, "repository-source-without-readme.json"))
+		w.Write(readBitbucketTestData(t, "refs.json"))
 	})
 }
 
-func newBitbucketRepositorySourceNotFoundHandler(t *testing.T, mux *http.ServeMux) {
-	mux.HandleFunc("/repositories/test-workspace/invalid-repository/src", func(w http.ResponseWriter, r *http.Request) {
+func newBitbucketBranchesHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/refs/branches", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			query := r.URL.Query()
+			assert.Equal(t, `(name ~ "release")`, query.Get("q"))
+			assert.Equal(t, "name", query.Get("sort"))
+			w.WriteHeader(http.StatusOK)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(readBitbucketTestData(t, "branches.json"))
+		case http.MethodPost:
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "feature/login", body["name"])
+			assert.Equal(t, map[string]any{"hash": "def456abc123456789012345678901234567890ab"}, body["target"])
+			w.WriteHeader(http.StatusCreated)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(readBitbucketTestData(t, "branch-created.json"))
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func newBitbucketTagsHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/refs/tags", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, "-target.date", r.URL.Query().Get("sort"))
+			w.WriteHeader(http.StatusOK)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(readBitbucketTestData(t, "tags.json"))
+		case http.MethodPost:
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "v1.2.0", body["name"])
+			assert.Equal(t, map[string]any{"hash": "def456abc123456789012345678901234567890ab"}, body["target"])
+			assert.Equal(t, "Release 1.2.0", body["message"])
+			w.WriteHeader(http.StatusCreated)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(readBitbucketTestData(t, "tag-created.json"))
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func newBitbucketDeleteRefHandler(t *testing.T, mux *http.ServeMux, kind string, name string) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/refs/"+kind+"/{name...}", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if r.PathValue("name") != name {
+			w.WriteHeader(http.StatusNotFound)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(readBitbucketTestData(t, "not-found.json"))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+const (
+	testPipelineUUID          = "{9f1c2e3a-4b5d-4e6f-8a9b-0c1d2e3f4a5b}"
+	testRunningPipelineUUID   = "{3c4d5e6f-7a8b-4c9d-8e0f-1a2b3c4d5e6f}"
+	testWaitedPipelineUUID    = "{8a9b0c1d-2e3f-4a5b-8c6d-7e8f9a0b1c2d}"
+	testFailedStepUUID        = "{b2c3d4e5-f6a7-4b8c-9d0e-1f2a3b4c5d6e}"
+	testVariablesPipelineUUID = "{5d6e7f8a-9b0c-4d1e-8f2a-3b4c5d6e7f8a}"
+)
+
+func newBitbucketPipelinesHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/pipelines", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			target := body["target"].(map[string]any)
+			switch target["type"] {
+			case "pipeline_ref_target":
+				assert.Equal(t, "main", target["ref_name"])
+				if selector, ok := target["selector"].(map[string]any); ok && selector["type"] == "branches" {
+					assert.Equal(t, "def456abc123456789012345678901234567890ab", target["commit"].(map[string]any)["hash"])
+					assert.Equal(t, []any{
+						map[string]any{"type": "pipeline_variable", "key": "GO_FLAGS", "value": "-race"},
+						map[string]any{"type": "pipeline_variable", "key": "DEPLOY_TOKEN", "value": "secret", "secured": true},
+					}, body["variables"])
+				}
+			case "pipeline_commit_target":
+				assert.Equal(t, map[string]any{"type": "custom", "pattern": "deploy-staging"}, target["selector"])
+				assert.Equal(t, []any{map[string]any{"type": "pipeline_variable", "key": "ENV", "value": "staging"}}, body["variables"])
+			default:
+				t.Errorf("unexpected pipeline target type %v", target["type"])
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			w.Write(readBitbucketTestData(t, "pipeline-triggered.json"))
+			return
+		}
 		if r.Method != http.MethodGet {
 			w.WriteHeader(http.StatusMethodNotAllowed)
 			return
 		}
-		w.WriteHeader(http.StatusNotFound)
+		assert.Equal(t, "-created_on", r.URL.Query().Get("sort"))
+		if branch := r.URL.Query().Get("target.branch"); branch != "" {
+			assert.Equal(t, "main", branch)
+			assert.Equal(t, "FAILED", r.URL.Query().Get("status"))
+			assert.Equal(t, "10", r.URL.Query().Get("pagelen"))
+		} else {
+			assert.Equal(t, "20", r.URL.Query().Get("pagelen"))
+		}
 		w.Header().Set("Content-Type", "application/json")
-		w.Write(readBitbucketTestData(t, "not-found.json"))
+		w.WriteHeader(http.StatusOK)
+		w.Write(readBitbucketTestData(t, "pipelines.json"))
 	})
 }
 
-func newBitbucketFileSourceReadmeHandler(t *testing.T, mux *http.ServeMux) {
-	mux.HandleFunc("/repositories/test-workspace/test-repository/src/abc123def456/README.md", func(w http.ResponseWriter, r *http.Request) {
+func newBitbucketPipelineHandler(t *testing.T, mux *http.ServeMux) {
+	var waitPolls atomic.Int32
+	mux.HandleFunc("/repositories/test-workspace/test-repository/pipelines/{pipeline}", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			w.WriteHeader(http.StatusMethodNotAllowed)
 			return
 		}
-		w.WriteHeader(http.StatusOK)
-		w.Header().Set("Content-Type", "text/plain")
-		w.Write(readBitbucketTestData(t, "file-source-readme.md"))
+		w.Header().Set("Content-Type", "application/json")
+		switch r.PathValue("pipeline") {
+		case "42", testPipelineUUID:
+			w.WriteHeader(http.StatusOK)
+			w.Write(readBitbucketTestData(t, "pipeline.json"))
+		case "43", testRunningPipelineUUID:
+			w.WriteHeader(http.StatusOK)
+			w.Write(readBitbucketTestData(t, "pipeline-running.json"))
+		case "45", testWaitedPipelineUUID:
+			w.WriteHeader(http.StatusOK)
+			if waitPolls.Add(1) == 1 {
+				w.Write(readBitbucketTestData(t, "pipeline-waiting.json"))
+			} else {
+				w.Write(readBitbucketTestData(t, "pipeline-waited.json"))
+			}
+		case "46", testVariablesPipelineUUID:
+			w.WriteHeader(http.StatusOK)
+			w.Write(readBitbucketTestData(t, "pipeline-with-variables.json"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write(readBitbucketTestData(t, "pipeline-not-found.json"))
+		}
 	})
 }
 
-func readBitbucketTestData(t *testing.T, filename string) []byte {
-	t.Helper()
-	return readTestData(t, filepath.Join("bitbucket", filename))
+func newBitbucketStopPipelineHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/pipelines/{pipeline}/stopPipeline", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if r.PathValue("pipeline") != testRunningPipelineUUID {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(readBitbucketTestData(t, "pipeline-stop-rejected.json"))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
 }
 
-func readMcpServerTestData(t *testing.T, filename string) []byte {
-	t.Helper()
-	return readTestData(t, filepath.Join("mcpserver", filename))
+func newBitbucketPipelineStepsHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/pipelines/{pipeline}/steps", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		assert.Contains(t, []string{testPipelineUUID, testRunningPipelineUUID, testWaitedPipelineUUID}, r.PathValue("pipeline"))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(readBitbucketTestData(t, "pipeline-steps.json"))
+	})
 }
 
-func readTestData(t *testing.T, filename string) []byte {
-	t.Helper()
+func newBitbucketPipelineStepLogHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/pipelines/{pipeline}/steps/{step}/log", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		assert.Contains(t, []string{testPipelineUUID, testRunningPipelineUUID, testWaitedPipelineUUID}, r.PathValue("pipeline"))
+		if r.PathValue("step") != testFailedStepUUID {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write(readBitbucketTestData(t, "not-found.json"))
+			return
+		}
 
-	data, err := os.ReadFile(filepath.Join("testdata", filename))
-	require.NoError(t, err, fmt.Sprintf("failed to read test data file %s", filename))
-	return data
+		log := readBitbucketTestData(t, "pipeline-step-log.txt")
+		size := len(log)
+		var start, end int
+		if suffix, ok := strings.CutPrefix(r.Header.Get("Range"), "bytes=-"); ok {
+			length, err := strconv.Atoi(suffix)
+			require.NoError(t, err)
+			start, end = max(size-length, 0), size-1
+		} else {
+			_, err := fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-%d", &start, &end)
+			require.NoError(t, err)
+			end = min(end, size-1)
+		}
+
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, size))
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write(log[start : end+1])
+	})
 }
 
-func readResponseBody(t *testing.T, resp *http.Response) string {
-	t.Helper()
+func newBitbucketCommitStatusesHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/commit/{hash}/statuses", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		assert.Equal(t, "100", r.URL.Query().Get("pagelen"))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if r.PathValue("hash") != "def456ghi789" {
+			w.Write([]byte(`{"pagelen": 100, "size": 0, "page": 1, "values": []}`))
+			return
+		}
+		w.Write(readBitbucketTestData(t, "commit-statuses.json"))
+	})
+}
 
-	var body string
-	err := web.ReadResponseText(resp, &body)
-	require.NoError(t, err, "failed to read response body")
-	return body
+func newBitbucketCreateBuildStatusHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/commit/{hash}/statuses/build", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.PathValue("hash") != "def456ghi789" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write(readBitbucketTestData(t, "commit-not-found.json"))
+			return
+		}
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]any{
+			"key":         "agent-review",
+			"state":       "SUCCESSFUL",
+			"name":        "Agent review",
+			"url":         "https://agents.example.com/reviews/15",
+			"description": "No issues found",
+			"refname":     "feature-branch",
+		}, body)
+		w.WriteHeader(http.StatusCreated)
+		w.Write(readBitbucketTestData(t, "build-status-reported.json"))
+	})
 }
 
-type oauthTransport struct {
-	base  http.RoundTripper
-	token string
+func newBitbucketReportsHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/commit/{hash}/reports", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		assert.Equal(t, "100", r.URL.Query().Get("pagelen"))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if r.PathValue("hash") != "def456ghi789" {
+			w.Write([]byte(`{"pagelen": 100, "size": 0, "page": 1, "values": []}`))
+			return
+		}
+		w.Write(readBitbucketTestData(t, "reports.json"))
+	})
 }
 
-func (t *oauthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	req2 := req.Clone(req.Context())
-	req2.Header.Set("Authorization", "Bearer "+t.token)
-	return t.base.RoundTrip(req2)
+// reportBodies lists the report bodies expected by the report handler by report ID.
+var reportBodies = map[string]map[string]any{
+	"eslint": {
+		"title":       "ESLint",
+		"details":     "3 findings: 1 errors, 2 warnings, 0 notes.",
+		"report_type": "BUG",
+		"reporter":    "ESLint",
+		"link":        "https://eslint.org",
+		"result":      "FAILED",
+		"data": []any{
+			map[string]any{"title": "Errors", "type": "NUMBER", "value": float64(1)},
+			map[string]any{"title": "Warnings", "type": "NUMBER", "value": float64(2)},
+			map[string]any{"title": "Notes", "type": "NUMBER", "value": float64(0)},
+		},
+	},
+	"semgrep-oss": {
+		"title":       "Semgrep OSS",
+		"details":     "2 findings: 0 errors, 2 warnings, 0 notes.",
+		"report_type": "SECURITY",
+		"reporter":    "Semgrep OSS",
+		"link":        "https://semgrep.dev",
+		"result":      "PASSED",
+		"data": []any{
+			map[string]any{"title": "Errors", "type": "NUMBER", "value": float64(0)},
+			map[string]any{"title": "Warnings", "type": "NUMBER", "value": float64(2)},
+			map[string]any{"title": "Notes", "type": "NUMBER", "value": float64(0)},
+		},
+	},
+	"agent-review": {
+		"title":       "Agent review",
+		"details":     "One issue found.",
+		"report_type": "BUG",
+		"result":      "FAILED",
+	},
 }
 
-func newBitbucketPullRequestHandler(t *testing.T, mux *http.ServeMux) {
-	mux.HandleFunc("/repositories/test-workspace/test-repository/pullrequests/1", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
+// annotationBodies lists the annotations expected by the annotations handler by report ID.
+var annotationBodies = map[string][]any{
+	"eslint": {
+		map[string]any{
+			"external_id":     "no-unused-vars-1",
+			"annotation_type": "BUG",
+			"path":            "src/main.js",
+			"line":            float64(12),
+			"summary":         "'result' is assigned a value but never used.",
+			"details":         "Disallow unused variables",
+			"severity":        "HIGH",
+			"link":            "https://eslint.org/docs/latest/rules/no-unused-vars",
+		},
+		map[string]any{
+			"external_id":     "eqeqeq-2",
+			"annotation_type": "CODE_SMELL",
+			"path":            "src/utils.js",
+			"line":            float64(4),
+			"summary":         "Expected '===' and instead saw '=='.",
+			"details":         "Require the use of === and !==",
+			"severity":        "MEDIUM",
+			"link":            "https://eslint.org/docs/latest/rules/eqeqeq",
+		},
+		map[string]any{
+			"external_id":     "eqeqeq-3",
+			"annotation_type": "CODE_SMELL",
+			"path":            "src/my utils.js",
+			"line":            float64(9),
+			"summary":         "Expected '!==' and instead saw '!='.",
+			"details":         "Require the use of === and !==",
+			"severity":        "MEDIUM",
+			"link":            "https://eslint.org/docs/latest/rules/eqeqeq",
+		},
+	},
+	"semgrep-oss": {
+		map[string]any{
+			"external_id":     "3f2a9c1b7e4d",
+			"annotation_type": "VULNERABILITY",
+			"path":            "src/db.js",
+			"line":            float64(27),
+			"summary":         "Query built from user input.",
+			"details":         "Untrusted input concatenated into a SQL query.",
+			"severity":        "CRITICAL",
+			"link":            "https://semgrep.dev/r/javascript.lang.security.sql-injection",
+		},
+		map[string]any{
+			"external_id":     "3f2a9c1b7e4d-2",
+			"annotation_type": "VULNERABILITY",
+			"path":            "src/db.js",
+			"line":            float64(27),
+			"summary":         "Query built from request parameters.",
+			"details":         "Untrusted input concatenated into a SQL query.",
+			"severity":        "CRITICAL",
+			"link":            "https://semgrep.dev/r/javascript.lang.security.sql-injection",
+		},
+	},
+	"agent-review": {
+		map[string]any{
+			"external_id":     "missing-check",
+			"annotation_type": "BUG",
+			"path":            "src/api.js",
+			"line":            float64(42),
+			"summary":         "The response status is not checked.",
+			"severity":        "HIGH",
+		},
+	},
+}
+
+func newBitbucketReportHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/commit/{hash}/reports/{report}", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
 			w.WriteHeader(http.StatusMethodNotAllowed)
 			return
 		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.PathValue("hash") != "def456ghi789" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write(readBitbucketTestData(t, "commit-not-found.json"))
+			return
+		}
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, reportBodies[r.PathValue("report")], body)
+
+		body["type"] = "report"
+		body["uuid"] = "{" + r.PathValue("report") + "-uuid}"
+		body["external_id"] = r.PathValue("report")
+		body["created_on"] = "2023-01-15T12:00:00.000000+00:00"
+		body["updated_on"] = "2023-01-15T12:00:00.000000+00:00"
 		w.WriteHeader(http.StatusOK)
+		require.NoError(t, json.NewEncoder(w).Encode(body))
+	})
+}
+
+func newBitbucketReportAnnotationsHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/commit/{hash}/reports/{report}/annotations", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
-		w.Write(readBitbucketTestData(t, "pull-request.json"))
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, "100", r.URL.Query().Get("pagelen"))
+			w.WriteHeader(http.StatusOK)
+			if r.PathValue("report") != "eslint" {
+				w.Write([]byte(`{"pagelen": 100, "size": 0, "page": 1, "values": []}`))
+				return
+			}
+			w.Write(readBitbucketTestData(t, "report-annotations.json"))
+		case http.MethodPost:
+			var body []map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			expected := make([]map[string]any, 0, len(body))
+			for _, annotation := range annotationBodies[r.PathValue("report")] {
+				expected = append(expected, annotation.(map[string]any))
+			}
+			assert.Equal(t, expected, body)
+
+			for _, annotation := range body {
+				annotation["type"] = "report_annotation"
+				annotation["uuid"] = fmt.Sprintf("{%s-uuid}", annotation["external_id"])
+			}
+			w.WriteHeader(http.StatusOK)
+			require.NoError(t, json.NewEncoder(w).Encode(body))
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
 	})
 }
 
-func newBitbucketPullRequestNotFoundHandler(t *testing.T, mux *http.ServeMux) {
-	mux.HandleFunc("/repositories/test-workspace/test-repository/pullrequests/999", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
+func newBitbucketIssuesHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/issues", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			query := r.URL.Query()
+			if query.Get("q") != "" {
+				assert.Equal(t, `(state = "open" OR state = "on hold") AND kind = "bug" AND (assignee.nickname = "devuser" OR assignee.account_id = "devuser")`, query.Get("q"))
+				assert.Equal(t, "-priority", query.Get("sort"))
+				assert.Equal(t, "10", query.Get("pagelen"))
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write(readBitbucketTestData(t, "issues.json"))
+		case http.MethodPost:
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, map[string]any{
+				"title":     "Crash on export",
+				"content":   map[string]any{"raw": "Exporting a report crashes the app."},
+				"kind":      "bug",
+				"priority":  "critical",
+				"assignee":  map[string]any{"uuid": "{new-reviewer-uuid}"},
+				"component": map[string]any{"name": "export"},
+			}, body)
+			w.WriteHeader(http.StatusCreated)
+			w.Write(readBitbucketTestData(t, "issue-created.json"))
+		default:
 			w.WriteHeader(http.StatusMethodNotAllowed)
-			return
 		}
-		w.WriteHeader(http.StatusNotFound)
-		w.Header().Set("Content-Type", "text/plain")
-		w.Write(readBitbucketTestData(t, "pull-request-not-found.txt"))
 	})
 }
 
-func newBitbucketPullRequestCommitsHandler(t *testing.T, mux *http.ServeMux) {
-	mux.HandleFunc("/repositories/test-workspace/test-repository/pullrequests/1/commits", func(w http.ResponseWriter, r *http.Request) {
+func newBitbucketIssueHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/issues/{id}", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			w.WriteHeader(http.StatusMethodNotAllowed)
 			return
 		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.PathValue("id") != "1" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write(readBitbucketTestData(t, "issue-not-found.json"))
+			return
+		}
 		w.WriteHeader(http.StatusOK)
+		w.Write(readBitbucketTestData(t, "issue.json"))
+	})
+}
+
+func newBitbucketIssueCommentsHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/repositories/test-workspace/test-repository/issues/{id}/comments", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
-		w.Write(readBitbucketTestData(t, "pull-request-commits.json"))
+		if r.PathValue("id") != "1" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write(readBitbucketTestData(t, "issue-not-found.json"))
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, "100", r.URL.Query().Get("pagelen"))
+			w.WriteHeader(http.StatusOK)
+			w.Write(readBitbucketTestData(t, "issue-comments.json"))
+		case http.MethodPost:
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, map[string]any{"content": map[string]any{"raw": "Fixed in the next release."}}, body)
+			w.WriteHeader(http.StatusCreated)
+			w.Write(readBitbucketTestData(t, "issue-comment-created.json"))
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
 	})
 }
 
-func newBitbucketPullRequestCommitsNotFoundHandler(t *testing.T, mux *http.ServeMux) {
-	mux.HandleFunc("/repositories/test-workspace/test-repository/pullrequests/999/commits", func(w http.ResponseWriter, r *http.Request) {
+func newBitbucketWorkspacesHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/user/permissions/workspaces", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			w.WriteHeader(http.StatusMethodNotAllowed)
 			return
 		}
-		w.WriteHeader(http.StatusNotFound)
-		w.Header().Set("Content-Type", "text/plain")
-		w.Write(readBitbucketTestData(t, "pull-request-not-found.txt"))
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(readBitbucketTestData(t, "workspaces.json"))
 	})
 }
 
-func newBitbucketPullRequestDiffHandler(t *testing.T, mux *http.ServeMux) {
-	mux.HandleFunc("/repositories/test-workspace/test-repository/pullrequests/1/diff", func(w http.ResponseWriter, r *http.Request) {
+func newBitbucketProjectsHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/workspaces/test-workspace/projects", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			w.WriteHeader(http.StatusMethodNotAllowed)
 			return
 		}
 		w.WriteHeader(http.StatusOK)
-		w.Header().Set("Content-Type", "text/plain")
-		w.Write(readBitbucketTestData(t, "pull-request-diff.txt"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(readBitbucketTestData(t, "projects.json"))
 	})
 }
 
-func newBitbucketPullRequestDiffNotFoundHandler(t *testing.T, mux *http.ServeMux) {
-	mux.HandleFunc("/repositories/test-workspace/test-repository/pullrequests/999/diff", func(w http.ResponseWriter, r *http.Request) {
+func newBitbucketProjectHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/workspaces/test-workspace/projects/{key}", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			w.WriteHeader(http.StatusMethodNotAllowed)
 			return
 		}
-		w.WriteHeader(http.StatusNotFound)
-		w.Header().Set("Content-Type", "text/plain")
-		w.Write(readBitbucketTestData(t, "pull-request-not-found.txt"))
+		w.Header().Set("Content-Type", "application/json")
+		if r.PathValue("key") != "TEST" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write(readBitbucketTestData(t, "project-not-found.json"))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write(readBitbucketTestData(t, "project.json"))
 	})
 }
 
-func newBitbucketPullRequestCommentsHandler(t *testing.T, mux *http.ServeMux) {
-	mux.HandleFunc("/repositories/test-workspace/test-repository/pullrequests/1/comments", func(w http.ResponseWriter, r *http.Request) {
+func newBitbucketUserEmailsHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/user/emails", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			w.WriteHeader(http.StatusMethodNotAllowed)
 			return
 		}
 		w.WriteHeader(http.StatusOK)
 		w.Header().Set("Content-Type", "application/json")
-		w.Write(readBitbucketTestData(t, "pull-request-comments.json"))
+		w.Write(readBitbucketTestData(t, "user-emails.json"))
 	})
 }
 
-func newBitbucketPullRequestCommentsNotFoundHandler(t *testing.T, mux *http.ServeMux) {
-	mux.HandleFunc("/repositories/test-workspace/test-repository/pullrequests/999/comments", func(w http.ResponseWriter, r *http.Request) {
+func newBitbucketUserEmailsForbiddenHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/user/emails", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write(readBitbucketTestData(t, "user-emails-forbidden.json"))
+	})
+}
+
+func newBitbucketCodeSearchHandler(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/workspaces/test-workspace/search/code", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			w.WriteHeader(http.StatusMethodNotAllowed)
 			return
 		}
-		w.WriteHeader(http.StatusNotFound)
-		w.Header().Set("Content-Type", "text/plain")
-		w.Write(readBitbucketTestData(t, "pull-request-not-found.txt"))
+		query := r.URL.Query()
+		assert.Equal(t, "+values.file.commit.repository", query.Get("fields"))
+		w.Header().Set("Content-Type", "application/json")
+		switch query.Get("search_query") {
+		case "NewClient":
+			assert.Equal(t, "10", query.Get("pagelen"))
+			assert.Equal(t, "1", query.Get("page"))
+		case `NewClient repo:test-repository lang:go ext:go path:internal/client`:
+			assert.Equal(t, "5", query.Get("pagelen"))
+			assert.Equal(t, "2", query.Get("page"))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(readBitbucketTestData(t, "code-search-invalid-query.json"))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write(readBitbucketTestData(t, "code-search.json"))
 	})
 }
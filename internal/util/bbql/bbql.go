@@ -0,0 +1,317 @@
+// Package bbql builds Bitbucket Query Language (BBQL) filter expressions and
+// sort parameters used by the "q" and "sort" query parameters of the Bitbucket API.
+//
+// Values are always rendered as BBQL literals, so user input can be passed
+// safely without manual quoting or escaping.
+//
+// Example:
+//
+//	query := bbql.New().
+//	  Where(bbql.Eq("state", "OPEN")).
+//	  Where(bbql.Or(bbql.Eq("author.nickname", "john"), bbql.Eq("reviewers.nickname", "john"))).
+//	  SortDesc("updated_on")
+//	query.Params()
+//	// map[q:state = "OPEN" AND (author.nickname = "john" OR reviewers.nickname = "john") sort:-updated_on]
+//
+// See: https://developer.atlassian.com/cloud/bitbucket/rest/intro/#filtering
+package bbql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Condition is a BBQL boolean expression that can be combined with other
+// conditions and negated.
+type Condition interface {
+	// String renders the condition as a BBQL expression.
+	// An empty string means the condition matches everything.
+	String() string
+	negate() Condition
+}
+
+// comparison is a single "field operator value" BBQL expression.
+type comparison struct {
+	field    string
+	operator string
+	value    string
+}
+
+// negations maps each BBQL comparison operator to its logical complement.
+var negations = map[string]string{
+	"=":  "!=",
+	"!=": "=",
+	"~":  "!~",
+	"!~": "~",
+	">":  "<=",
+	"<=": ">",
+	"<":  ">=",
+	">=": "<",
+}
+
+func (c comparison) String() string {
+	return c.field + " " + c.operator + " " + c.value
+}
+
+func (c comparison) negate() Condition {
+	return comparison{field: c.field, operator: negations[c.operator], value: c.value}
+}
+
+// group is a list of conditions joined by a single boolean operator.
+type group struct {
+	operator   string
+	conditions []Condition
+}
+
+func (g group) String() string {
+	nested := g.size() > 1
+	parts := make([]string, 0, len(g.conditions))
+	for _, cond := range g.conditions {
+		expr := cond.String()
+		if expr == "" {
+			continue
+		}
+		if sub, ok := cond.(group); ok && nested && sub.size() > 1 {
+			expr = "(" + expr + ")"
+		}
+		parts = append(parts, expr)
+	}
+	return strings.Join(parts, " "+g.operator+" ")
+}
+
+func (g group) negate() Condition {
+	operator := "OR"
+	if g.operator == "OR" {
+		operator = "AND"
+	}
+	conditions := make([]Condition, 0, len(g.conditions))
+	for _, cond := range g.conditions {
+		conditions = append(conditions, cond.negate())
+	}
+	return group{operator: operator, conditions: conditions}
+}
+
+// size returns the number of non-empty conditions in the group.
+func (g group) size() int {
+	n := 0
+	for _, cond := range g.conditions {
+		if cond.String() != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// Eq returns a condition matching fields equal to the value.
+func Eq(field string, value any) Condition {
+	return compare(field, "=", value)
+}
+
+// Ne returns a condition matching fields not equal to the value.
+func Ne(field string, value any) Condition {
+	return compare(field, "!=", value)
+}
+
+// Gt returns a condition matching fields greater than the value.
+func Gt(field string, value any) Condition {
+	return compare(field, ">", value)
+}
+
+// Ge returns a condition matching fields greater than or equal to the value.
+func Ge(field string, value any) Condition {
+	return compare(field, ">=", value)
+}
+
+// Lt returns a condition matching fields less than the value.
+func Lt(field string, value any) Condition {
+	return compare(field, "<", value)
+}
+
+// Le returns a condition matching fields less than or equal to the value.
+func Le(field string, value any) Condition {
+	return compare(field, "<=", value)
+}
+
+// Contains returns a condition matching string fields containing the value
+// (case-insensitive).
+func Contains(field string, value string) Condition {
+	return compare(field, "~", value)
+}
+
+// NotContains returns a condition matching string fields not containing the value
+// (case-insensitive).
+func NotContains(field string, value string) Condition {
+	return compare(field, "!~", value)
+}
+
+// In returns a condition matching fields equal to any of the values.
+// With no values it matches everything.
+func In[T any](field string, values ...T) Condition {
+	conditions := make([]Condition, 0, len(values))
+	for _, value := range values {
+		conditions = append(conditions, Eq(field, value))
+	}
+	return Or(conditions...)
+}
+
+// And returns a condition matching when all of the conditions match.
+// Empty conditions are ignored.
+func And(conditions ...Condition) Condition {
+	return group{operator: "AND", conditions: conditions}
+}
+
+// Or returns a condition matching when any of the conditions matches.
+// Empty conditions are ignored.
+func Or(conditions ...Condition) Condition {
+	return group{operator: "OR", conditions: conditions}
+}
+
+// Not returns the logical complement of the condition.
+// BBQL has no negation operator, so comparisons are inverted
+// (e.g., "=" becomes "!=") and groups are negated using De Morgan's laws.
+func Not(condition Condition) Condition {
+	return condition.negate()
+}
+
+func compare(field string, operator string, value any) Condition {
+	return comparison{field: field, operator: operator, value: Literal(value)}
+}
+
+// Literal renders a Go value as a BBQL literal.
+//
+// Strings (and fmt.Stringer values) are double-quoted with backslashes and quotes escaped,
+// time.Time values are rendered as unquoted ISO 8601 datetimes, booleans and numbers
+// as-is, and nil as null. Any other value is rendered as a quoted string of its
+// default format.
+func Literal(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return quote(v)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return "null"
+		}
+		return v.Format(time.RFC3339)
+	case bool:
+		return strconv.FormatBool(v)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%d", v)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case fmt.Stringer:
+		return quote(v.String())
+	default:
+		return quote(fmt.Sprint(v))
+	}
+}
+
+// quote turns a value into a BBQL string literal, escaping backslashes and double quotes.
+func quote(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return `"` + value + `"`
+}
+
+// Query combines a BBQL filter with a sort order.
+// The zero value (and a nil *Query) is an empty query adding no parameters.
+type Query struct {
+	conditions []Condition
+	raw        []string
+	sort       string
+}
+
+// New creates an empty query.
+func New() *Query {
+	return &Query{}
+}
+
+// Where adds a condition to the filter. All conditions are combined with AND.
+func (q *Query) Where(condition Condition) *Query {
+	q.conditions = append(q.conditions, condition)
+	return q
+}
+
+// WhereRaw adds a raw BBQL expression to the filter, combined with the other
+// conditions using AND. Blank expressions are ignored.
+//
+// The expression is not escaped, so it should come from a trusted source or
+// be a complete expression written by the caller (e.g., an MCP client).
+func (q *Query) WhereRaw(expr string) *Query {
+	if expr = strings.TrimSpace(expr); expr != "" {
+		q.raw = append(q.raw, expr)
+	}
+	return q
+}
+
+// Sort sets the field to sort results by. A field prefixed with "-" sorts
+// in descending order. An empty field removes sorting.
+func (q *Query) Sort(field string) *Query {
+	q.sort = strings.TrimSpace(field)
+	return q
+}
+
+// SortAsc sorts results by the field in ascending order.
+func (q *Query) SortAsc(field string) *Query {
+	return q.Sort(field)
+}
+
+// SortDesc sorts results by the field in descending order.
+func (q *Query) SortDesc(field string) *Query {
+	return q.Sort("-" + field)
+}
+
+// Filter renders the BBQL filter expression ("q" parameter).
+// Returns an empty string if the query has no conditions.
+func (q *Query) Filter() string {
+	if q == nil {
+		return ""
+	}
+	conditions := append([]Condition{}, q.conditions...)
+	for _, expr := range q.raw {
+		conditions = append(conditions, rawCondition(expr))
+	}
+	return And(conditions...).String()
+}
+
+// SortField returns the sort parameter, or an empty string if unsorted.
+func (q *Query) SortField() string {
+	if q == nil {
+		return ""
+	}
+	return q.sort
+}
+
+// Params returns the "q" and "sort" query parameters of the query.
+// Empty parameters are omitted; a nil query yields an empty map.
+func (q *Query) Params() map[string]string {
+	params := map[string]string{}
+	if filter := q.Filter(); filter != "" {
+		params["q"] = filter
+	}
+	if sort := q.SortField(); sort != "" {
+		params["sort"] = sort
+	}
+	return params
+}
+
+// rawCondition is a caller-provided BBQL expression. It is always parenthesized
+// so its own operators cannot leak into the surrounding filter.
+type rawCondition string
+
+func (r rawCondition) String() string {
+	return "(" + string(r) + ")"
+}
+
+// negate is never called: raw conditions are only created by Query.Filter
+// and are not exposed to callers of Not.
+func (r rawCondition) negate() Condition {
+	return r
+}
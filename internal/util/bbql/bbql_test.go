@@ -0,0 +1,253 @@
+package bbql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/branow/mcp-bitbucket/internal/util/bbql"
+	"github.com/stretchr/testify/assert"
+)
+
+type stringer struct{}
+
+func (stringer) String() string { return `say "hi"` }
+
+func TestLiteral(t *testing.T) {
+	date := time.Date(2024, 3, 15, 10, 30, 0, 0, time.FixedZone("", -7*60*60))
+
+	tests := []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{"nil", nil, "null"},
+		{"string", "OPEN", `"OPEN"`},
+		{"empty string", "", `""`},
+		{"string with quotes", `say "hi"`, `"say \"hi\""`},
+		{"string with backslash", `C:\path`, `"C:\\path"`},
+		{"string with backslash before quote", `\"`, `"\\\""`},
+		{"string with BBQL operators", `x" OR state = "MERGED`, `"x\" OR state = \"MERGED"`},
+		{"time", date, "2024-03-15T10:30:00-07:00"},
+		{"utc time", date.UTC(), "2024-03-15T17:30:00Z"},
+		{"time pointer", &date, "2024-03-15T10:30:00-07:00"},
+		{"nil time pointer", (*time.Time)(nil), "null"},
+		{"true", true, "true"},
+		{"false", false, "false"},
+		{"int", 42, "42"},
+		{"negative int", -7, "-7"},
+		{"int64", int64(9000000000), "9000000000"},
+		{"uint", uint(3), "3"},
+		{"float64", 1.5, "1.5"},
+		{"float32", float32(0.25), "0.25"},
+		{"stringer", stringer{}, `"say \"hi\""`},
+		{"other value", []string{"a"}, `"[a]"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, bbql.Literal(tt.value))
+		})
+	}
+}
+
+func TestComparisons(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition bbql.Condition
+		expected  string
+	}{
+		{"eq", bbql.Eq("state", "OPEN"), `state = "OPEN"`},
+		{"ne", bbql.Ne("state", "OPEN"), `state != "OPEN"`},
+		{"gt", bbql.Gt("size", 10), `size > 10`},
+		{"ge", bbql.Ge("size", 10), `size >= 10`},
+		{"lt", bbql.Lt("size", 10), `size < 10`},
+		{"le", bbql.Le("size", 10), `size <= 10`},
+		{"contains", bbql.Contains("title", "fix"), `title ~ "fix"`},
+		{"not contains", bbql.NotContains("title", "wip"), `title !~ "wip"`},
+		{"null", bbql.Eq("parent", nil), `parent = null`},
+		{"bool", bbql.Eq("is_private", true), `is_private = true`},
+		{"nested field", bbql.Eq("author.nickname", "john"), `author.nickname = "john"`},
+		{
+			"date",
+			bbql.Gt("updated_on", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+			`updated_on > 2024-01-02T03:04:05Z`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.condition.String())
+		})
+	}
+}
+
+func TestGrouping(t *testing.T) {
+	a := bbql.Eq("a", 1)
+	b := bbql.Eq("b", 2)
+	c := bbql.Eq("c", 3)
+
+	tests := []struct {
+		name      string
+		condition bbql.Condition
+		expected  string
+	}{
+		{"empty and", bbql.And(), ""},
+		{"empty or", bbql.Or(), ""},
+		{"single and", bbql.And(a), `a = 1`},
+		{"single or", bbql.Or(a), `a = 1`},
+		{"and", bbql.And(a, b, c), `a = 1 AND b = 2 AND c = 3`},
+		{"or", bbql.Or(a, b, c), `a = 1 OR b = 2 OR c = 3`},
+		{"or in and", bbql.And(a, bbql.Or(b, c)), `a = 1 AND (b = 2 OR c = 3)`},
+		{"and in or", bbql.Or(bbql.And(a, b), c), `(a = 1 AND b = 2) OR c = 3`},
+		{"single nested group", bbql.And(bbql.Or(a, b)), `a = 1 OR b = 2`},
+		{"nested single condition", bbql.And(a, bbql.Or(b)), `a = 1 AND b = 2`},
+		{"skips empty groups", bbql.And(bbql.Or(), a, bbql.And()), `a = 1`},
+		{"skips empty nested groups", bbql.And(a, bbql.Or(bbql.And(), b, c)), `a = 1 AND (b = 2 OR c = 3)`},
+		{"deep nesting", bbql.Or(a, bbql.And(b, bbql.Or(c, a))), `a = 1 OR (b = 2 AND (c = 3 OR a = 1))`},
+		{"in", bbql.In("state", "OPEN", "MERGED"), `state = "OPEN" OR state = "MERGED"`},
+		{"in single", bbql.In("state", "OPEN"), `state = "OPEN"`},
+		{"in empty", bbql.In[string]("state"), ""},
+		{"in ints", bbql.And(a, bbql.In("id", 1, 2)), `a = 1 AND (id = 1 OR id = 2)`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.condition.String())
+		})
+	}
+}
+
+func TestNot(t *testing.T) {
+	a := bbql.Eq("a", 1)
+	b := bbql.Contains("b", "x")
+
+	tests := []struct {
+		name      string
+		condition bbql.Condition
+		expected  string
+	}{
+		{"eq", bbql.Not(bbql.Eq("a", 1)), `a != 1`},
+		{"ne", bbql.Not(bbql.Ne("a", 1)), `a = 1`},
+		{"gt", bbql.Not(bbql.Gt("a", 1)), `a <= 1`},
+		{"ge", bbql.Not(bbql.Ge("a", 1)), `a < 1`},
+		{"lt", bbql.Not(bbql.Lt("a", 1)), `a >= 1`},
+		{"le", bbql.Not(bbql.Le("a", 1)), `a > 1`},
+		{"contains", bbql.Not(bbql.Contains("a", "x")), `a !~ "x"`},
+		{"not contains", bbql.Not(bbql.NotContains("a", "x")), `a ~ "x"`},
+		{"double negation", bbql.Not(bbql.Not(a)), `a = 1`},
+		{"and", bbql.Not(bbql.And(a, b)), `a != 1 OR b !~ "x"`},
+		{"or", bbql.Not(bbql.Or(a, b)), `a != 1 AND b !~ "x"`},
+		{"nested", bbql.Not(bbql.And(a, bbql.Or(a, b))), `a != 1 OR (a != 1 AND b !~ "x")`},
+		{"in", bbql.Not(bbql.In("state", "OPEN", "MERGED")), `state != "OPEN" AND state != "MERGED"`},
+		{"empty", bbql.Not(bbql.And()), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.condition.String())
+		})
+	}
+}
+
+func TestQuery(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  *bbql.Query
+		filter string
+		sort   string
+		params map[string]string
+	}{
+		{
+			"nil query",
+			nil,
+			"", "",
+			map[string]string{},
+		},
+		{
+			"empty query",
+			bbql.New(),
+			"", "",
+			map[string]string{},
+		},
+		{
+			"single condition",
+			bbql.New().Where(bbql.Eq("state", "OPEN")),
+			`state = "OPEN"`, "",
+			map[string]string{"q": `state = "OPEN"`},
+		},
+		{
+			"multiple conditions",
+			bbql.New().Where(bbql.Eq("state", "OPEN")).Where(bbql.Or(bbql.Eq("a", 1), bbql.Eq("b", 2))),
+			`state = "OPEN" AND (a = 1 OR b = 2)`, "",
+			map[string]string{"q": `state = "OPEN" AND (a = 1 OR b = 2)`},
+		},
+		{
+			"single or condition",
+			bbql.New().Where(bbql.Or(bbql.Eq("a", 1), bbql.Eq("b", 2))),
+			`a = 1 OR b = 2`, "",
+			map[string]string{"q": `a = 1 OR b = 2`},
+		},
+		{
+			"raw expression",
+			bbql.New().WhereRaw(`title ~ "x" OR title ~ "y"`),
+			`(title ~ "x" OR title ~ "y")`, "",
+			map[string]string{"q": `(title ~ "x" OR title ~ "y")`},
+		},
+		{
+			"blank raw expression",
+			bbql.New().WhereRaw("  "),
+			"", "",
+			map[string]string{},
+		},
+		{
+			"conditions and raw expression",
+			bbql.New().Where(bbql.Or(bbql.Eq("a", 1), bbql.Eq("b", 2))).WhereRaw(`c = 3`),
+			`(a = 1 OR b = 2) AND (c = 3)`, "",
+			map[string]string{"q": `(a = 1 OR b = 2) AND (c = 3)`},
+		},
+		{
+			"empty condition",
+			bbql.New().Where(bbql.And()),
+			"", "",
+			map[string]string{},
+		},
+		{
+			"sort",
+			bbql.New().Sort(" updated_on "),
+			"", "updated_on",
+			map[string]string{"sort": "updated_on"},
+		},
+		{
+			"sort asc",
+			bbql.New().SortAsc("name"),
+			"", "name",
+			map[string]string{"sort": "name"},
+		},
+		{
+			"sort desc",
+			bbql.New().SortDesc("updated_on"),
+			"", "-updated_on",
+			map[string]string{"sort": "-updated_on"},
+		},
+		{
+			"sort overridden",
+			bbql.New().SortDesc("updated_on").Sort(""),
+			"", "",
+			map[string]string{},
+		},
+		{
+			"filter and sort",
+			bbql.New().Where(bbql.Eq("state", "OPEN")).SortDesc("created_on"),
+			`state = "OPEN"`, "-created_on",
+			map[string]string{"q": `state = "OPEN"`, "sort": "-created_on"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.filter, tt.query.Filter())
+			assert.Equal(t, tt.sort, tt.query.SortField())
+			assert.Equal(t, tt.params, tt.query.Params())
+		})
+	}
+}
@@ -4,7 +4,11 @@
 // for creating consistent error responses throughout the application.
 package util
 
-import "github.com/modelcontextprotocol/go-sdk/jsonrpc"
+import (
+	"errors"
+
+	"github.com/modelcontextprotocol/go-sdk/jsonrpc"
+)
 
 // JSON-RPC error codes used throughout the application.
 const (
@@ -49,3 +53,19 @@ func NewInternalError() error {
 		Message: "Internal server error",
 	}
 }
+
+// IsInvalidParamsError reports whether the error is a JSON-RPC error for invalid parameters,
+// which Bitbucket client errors become for 4xx responses other than 404.
+func IsInvalidParamsError(err error) bool {
+	return hasErrorCode(err, CodeInvalidParamsErr)
+}
+
+// IsResourceNotFoundError reports whether the error is a JSON-RPC error for a missing resource.
+func IsResourceNotFoundError(err error) bool {
+	return hasErrorCode(err, CodeResourceNotFoundErr)
+}
+
+func hasErrorCode(err error, code int64) bool {
+	var rpcErr *jsonrpc.Error
+	return errors.As(err, &rpcErr) && rpcErr.Code == code
+}
@@ -0,0 +1,33 @@
+package util_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/branow/mcp-bitbucket/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorCodes(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		invalidParams bool
+		notFound      bool
+	}{
+		{name: "nil", err: nil},
+		{name: "plain error", err: errors.New("boom")},
+		{name: "invalid params", err: util.NewInvalidParamsError("bad"), invalidParams: true},
+		{name: "wrapped invalid params", err: fmt.Errorf("context: %w", util.NewInvalidParamsError("bad")), invalidParams: true},
+		{name: "not found", err: util.NewResourceNotFoundError("missing"), notFound: true},
+		{name: "unavailable", err: util.NewResourceUnavailableError("down")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.invalidParams, util.IsInvalidParamsError(tt.err))
+			assert.Equal(t, tt.notFound, util.IsResourceNotFoundError(tt.err))
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // String creates a Required for string values.
@@ -48,3 +49,19 @@ func List(delimiter string) Required[[]string] {
 		return strings.Split(s, delimiter), nil
 	})
 }
+
+// Time creates a Required for time values.
+// The input must be an RFC 3339 timestamp (e.g., "2024-01-15T10:30:00Z")
+// or a date (e.g., "2024-01-15"), which is interpreted as midnight UTC.
+func Time() Required[time.Time] {
+	return NewSchema(func(s string) (time.Time, error) {
+		if value, err := time.Parse(time.RFC3339, s); err == nil {
+			return value, nil
+		}
+		value, err := time.Parse(time.DateOnly, s)
+		if err != nil {
+			return value, fmt.Errorf("expected RFC 3339 timestamp or date, got: '%s'", s)
+		}
+		return value, nil
+	})
+}
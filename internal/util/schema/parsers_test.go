@@ -3,6 +3,7 @@ package schema_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/branow/mcp-bitbucket/internal/util/schema"
 	"github.com/stretchr/testify/assert"
@@ -118,6 +119,37 @@ func TestListParser(t *testing.T) {
 	}
 }
 
+func TestTimeParser(t *testing.T) {
+	schema := schema.Time()
+
+	tests := []struct {
+		name     string
+		input    string
+		valid    bool
+		expected time.Time
+	}{
+		{"utc timestamp", "2024-01-15T10:30:00Z", true, time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)},
+		{"fractional seconds", "2024-01-15T10:30:00.123Z", true, time.Date(2024, 1, 15, 10, 30, 0, 123000000, time.UTC)},
+		{"date", "2024-01-15", true, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{"missing zone", "2024-01-15T10:30:00", false, time.Time{}},
+		{"invalid date", "2024-13-01", false, time.Time{}},
+		{"invalid letters", "yesterday", false, time.Time{}},
+		{"empty string", "", false, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testParser(t, schema, tt.input, tt.valid, tt.expected, fmt.Sprintf("expected RFC 3339 timestamp or date, got: '%v'", tt.input))
+		})
+	}
+
+	t.Run("timestamp with offset", func(t *testing.T) {
+		actual, err := schema.Parse("2024-01-15T12:30:00+02:00")
+		require.NoError(t, err)
+		assert.True(t, time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC).Equal(actual))
+	})
+}
+
 func testParser[T comparable](t *testing.T, schema schema.Required[T], in string, valid bool, expected T, errorContains string) {
 	t.Helper()
 	actual, err := schema.Parse(in)
@@ -30,6 +30,16 @@ func NonNegative() Validator[int] {
 	}
 }
 
+// Between returns a Validator that checks if an integer is within the inclusive range [min, max].
+func Between(min, max int) Validator[int] {
+	return func(i int) error {
+		if i < min || i > max {
+			return fmt.Errorf("expected integer between %d and %d, got: %d", min, max, i)
+		}
+		return nil
+	}
+}
+
 // NotBlank returns a Validator that checks if a string is not blank.
 // A string is considered blank if it is empty or contains only whitespace characters.
 func NotBlank() Validator[string] {
@@ -53,6 +53,29 @@ func TestNonNegativeValidator(t *testing.T) {
 	}
 }
 
+func TestBetweenValidator(t *testing.T) {
+	schema := schema.Int().Must(schema.Between(1, 50))
+
+	tests := []struct {
+		name  string
+		input string
+		valid bool
+	}{
+		{"lower bound", "1", true},
+		{"within range", "25", true},
+		{"upper bound", "50", true},
+		{"below lower bound", "0", false},
+		{"above upper bound", "51", false},
+		{"negative", "-5", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testValidator(t, schema, tt.input, tt.valid, "expected integer between 1 and 50")
+		})
+	}
+}
+
 func TestNotBlankValidator(t *testing.T) {
 	schema := schema.String().Must(schema.NotBlank())
 
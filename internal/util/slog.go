@@ -48,6 +48,13 @@ func (e *LogArgsExtractor) AddUrl(url string) *LogArgsExtractor {
 	return e
 }
 
+func (e *LogArgsExtractor) AddPullRequest(workspace string, repository string, id int) *LogArgsExtractor {
+	e.addArg("workspace", workspace)
+	e.addArg("repository", repository)
+	e.addArg("pull_request_id", id)
+	return e
+}
+
 func (e *LogArgsExtractor) Extract() []any {
 	argsList := make([]any, 0, len(e.args)*2)
 	for key, value := range e.args {
@@ -5,6 +5,7 @@ import (
 	"net/url"
 	"regexp"
 	"strings"
+	"sync"
 )
 
 // UriParams holds extracted parameters from a URI.
@@ -124,7 +125,9 @@ func normalizeRFC6570QueryParams(template string) string {
 //   - URL decoding is applied automatically (spaces, percent-encoding)
 //   - Query parameters missing from the URI will have empty string values in the result
 //   - Extra query parameters in the URI that aren't in the template are ignored
-//   - Path segments are compared after URL decoding
+//   - Path segments are compared after URL decoding, so an encoded slash (%2F)
+//     stays within its segment
+//   - A segment may combine several parameters with literals, e.g. "{from}..{to}"
 //
 // Returns an error if:
 //   - The URI cannot be parsed
@@ -144,7 +147,7 @@ func (p *UriTemplateParser) Parse(uri string) (*UriParams, error) {
 		return nil, fmt.Errorf("host mismatch: expected %s, got %s", p.Template.Host, actualUrl.Host)
 	}
 
-	pathParams, err := extractPathParams(p.Template.Path, actualUrl.Path)
+	pathParams, err := extractPathParams(p.Template.Path, actualUrl.EscapedPath())
 	if err != nil {
 		return nil, err
 	}
@@ -169,22 +172,59 @@ func extractPathParams(templatePath, actualPath string) (map[string]string, erro
 		return params, fmt.Errorf("path segment count mismatch: expected %d, got %d", len(templateSegments), len(actualSegments))
 	}
 
-	paramRegex := regexp.MustCompile(`^\{([^}]+)\}$`)
-
 	for i, templateSegment := range templateSegments {
-		if matches := paramRegex.FindStringSubmatch(templateSegment); matches != nil {
-			paramName := matches[1]
-			params[paramName] = actualSegments[i]
-		} else {
-			if templateSegment != actualSegments[i] {
-				return params, fmt.Errorf("path segment mismatch at position %d: expected %s, got %s", i, templateSegment, actualSegments[i])
+		actualSegment, err := url.PathUnescape(actualSegments[i])
+		if err != nil {
+			return params, fmt.Errorf("invalid path segment at position %d: %w", i, err)
+		}
+
+		names := placeholderRegex.FindAllStringSubmatch(templateSegment, -1)
+		if names == nil {
+			if templateSegment != actualSegment {
+				return params, fmt.Errorf("path segment mismatch at position %d: expected %s, got %s", i, templateSegment, actualSegment)
 			}
+			continue
+		}
+
+		matches := segmentRegex(templateSegment).FindStringSubmatch(actualSegment)
+		if matches == nil {
+			return params, fmt.Errorf("path segment mismatch at position %d: expected %s, got %s", i, templateSegment, actualSegment)
+		}
+		for j, name := range names {
+			params[name[1]] = matches[j+1]
 		}
 	}
 
 	return params, nil
 }
 
+var placeholderRegex = regexp.MustCompile(`\{([^}]+)\}`)
+
+// segmentRegexes caches the compiled regular expressions of template path segments,
+// so that each segment is compiled once rather than on every Parse.
+var segmentRegexes sync.Map
+
+// segmentRegex returns a regular expression matching a template path segment,
+// where every placeholder captures a non-empty value and literals between
+// placeholders must match exactly, e.g. "{from}..{to}" matches "v1.2..main".
+func segmentRegex(templateSegment string) *regexp.Regexp {
+	if re, ok := segmentRegexes.Load(templateSegment); ok {
+		return re.(*regexp.Regexp)
+	}
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	last := 0
+	for _, loc := range placeholderRegex.FindAllStringIndex(templateSegment, -1) {
+		pattern.WriteString(regexp.QuoteMeta(templateSegment[last:loc[0]]))
+		pattern.WriteString("(.+?)")
+		last = loc[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(templateSegment[last:]))
+	pattern.WriteString("$")
+	re, _ := segmentRegexes.LoadOrStore(templateSegment, regexp.MustCompile(pattern.String()))
+	return re.(*regexp.Regexp)
+}
+
 func extractQueryParams(templateQuery string, actualQuery url.Values) (map[string]string, error) {
 	params := make(map[string]string)
 
@@ -192,7 +232,6 @@ func extractQueryParams(templateQuery string, actualQuery url.Values) (map[strin
 		return params, nil
 	}
 
-	paramRegex := regexp.MustCompile(`\{([^}]+)\}`)
 	templatePairs := strings.Split(templateQuery, "&")
 
 	for _, pair := range templatePairs {
@@ -204,7 +243,7 @@ func extractQueryParams(templateQuery string, actualQuery url.Values) (map[strin
 		queryKey := parts[0]
 		templateValue := parts[1]
 
-		if matches := paramRegex.FindStringSubmatch(templateValue); matches != nil {
+		if matches := placeholderRegex.FindStringSubmatch(templateValue); matches != nil {
 			paramName := matches[1]
 			params[paramName] = actualQuery.Get(queryKey)
 		}
@@ -220,6 +220,36 @@ func TestParseUriParams_Success(t *testing.T) {
 			},
 		},
 
+		{
+			name:     "URL-encoded slash in path parameter",
+			template: "https://example.com/branches/{branch}/commits",
+			uri:      "https://example.com/branches/release%2F1.2/commits",
+			expected: &util.UriParams{
+				Path:  map[string]string{"branch": "release/1.2"}, // %2F stays within the segment
+				Query: map[string]string{},
+			},
+		},
+
+		// Multiple parameters in a single segment
+		{
+			name:     "two path parameters separated by a literal",
+			template: "mcp://bitbucket/{repo}/compare/{from}..{to}",
+			uri:      "mcp://bitbucket/web/compare/release-1.2..main",
+			expected: &util.UriParams{
+				Path:  map[string]string{"repo": "web", "from": "release-1.2", "to": "main"},
+				Query: map[string]string{},
+			},
+		},
+		{
+			name:     "path parameters with prefix and suffix literals",
+			template: "https://example.com/files/v{major}.{minor}.tar.gz",
+			uri:      "https://example.com/files/v1.24.tar.gz",
+			expected: &util.UriParams{
+				Path:  map[string]string{"major": "1", "minor": "24"},
+				Query: map[string]string{},
+			},
+		},
+
 		// Different schemes
 		{
 			name:     "http scheme",
@@ -538,6 +568,18 @@ func TestParseUriParams_Failure(t *testing.T) {
 		},
 
 		// Path segment literal mismatches
+		{
+			name:     "path segment missing separator between parameters",
+			template: "mcp://bitbucket/compare/{from}..{to}",
+			uri:      "mcp://bitbucket/compare/main",
+			errorMsg: "path segment mismatch",
+		},
+		{
+			name:     "path segment with empty parameter",
+			template: "mcp://bitbucket/compare/{from}..{to}",
+			uri:      "mcp://bitbucket/compare/..main",
+			errorMsg: "path segment mismatch",
+		},
 		{
 			name:     "path literal mismatch - first segment",
 			template: "https://api.example.com/users/{id}",
@@ -19,6 +19,7 @@ type Mime string
 const (
 	MimeApplicationJson   Mime = "application/json"
 	MimeTextPlain         Mime = "text/plain"
+	MimeTextMarkdown      Mime = "text/markdown"
 	MimeMultipartFormData Mime = "multipart/form-data"
 	MimeOmit              Mime = "" // indicates no content type should be set (no body)
 )